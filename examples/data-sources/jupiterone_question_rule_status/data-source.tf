@@ -0,0 +1,10 @@
+check "rule_evaluation" {
+  data "jupiterone_question_rule_status" "unencrypted_data_stores" {
+    id = jupiterone_rule.unencrypted_data_stores.id
+  }
+
+  assert {
+    condition     = data.jupiterone_question_rule_status.unencrypted_data_stores.evaluation_step != "FAILED"
+    error_message = "The rule ${data.jupiterone_question_rule_status.unencrypted_data_stores.name} is failing evaluation."
+  }
+}
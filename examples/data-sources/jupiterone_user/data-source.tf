@@ -0,0 +1,15 @@
+data "jupiterone_user" "auditor" {
+  email = "auditor@jupiterone.com"
+}
+
+resource "jupiterone_resource_permission" "auditor_dashboards" {
+  subject_type  = "user"
+  subject_id    = data.jupiterone_user.auditor.id
+  resource_area = "dashboard"
+  resource_type = "resource_group"
+  resource_id   = jupiterone_resource_group.engineering.id
+  can_create    = false
+  can_read      = true
+  can_update    = false
+  can_delete    = false
+}
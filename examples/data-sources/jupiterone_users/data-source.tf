@@ -0,0 +1,15 @@
+data "jupiterone_users" "administrators" {
+  group_id = data.jupiterone_user_group.administrators.id
+}
+
+data "jupiterone_users" "pending" {
+  status = "PENDING"
+}
+
+output "administrator_emails" {
+  value = data.jupiterone_users.administrators.users[*].email
+}
+
+output "pending_invitations" {
+  value = data.jupiterone_users.pending.users[*].email
+}
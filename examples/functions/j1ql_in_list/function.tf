@@ -0,0 +1,9 @@
+resource "jupiterone_question" "instances" {
+  title       = "Instances"
+  description = "Compute instances of all clouds"
+
+  query {
+    name  = "query0"
+    query = "FIND Host WITH ${provider::jupiterone::j1ql_in_list("_type", ["aws_instance", "azure_vm", "google_compute_instance"])}"
+  }
+}
@@ -0,0 +1,8 @@
+output "normalized_query" {
+  # FIND Host WITH active = TRUE THAT HAS Device
+  value = provider::jupiterone::j1ql_normalize(<<-EOT
+    find Host with active=true
+      that HAS Device
+  EOT
+  )
+}
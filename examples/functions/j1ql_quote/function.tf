@@ -0,0 +1,9 @@
+resource "jupiterone_question" "owned_hosts" {
+  title       = "Hosts of ${var.owner}"
+  description = "Hosts owned by ${var.owner}"
+
+  query {
+    name  = "query0"
+    query = "FIND Host WITH owner = ${provider::jupiterone::j1ql_quote(var.owner)}"
+  }
+}
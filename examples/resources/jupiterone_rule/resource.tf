@@ -148,3 +148,67 @@ resource "jupiterone_rule" "unencrypted_critical_data_stores_jira" {
   ]
 }
 
+resource "jupiterone_rule" "unencrypted_critical_data_stores_notify" {
+  name             = "unencrypted-critical-data-stores-notify"
+  description      = "Notify the security team of unencrypted critical data stores."
+  polling_interval = "ONE_DAY"
+
+  question {
+    queries {
+      name    = "query0"
+      query   = "Find DataStore with classification=('critical' or 'sensitive' or 'confidential' or 'restricted') and encrypted!=true"
+      version = "v1"
+    }
+  }
+
+  operations = [
+    {
+      condition = {
+        conditions = [
+          {
+            left     = "queries.query0.total"
+            operator = ">"
+            right    = "0"
+          }
+        ]
+      }
+      # The actions are executed in this order.
+      typed_actions = [
+        {
+          set_property = {
+            target_property = "alertLevel"
+            target_value    = jsonencode("HIGH")
+          }
+        },
+        {
+          json = jsonencode({
+            "type" : "CREATE_ALERT"
+          })
+        },
+        {
+          send_email = {
+            recipients = ["security@example.com"]
+            body       = "{{queries.query0.total}} unencrypted data stores: {{alertWebLink}}"
+          }
+        },
+        {
+          send_slack_message = {
+            integration_instance_id = "ec1a4975-7196-4f15-9466-2119c9d4aa19"
+            channels                = ["#security-alerts"]
+          }
+        },
+        {
+          tag_entities = {
+            entities = "{{queries.query0.data}}"
+            tags = [
+              {
+                name  = "unencrypted"
+                value = "true"
+              }
+            ]
+          }
+        },
+      ]
+    }
+  ]
+}
@@ -0,0 +1,11 @@
+resource "jupiterone_user_group_members" "insights_admin" {
+  group_id = jupiterone_user_group.insights_admin.id
+  emails = [
+    "existing.user@jupiterone.com",
+    "new.user@jupiterone.com",
+  ]
+}
+
+output "pending_insights_admins" {
+  value = jupiterone_user_group_members.insights_admin.pending_invitations
+}
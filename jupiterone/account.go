@@ -0,0 +1,89 @@
+package jupiterone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+)
+
+// resourceAccountIdAttribute is the optional per-resource account override.
+// The account is recorded in state so that the resource keeps being read,
+// updated and deleted in the account it was created in.
+func resourceAccountIdAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "JupiterOne account ID to manage the resource in. Defaults to the provider account_id. Changing this forces a new resource to be created.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			// Only an explicitly configured account moves the resource, state
+			// written before the attribute existed has no account recorded.
+			stringplanmodifier.RequiresReplaceIf(
+				func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+					resp.RequiresReplace = !req.ConfigValue.IsNull()
+				},
+				"Changing the configured account_id forces a new resource to be created.",
+				"Changing the configured `account_id` forces a new resource to be created.",
+			),
+		},
+	}
+}
+
+// dataSourceAccountIdAttribute is the optional per-data-source account
+// override.
+func dataSourceAccountIdAttribute() datasourceschema.StringAttribute {
+	return datasourceschema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "JupiterOne account ID to read from. Defaults to the provider account_id.",
+	}
+}
+
+// withAccountContext scopes the API requests made with the returned context
+// to the account_id of a resource or data source. When it is not set the
+// provider account is used, and the returned value is that account so it can
+// be recorded in state.
+func withAccountContext(ctx context.Context, qlient graphql.Client, accountId types.String) (context.Context, types.String) {
+	if !accountId.IsNull() && !accountId.IsUnknown() && accountId.ValueString() != "" {
+		return client.WithAccount(ctx, accountId.ValueString()), accountId
+	}
+
+	if accounts, ok := qlient.(*client.AccountQlients); ok && accounts.DefaultAccountID() != "" {
+		return ctx, types.StringValue(accounts.DefaultAccountID())
+	}
+
+	return ctx, types.StringNull()
+}
+
+// importStateWithAccount imports a resource by the ID given to
+// `terraform import`, written to the attribute at attrPath. An ID of the form
+// `<account_id>/<id>` imports the resource from that account instead of the
+// provider account.
+func importStateWithAccount(ctx context.Context, attrPath path.Path, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	accountId, id, found := strings.Cut(req.ID, "/")
+	if !found {
+		resource.ImportStatePassthroughID(ctx, attrPath, req, resp)
+		return
+	}
+
+	if accountId == "" || id == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form <id> or <account_id>/<id>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, attrPath, id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("account_id"), accountId)...)
+}
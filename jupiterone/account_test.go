@@ -0,0 +1,55 @@
+package jupiterone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// importQuestion runs the import of a question resource with the import ID
+// and returns the id and account_id attributes of the imported state.
+func importQuestion(t *testing.T, importId string) (types.String, types.String, *resource.ImportStateResponse) {
+	ctx := context.TODO()
+
+	r := NewQuestionResource().(*QuestionResource)
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: importId}, resp)
+
+	var id, accountId types.String
+	if !resp.Diagnostics.HasError() {
+		require.False(t, resp.State.GetAttribute(ctx, path.Root("id"), &id).HasError())
+		require.False(t, resp.State.GetAttribute(ctx, path.Root("account_id"), &accountId).HasError())
+	}
+	return id, accountId, resp
+}
+
+func TestImportStateWithAccount(t *testing.T) {
+	id, accountId, resp := importQuestion(t, "question-id")
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.Equal(t, "question-id", id.ValueString())
+	assert.True(t, accountId.IsNull(), "the provider account is used")
+
+	id, accountId, resp = importQuestion(t, "other-account/question-id")
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.Equal(t, "question-id", id.ValueString())
+	assert.Equal(t, "other-account", accountId.ValueString())
+
+	_, _, resp = importQuestion(t, "other-account/")
+	assert.True(t, resp.Diagnostics.HasError())
+}
@@ -0,0 +1,251 @@
+package jupiterone
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/dnaeon/go-vcr.v3/cassette"
+	"gopkg.in/dnaeon/go-vcr.v3/recorder"
+)
+
+// cassetteRecorder replays the interactions of a cassette by GraphQL
+// operation instead of by the order of the requests, and explains requests
+// that are not in the cassette with a diff against the recorded requests of
+// the same operation.
+type cassetteRecorder struct {
+	*recorder.Recorder
+	cassetteName string
+}
+
+func newCassetteRecorder(cassetteName string) (*cassetteRecorder, error) {
+	rec, err := recorder.NewWithOptions(&recorder.Options{
+		CassetteName:       cassetteName,
+		Mode:               recorder.ModeRecordOnce,
+		RealTransport:      cleanhttp.DefaultTransport(),
+		SkipRequestLatency: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rec.SetMatcher(matchGraphQLOperation)
+	rec.AddHook(stripHeadersFromCassetteInteraction, recorder.BeforeSaveHook)
+
+	return &cassetteRecorder{Recorder: rec, cassetteName: cassetteName}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *cassetteRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.Recorder.RoundTrip(req)
+	if errors.Is(err, cassette.ErrInteractionNotFound) {
+		return nil, r.mismatchError(req)
+	}
+	return resp, err
+}
+
+// mismatchError describes how the request differs from the recorded requests
+// of the same operation, or lists the recorded operations when there are none.
+func (r *cassetteRecorder) mismatchError(req *http.Request) error {
+	requested, ok := parseGraphQLOperation(requestBody(req))
+	if !ok {
+		return fmt.Errorf("cassette %s has no interaction for %s %s", r.cassetteName, req.Method, req.URL)
+	}
+
+	c, err := cassette.Load(r.cassetteName)
+	if err != nil {
+		return fmt.Errorf("cassette %s has no interaction for %s: %w", r.cassetteName, requested.OperationName, err)
+	}
+
+	var diffs []string
+	var recordedNames []string
+	for _, i := range c.Interactions {
+		recorded, ok := parseGraphQLOperation([]byte(i.Request.Body))
+		if !ok {
+			continue
+		}
+		recordedNames = append(recordedNames, recorded.OperationName)
+		if recorded.OperationName != requested.OperationName {
+			continue
+		}
+
+		diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(recorded.String()),
+			B:        difflib.SplitLines(requested.String()),
+			FromFile: fmt.Sprintf("recorded interaction %d", i.ID),
+			ToFile:   "request",
+			Context:  3,
+		})
+		if diff == "" {
+			diff = fmt.Sprintf("recorded interaction %d is identical but was already replayed\n", i.ID)
+		}
+		diffs = append(diffs, diff)
+	}
+
+	if len(diffs) == 0 {
+		return fmt.Errorf("cassette %s has no %s request, it recorded: %s\n\n"+
+			"Re-record the cassette if the test changed.",
+			r.cassetteName, requested.OperationName, strings.Join(recordedNames, ", "))
+	}
+
+	return fmt.Errorf("cassette %s has no %s request with the same variables:\n\n%s\n"+
+		"Re-record the cassette if the test changed.",
+		r.cassetteName, requested.OperationName, strings.Join(diffs, "\n"))
+}
+
+// matchGraphQLOperation matches GraphQL requests by operation name and
+// normalized variables. Other requests, such as OAuth token requests, are
+// matched by method.
+func matchGraphQLOperation(req *http.Request, c cassette.Request) bool {
+	// ignore hostname prefixes and URI paths on replays
+	if req.Method != c.Method || !strings.HasSuffix(req.Host, "jupiterone.io") {
+		return false
+	}
+
+	requested, requestedOk := parseGraphQLOperation(requestBody(req))
+	recorded, recordedOk := parseGraphQLOperation([]byte(c.Body))
+	if !requestedOk || !recordedOk {
+		return requestedOk == recordedOk
+	}
+
+	return requested.String() == recorded.String()
+}
+
+// graphQLOperation identifies a GraphQL request in a cassette.
+type graphQLOperation struct {
+	OperationName string      `json:"operationName"`
+	Variables     interface{} `json:"variables"`
+}
+
+func parseGraphQLOperation(body []byte) (graphQLOperation, bool) {
+	var op graphQLOperation
+	if err := json.Unmarshal(body, &op); err != nil || op.OperationName == "" {
+		return op, false
+	}
+
+	op.Variables = normalizeVariables(op.Variables)
+	return op, true
+}
+
+// String formats the operation as indented JSON with sorted keys, so equal
+// operations have equal strings and differences show up line by line.
+func (op graphQLOperation) String() string {
+	b, err := json.MarshalIndent(op, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%s %v", op.OperationName, op.Variables)
+	}
+	return string(b) + "\n"
+}
+
+// randomSuffix matches the suffix added by acctest.RandomWithPrefix, which
+// differs between the recording and the replay.
+var randomSuffix = regexp.MustCompile(`-\d{9,}$`)
+
+func normalizeVariables(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = normalizeVariables(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalizeVariables(value)
+		}
+		return v
+	case string:
+		return randomSuffix.ReplaceAllString(v, "-<random>")
+	default:
+		return v
+	}
+}
+
+// requestBody reads the body of the request and restores it, so it can be
+// read again by the next matcher call or the real transport.
+func requestBody(req *http.Request) []byte {
+	if req.Body == nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body
+}
+
+func TestMatchGraphQLOperation(t *testing.T) {
+	recorded := cassette.Request{
+		Method: http.MethodPost,
+		Body:   `{"query":"...","variables":{"id":"1","name":"tf-acc-test-5577006791947779410"},"operationName":"GetQuestionById"}`,
+	}
+
+	newRequest := func(body string) *http.Request {
+		req, err := http.NewRequest(http.MethodPost, "https://graphql.us.jupiterone.io/", strings.NewReader(body))
+		require.NoError(t, err)
+		return req
+	}
+
+	req := newRequest(`{"operationName":"GetQuestionById","variables":{"name":"tf-acc-test-8674665223082153551","id":"1"},"query":"..."}`)
+	assert.True(t, matchGraphQLOperation(req, recorded), "variables are compared after normalizing random names")
+
+	body, _ := io.ReadAll(req.Body)
+	assert.Contains(t, string(body), "GetQuestionById", "the body can still be sent")
+
+	assert.False(t, matchGraphQLOperation(newRequest(`{"operationName":"GetQuestionById","variables":{"id":"2","name":"tf-acc-test-1"}}`), recorded))
+	assert.False(t, matchGraphQLOperation(newRequest(`{"operationName":"DeleteQuestion","variables":{"id":"1"}}`), recorded))
+}
+
+func TestCassetteRecorderExplainsMismatches(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "cassette")
+
+	c := cassette.New(name)
+	c.AddInteraction(&cassette.Interaction{
+		Request: cassette.Request{
+			Method: http.MethodPost,
+			URL:    "https://graphql.us.jupiterone.io/",
+			Body:   `{"query":"...","variables":{"id":"1"},"operationName":"GetQuestionById"}`,
+		},
+		Response: cassette.Response{Code: http.StatusOK, Body: `{"data":{}}`},
+	})
+	require.NoError(t, c.Save())
+	_, err := os.Stat(name + ".yaml")
+	require.NoError(t, err)
+
+	rec, err := newCassetteRecorder(name)
+	require.NoError(t, err)
+	defer func() { _ = rec.Stop() }()
+
+	httpClient := &http.Client{Transport: rec}
+	post := func(body string) error {
+		resp, err := httpClient.Post("https://graphql.us.jupiterone.io/", "application/json", strings.NewReader(body))
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	err = post(`{"query":"...","variables":{"id":"2"},"operationName":"GetQuestionById"}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `-    "id": "1"`)
+	assert.Contains(t, err.Error(), `+    "id": "2"`)
+
+	err = post(`{"query":"...","variables":{"id":"1"},"operationName":"DeleteQuestion"}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no DeleteQuestion request, it recorded: GetQuestionById")
+
+	assert.NoError(t, post(`{"query":"...","variables":{"id":"1"},"operationName":"GetQuestionById"}`))
+}
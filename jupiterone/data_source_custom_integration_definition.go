@@ -27,6 +27,7 @@ type customIntegrationDefinitionDataSource struct {
 // customIntegrationDefinitionDataSourceModel describes the data source data model.
 type customIntegrationDefinitionDataSourceModel struct {
 	ID                   types.String   `tfsdk:"id"`
+	AccountId            types.String   `tfsdk:"account_id"`
 	IntegrationType      types.String   `tfsdk:"integration_type"`
 	Name                 types.String   `tfsdk:"name"`
 	Icon                 types.String   `tfsdk:"icon"`
@@ -48,6 +49,7 @@ func (d *customIntegrationDefinitionDataSource) Schema(ctx context.Context, req
 				Description: "The unique identifier of the custom integration definition.",
 				Computed:    true,
 			},
+			"account_id": dataSourceAccountIdAttribute(),
 			"integration_type": schema.StringAttribute{
 				Description: "The type of the integration.",
 				Required:    true,
@@ -111,6 +113,8 @@ func (d *customIntegrationDefinitionDataSource) Read(ctx context.Context, req da
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, d.qlient, data.AccountId)
+
 	result, err := client.GetCustomIntegrationDefinition(ctx, d.qlient, data.IntegrationType.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom integration definition, got error: %s", err))
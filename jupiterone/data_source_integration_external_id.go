@@ -12,7 +12,8 @@ import (
 )
 
 type IntegrationExternalIdModel struct {
-	Id types.String `json:"id,omitempty" tfsdk:"id"`
+	Id        types.String `json:"id,omitempty" tfsdk:"id"`
+	AccountId types.String `json:"account_id,omitempty" tfsdk:"account_id"`
 }
 
 // NewIntegrationExternalIdDataSource is a helper function to simplify the provider implementation.
@@ -39,6 +40,7 @@ func (*integrationExternalIdDataSource) Schema(ctx context.Context, req datasour
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"account_id": dataSourceAccountIdAttribute(),
 		},
 	}
 }
@@ -54,9 +56,11 @@ func (d *integrationExternalIdDataSource) Read(ctx context.Context, req datasour
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, d.qlient, data.AccountId)
+
 	response, err := client.GetExternalId(ctx, d.qlient)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to execute query", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to execute query", err)
 		return
 	}
 
@@ -9,22 +9,24 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
 )
 
 type QueryModel struct {
-	Query          types.String `json:"query,omitempty" tfsdk:"query"`
-	IncludeDeleted types.Bool   `json:"includeDeleted,omitempty" tfsdk:"include_deleted"`
+	Query          J1QLQueryValue `json:"query,omitempty" tfsdk:"query"`
+	IncludeDeleted types.Bool     `json:"includeDeleted,omitempty" tfsdk:"include_deleted"`
 }
 
 type J1QLResultModel struct {
-	Id       types.String `json:"id,omitempty" tfsdk:"id"`
-	Query    QueryModel   `json:"query,omitempty" tfsdk:"query"`
-	Type     types.String `json:"type,omitempty" tfsdk:"type"`
-	DataJson types.String `json:"data,omitempty" tfsdk:"data_json"`
-	MaxPages types.Int64  `json:"maxPages,omitempty" tfsdk:"max_pages"`
+	Id        types.String `json:"id,omitempty" tfsdk:"id"`
+	AccountId types.String `json:"account_id,omitempty" tfsdk:"account_id"`
+	Query     QueryModel   `json:"query,omitempty" tfsdk:"query"`
+	Type      types.String `json:"type,omitempty" tfsdk:"type"`
+	DataJson  types.String `json:"data,omitempty" tfsdk:"data_json"`
+	MaxPages  types.Int64  `json:"maxPages,omitempty" tfsdk:"max_pages"`
 }
 
 // NewJ1QLDataSource is a helper function to simplify the provider implementation.
@@ -36,6 +38,7 @@ func NewJ1QLResultDataSource() datasource.DataSource {
 type j1qlResultDataSource struct {
 	version string
 	qlient  graphql.Client
+	j1ql    *j1qlSettings
 }
 
 // Metadata implements resource.Resource
@@ -51,11 +54,13 @@ func (*j1qlResultDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"account_id": dataSourceAccountIdAttribute(),
 			"query": schema.SingleNestedAttribute{
 				Required:    true,
 				Description: "The query object to execute.",
 				Attributes: map[string]schema.Attribute{
 					"query": schema.StringAttribute{
+						CustomType:  J1QLQueryType{},
 						Required:    true,
 						Description: "The j1ql query string.",
 					},
@@ -92,8 +97,10 @@ func (d *j1qlResultDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, d.qlient, data.AccountId)
+
 	var endResults interface{}
-	var allArrayResults []interface{}
+	var allArrayResults []client.JSON
 	var cursor string
 	var resultType string
 	var numberOfPagesQueried int = 0
@@ -102,7 +109,7 @@ func (d *j1qlResultDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		numberOfPagesQueried++
 		executeResponse, err := client.ExecuteQuery(ctx, d.qlient, data.Query.Query.ValueString(), data.Query.IncludeDeleted.ValueBool(), cursor)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to execute query", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to execute query", err)
 			return
 		}
 
@@ -115,7 +122,8 @@ func (d *j1qlResultDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		}
 
 		// Table and list results are arrays and can be appended to allArrayResults
-		if dataArray, ok := executeResponse.QueryV1.Data.([]interface{}); ok {
+		var dataArray []client.JSON
+		if err := executeResponse.QueryV1.Data.Decode(&dataArray); err == nil {
 			allArrayResults = append(allArrayResults, dataArray...)
 		}
 
@@ -166,4 +174,15 @@ func (r *j1qlResultDataSource) Configure(ctx context.Context, req datasource.Con
 
 	r.version = p.version
 	r.qlient = p.Qlient
+	r.j1ql = &p.j1ql
+}
+
+// ConfigValidators implements datasource.DataSourceWithConfigValidators
+func (r *j1qlResultDataSource) ConfigValidators(context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		j1qlValidator{
+			settings: r.j1ql,
+			queries:  path.MatchRoot("query").AtName("query"),
+		},
+	}
 }
@@ -0,0 +1,212 @@
+package jupiterone
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+)
+
+// RuleEvaluationStatus is implemented by the rule instances returned by the
+// API, which all include the status of the latest evaluation.
+type RuleEvaluationStatus interface {
+	GetLatestAlertId() string
+	GetLatestAlertIsActive() bool
+	GetLastEvaluationEndOn() int64
+	GetLastSuccessfulEvaluationEndOn() int64
+	GetLastFailedEvaluationEndOn() int64
+	GetEvaluationStep() client.RuleEvaluationStep
+}
+
+// ruleStatusDescriptions are the descriptions of the evaluation status
+// attributes of the rule resource and the rule status data source.
+var ruleStatusDescriptions = map[string]string{
+	"latest_alert_id":                   "ID of the latest alert raised by the rule, if any.",
+	"latest_alert_is_active":            "Whether the latest alert raised by the rule is active.",
+	"last_evaluation_end_on":            "When the latest evaluation of the rule ended, in milliseconds since the epoch. 0 when the rule has not been evaluated.",
+	"last_successful_evaluation_end_on": "When the latest successful evaluation of the rule ended, in milliseconds since the epoch. 0 when no evaluation succeeded.",
+	"last_failed_evaluation_end_on":     "When the latest failed evaluation of the rule ended, in milliseconds since the epoch. 0 when no evaluation failed.",
+	"evaluation_step":                   "Step of the latest evaluation of the rule, e.g. `DONE` or `FAILED`.",
+}
+
+// ruleStatusUnknowns are the evaluation status attributes of the rule
+// resource with unknown values, to plan them when the rule is updated.
+var ruleStatusUnknowns = map[string]attr.Value{
+	"latest_alert_id":                   types.StringUnknown(),
+	"latest_alert_is_active":            types.BoolUnknown(),
+	"last_evaluation_end_on":            types.Int64Unknown(),
+	"last_successful_evaluation_end_on": types.Int64Unknown(),
+	"last_failed_evaluation_end_on":     types.Int64Unknown(),
+	"evaluation_step":                   types.StringUnknown(),
+}
+
+// ruleStatusAttributes points to the evaluation status attributes of the rule
+// resource or the rule status data source, so both are set the same way.
+// Models cannot embed a struct of the attributes in this version of the
+// framework.
+type ruleStatusAttributes struct {
+	latestAlertId                 *types.String
+	latestAlertIsActive           *types.Bool
+	lastEvaluationEndOn           *types.Int64
+	lastSuccessfulEvaluationEndOn *types.Int64
+	lastFailedEvaluationEndOn     *types.Int64
+	evaluationStep                *types.String
+}
+
+// set sets the attributes to the status of the latest evaluation.
+func (a ruleStatusAttributes) set(status RuleEvaluationStatus) {
+	*a.latestAlertId = stringOrNull(status.GetLatestAlertId())
+	*a.latestAlertIsActive = types.BoolValue(status.GetLatestAlertIsActive())
+	*a.lastEvaluationEndOn = types.Int64Value(status.GetLastEvaluationEndOn())
+	*a.lastSuccessfulEvaluationEndOn = types.Int64Value(status.GetLastSuccessfulEvaluationEndOn())
+	*a.lastFailedEvaluationEndOn = types.Int64Value(status.GetLastFailedEvaluationEndOn())
+	*a.evaluationStep = stringOrNull(string(status.GetEvaluationStep()))
+}
+
+// setEvaluationStatus sets the evaluation status attributes of the rule.
+func (r *RuleModel) setEvaluationStatus(status RuleEvaluationStatus) {
+	ruleStatusAttributes{
+		latestAlertId:                 &r.LatestAlertId,
+		latestAlertIsActive:           &r.LatestAlertIsActive,
+		lastEvaluationEndOn:           &r.LastEvaluationEndOn,
+		lastSuccessfulEvaluationEndOn: &r.LastSuccessfulEvaluationEndOn,
+		lastFailedEvaluationEndOn:     &r.LastFailedEvaluationEndOn,
+		evaluationStep:                &r.EvaluationStep,
+	}.set(status)
+}
+
+// setEvaluationStatus sets the evaluation status attributes of the rule.
+func (m *QuestionRuleStatusModel) setEvaluationStatus(status RuleEvaluationStatus) {
+	ruleStatusAttributes{
+		latestAlertId:                 &m.LatestAlertId,
+		latestAlertIsActive:           &m.LatestAlertIsActive,
+		lastEvaluationEndOn:           &m.LastEvaluationEndOn,
+		lastSuccessfulEvaluationEndOn: &m.LastSuccessfulEvaluationEndOn,
+		lastFailedEvaluationEndOn:     &m.LastFailedEvaluationEndOn,
+		evaluationStep:                &m.EvaluationStep,
+	}.set(status)
+}
+
+type QuestionRuleStatusModel struct {
+	Id                            types.String `json:"id" tfsdk:"id"`
+	AccountId                     types.String `json:"account_id,omitempty" tfsdk:"account_id"`
+	Name                          types.String `json:"name" tfsdk:"name"`
+	LatestAlertId                 types.String `json:"latest_alert_id" tfsdk:"latest_alert_id"`
+	LatestAlertIsActive           types.Bool   `json:"latest_alert_is_active" tfsdk:"latest_alert_is_active"`
+	LastEvaluationEndOn           types.Int64  `json:"last_evaluation_end_on" tfsdk:"last_evaluation_end_on"`
+	LastSuccessfulEvaluationEndOn types.Int64  `json:"last_successful_evaluation_end_on" tfsdk:"last_successful_evaluation_end_on"`
+	LastFailedEvaluationEndOn     types.Int64  `json:"last_failed_evaluation_end_on" tfsdk:"last_failed_evaluation_end_on"`
+	EvaluationStep                types.String `json:"evaluation_step" tfsdk:"evaluation_step"`
+}
+
+// NewQuestionRuleStatusDataSource is a helper function to simplify the provider implementation.
+func NewQuestionRuleStatusDataSource() datasource.DataSource {
+	return &questionRuleStatusDataSource{}
+}
+
+// questionRuleStatusDataSource is the data source implementation.
+type questionRuleStatusDataSource struct {
+	version string
+	qlient  graphql.Client
+}
+
+// Metadata implements datasource.DataSource
+func (*questionRuleStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_question_rule_status"
+}
+
+// Schema implements datasource.DataSource
+func (*questionRuleStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The evaluation status of a JupiterOne rule, for use in `check` blocks and conditions.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the rule.",
+			},
+			"account_id": dataSourceAccountIdAttribute(),
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the rule.",
+			},
+			"latest_alert_id": schema.StringAttribute{
+				Computed:    true,
+				Description: ruleStatusDescriptions["latest_alert_id"],
+			},
+			"latest_alert_is_active": schema.BoolAttribute{
+				Computed:    true,
+				Description: ruleStatusDescriptions["latest_alert_is_active"],
+			},
+			"last_evaluation_end_on": schema.Int64Attribute{
+				Computed:    true,
+				Description: ruleStatusDescriptions["last_evaluation_end_on"],
+			},
+			"last_successful_evaluation_end_on": schema.Int64Attribute{
+				Computed:    true,
+				Description: ruleStatusDescriptions["last_successful_evaluation_end_on"],
+			},
+			"last_failed_evaluation_end_on": schema.Int64Attribute{
+				Computed:    true,
+				Description: ruleStatusDescriptions["last_failed_evaluation_end_on"],
+			},
+			"evaluation_step": schema.StringAttribute{
+				Computed:    true,
+				Description: ruleStatusDescriptions["evaluation_step"],
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *questionRuleStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data QuestionRuleStatusModel
+
+	// Read Terraform configuration into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, data.AccountId = withAccountContext(ctx, d.qlient, data.AccountId)
+
+	getResp, err := client.GetQuestionRuleInstance(ctx, d.qlient, data.Id.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to get rule", err)
+		return
+	}
+	rule := &getResp.QuestionRuleInstance
+
+	data.Name = types.StringValue(rule.Name)
+	data.setEvaluationStatus(rule)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Configure implements datasource.DataSourceWithConfigure
+func (d *questionRuleStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*JupiterOneProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected JupiterOneProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.version = p.version
+	d.qlient = p.Qlient
+}
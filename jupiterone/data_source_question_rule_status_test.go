@@ -0,0 +1,60 @@
+package jupiterone
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestQuestionRuleStatus_FakeServer(t *testing.T) {
+	ctx := context.TODO()
+
+	server, qlient := setupFakeServer(ctx, t)
+
+	ruleName := "tf-provider-test-rule"
+	config := testInlineRuleInstanceBasicConfigWithOperations(ruleName, getValidOperations())
+	statusName := "data.jupiterone_question_rule_status.test"
+	var ruleId string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(qlient),
+		CheckDestroy:             testAccCheckRuleInstanceDestroy(ctx, qlient),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testRuleResourceName, "last_evaluation_end_on", "0"),
+					resource.TestCheckNoResourceAttr(testRuleResourceName, "evaluation_step"),
+					func(s *terraform.State) error {
+						ruleId = s.RootModule().Resources[testRuleResourceName].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					server.EvaluateRule(ruleId, 1700000000000, true)
+				},
+				Config: config + testQuestionRuleStatusConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testRuleResourceName, "evaluation_step", "FAILED"),
+					resource.TestCheckResourceAttr(testRuleResourceName, "last_failed_evaluation_end_on", "1700000000000"),
+					resource.TestCheckResourceAttr(statusName, "name", ruleName),
+					resource.TestCheckResourceAttr(statusName, "evaluation_step", "FAILED"),
+					resource.TestCheckResourceAttr(statusName, "last_evaluation_end_on", "1700000000000"),
+					resource.TestCheckResourceAttr(statusName, "last_successful_evaluation_end_on", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testQuestionRuleStatusConfig() string {
+	return fmt.Sprintf(`
+		data "jupiterone_question_rule_status" "test" {
+			id = %s.id
+		}
+	`, testRuleResourceName)
+}
@@ -35,6 +35,7 @@ func (*resourceGroupDataSource) Schema(ctx context.Context, req datasource.Schem
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"account_id": dataSourceAccountIdAttribute(),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the resource group.",
@@ -54,9 +55,11 @@ func (d *resourceGroupDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, d.qlient, data.AccountId)
+
 	resourceGroupsData, err := client.GetResourceGroups(ctx, d.qlient)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get resource groups", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to get resource groups", err)
 		return
 	}
 
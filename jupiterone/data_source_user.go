@@ -0,0 +1,148 @@
+package jupiterone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+)
+
+type UserDataModel struct {
+	Id              types.String `json:"id" tfsdk:"id"`
+	AccountId       types.String `json:"account_id,omitempty" tfsdk:"account_id"`
+	Email           types.String `json:"email" tfsdk:"email"`
+	Name            types.String `json:"name" tfsdk:"name"`
+	Status          types.String `json:"status" tfsdk:"status"`
+	GroupIds        []string     `json:"groupIds" tfsdk:"group_ids"`
+	PendingGroupIds []string     `json:"pendingGroupIds" tfsdk:"pending_group_ids"`
+}
+
+// NewUserDataSource is a helper function to simplify the provider implementation.
+func NewUserDataSource() datasource.DataSource {
+	return &userDataSource{}
+}
+
+// userDataSource is the data source implementation.
+type userDataSource struct {
+	version string
+	qlient  graphql.Client
+}
+
+// Metadata implements datasource.DataSource
+func (*userDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+// Schema implements datasource.DataSource
+func (*userDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := userAttributes()
+	attributes["account_id"] = dataSourceAccountIdAttribute()
+	attributes["email"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The email of the user. Emails are compared case-insensitively.",
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "A JupiterOne user, looked up by email. Users that have been invited and have not accepted the invitation yet are returned with the `PENDING` status.",
+		Attributes:  attributes,
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserDataModel
+
+	// Read Terraform configuration into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, data.AccountId = withAccountContext(ctx, d.qlient, data.AccountId)
+
+	user, err := getUserByEmail(ctx, d.qlient, data.Email.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to get user", err)
+		return
+	}
+	if user == nil {
+		resp.Diagnostics.AddError(
+			"User Not Found",
+			fmt.Sprintf("No user or pending invitation found with email %q.", data.Email.ValueString()),
+		)
+		return
+	}
+
+	data.Id = user.Id
+	data.Name = user.Name
+	data.Status = user.Status
+	data.GroupIds = user.GroupIds
+	data.PendingGroupIds = user.PendingGroupIds
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// getUserByEmail returns the user of the account with the email, with its
+// pending invitations, or the user invited with the email if there is none.
+// It returns nil if neither exist.
+func getUserByEmail(ctx context.Context, qlient graphql.Client, email string) (*UserModel, error) {
+	users, err := client.GetUsersByEmail(ctx, qlient, email)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := getPendingUsers(ctx, qlient, func(invited string) bool {
+		return strings.EqualFold(invited, email)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range users.IamGetUserList.Items {
+		// Only return exact matches of the email filter.
+		if strings.EqualFold(users.IamGetUserList.Items[i].Email, email) {
+			if err := getAllUserGroups(ctx, qlient, &users.IamGetUserList.Items[i]); err != nil {
+				return nil, err
+			}
+			user := newUserModel(&users.IamGetUserList.Items[i])
+			if len(pending) > 0 {
+				user.PendingGroupIds = pending[0].PendingGroupIds
+			}
+			return &user, nil
+		}
+	}
+
+	if len(pending) == 0 {
+		return nil, nil
+	}
+	return &pending[0], nil
+}
+
+// Configure implements datasource.DataSourceWithConfigure
+func (d *userDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*JupiterOneProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected JupiterOneProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.version = p.version
+	d.qlient = p.Qlient
+}
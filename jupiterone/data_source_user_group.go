@@ -2,7 +2,6 @@ package jupiterone
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/Khan/genqlient/graphql"
@@ -36,6 +35,7 @@ func (*userGroupDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"account_id": dataSourceAccountIdAttribute(),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the user group.",
@@ -73,9 +73,11 @@ func (d *userGroupDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, d.qlient, data.AccountId)
+
 	groups, err := client.GetGroupsByName(ctx, d.qlient, data.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get user group", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to get user group", err)
 		return
 	}
 
@@ -103,36 +105,10 @@ func (d *userGroupDataSource) Read(ctx context.Context, req datasource.ReadReque
 	data.Description = types.StringValue(group.GroupDescription)
 	data.Permissions = group.GroupAbacPermission.Statement
 
-	// Convert from []map[string]interface{} to []map[string][]string
-	var queryPolicy []map[string][]string
-
-	for _, statementData := range group.GroupQueryPolicy.Statement {
-		var queryPolicyStatement = make(map[string][]string)
-
-		if statementDataMap, ok := statementData.(map[string]interface{}); ok {
-			for key, value := range statementDataMap {
-				// Was unable to parse the []string from the JSON response in any other way.
-				// So we convert the value to a string and then unmarshal it into a []string.
-				stringValue, stringifyError := json.Marshal(value)
-
-				if stringifyError != nil {
-					resp.Diagnostics.AddError("failed to parse query policy", stringifyError.Error())
-					return
-				}
-
-				var arrayValue []string
-				parseError := json.Unmarshal(stringValue, &arrayValue)
-
-				if parseError != nil {
-					resp.Diagnostics.AddError("failed to parse query policy", parseError.Error())
-					return
-				}
-
-				queryPolicyStatement[key] = arrayValue
-			}
-		}
-
-		queryPolicy = append(queryPolicy, queryPolicyStatement)
+	queryPolicy, err := readQueryPolicy(group.GroupQueryPolicy.Statement)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to parse query policy", err.Error())
+		return
 	}
 
 	data.QueryPolicy = queryPolicy
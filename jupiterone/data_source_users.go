@@ -0,0 +1,374 @@
+package jupiterone
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+)
+
+// userStatusActive is the status of the users in the user list of the
+// account. The API has no status for users, so the status is derived by the
+// provider: users that are only known by their pending invitations have the
+// status of these invitations instead.
+const userStatusActive = "ACTIVE"
+
+type UserModel struct {
+	Id              types.String `json:"id" tfsdk:"id"`
+	Email           types.String `json:"email" tfsdk:"email"`
+	Name            types.String `json:"name" tfsdk:"name"`
+	Status          types.String `json:"status" tfsdk:"status"`
+	GroupIds        []string     `json:"groupIds" tfsdk:"group_ids"`
+	PendingGroupIds []string     `json:"pendingGroupIds" tfsdk:"pending_group_ids"`
+}
+
+type UsersModel struct {
+	Id        types.String `json:"id" tfsdk:"id"`
+	AccountId types.String `json:"account_id,omitempty" tfsdk:"account_id"`
+	Email     types.String `json:"email,omitempty" tfsdk:"email"`
+	GroupId   types.String `json:"groupId,omitempty" tfsdk:"group_id"`
+	Status    types.String `json:"status,omitempty" tfsdk:"status"`
+	Users     []UserModel  `json:"users" tfsdk:"users"`
+}
+
+// userAttributes are the computed attributes of a user, shared by the user
+// and the users data sources.
+func userAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the user, e.g. to use as `subject_id` of a `jupiterone_resource_permission` with `subject_type = \"user\"`. Null for users that have only been invited.",
+		},
+		"email": schema.StringAttribute{
+			Computed:    true,
+			Description: "The email of the user.",
+		},
+		"name": schema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the user.",
+		},
+		"status": schema.StringAttribute{
+			Computed:    true,
+			Description: "The status of the user, derived by the provider as the API has no user status: `ACTIVE` for the users listed in the account, `PENDING` for the emails that only have pending invitations to the account.",
+		},
+		"group_ids": schema.SetAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "The IDs of the user groups the user is a member of. Empty for pending users.",
+		},
+		"pending_group_ids": schema.SetAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "The IDs of the user groups the user has been invited to and has not accepted the invitation yet. Active users may also have pending invitations to other groups.",
+		},
+	}
+}
+
+// NewUsersDataSource is a helper function to simplify the provider implementation.
+func NewUsersDataSource() datasource.DataSource {
+	return &usersDataSource{}
+}
+
+// usersDataSource is the data source implementation.
+type usersDataSource struct {
+	version string
+	qlient  graphql.Client
+}
+
+// Metadata implements datasource.DataSource
+func (*usersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+// Schema implements datasource.DataSource
+func (*usersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The users of a JupiterOne account, including the users that have been invited and have not accepted the invitation yet.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"account_id": dataSourceAccountIdAttribute(),
+			"email": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the user with this email. Emails are compared case-insensitively.",
+			},
+			"group_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the users that are members of, or have a pending invitation to, the user group with this ID, as the `members` and `pending_invitations` of a `jupiterone_user_group_members`.",
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the users with this status. Possible values: `ACTIVE`, `PENDING`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(userStatusActive, invitationStatusPending),
+				},
+			},
+			"users": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The users, sorted by email.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: userAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UsersModel
+
+	// Read Terraform configuration into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, data.AccountId = withAccountContext(ctx, d.qlient, data.AccountId)
+
+	users, err := getAccountUsers(ctx, d.qlient)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to get users", err)
+		return
+	}
+
+	data.Users = []UserModel{}
+	for _, user := range users {
+		if data.matches(user) {
+			data.Users = append(data.Users, user)
+		}
+	}
+	data.Id = types.StringValue(uuid.New().String())
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// matches returns whether the user matches the filters of the data source.
+func (m *UsersModel) matches(user UserModel) bool {
+	if email := m.Email.ValueString(); email != "" && !strings.EqualFold(email, user.Email.ValueString()) {
+		return false
+	}
+	if status := m.Status.ValueString(); status != "" && status != user.Status.ValueString() {
+		return false
+	}
+	if groupId := m.GroupId.ValueString(); groupId != "" {
+		return slices.Contains(user.GroupIds, groupId) || slices.Contains(user.PendingGroupIds, groupId)
+	}
+	return true
+}
+
+// Configure implements datasource.DataSourceWithConfigure
+func (d *usersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*JupiterOneProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected JupiterOneProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.version = p.version
+	d.qlient = p.Qlient
+}
+
+// getAllUsers returns all the users of the account with all their groups,
+// following the pages of the user list and of the groups of each user.
+func getAllUsers(ctx context.Context, qlient graphql.Client) ([]client.IamUser, error) {
+	var all []client.IamUser
+
+	cursor := ""
+	for {
+		users, err := client.GetUsers(ctx, qlient, cursor)
+		if err != nil {
+			return nil, err
+		}
+		for i := range users.IamGetUserList.Items {
+			if err := getAllUserGroups(ctx, qlient, &users.IamGetUserList.Items[i]); err != nil {
+				return nil, err
+			}
+		}
+		all = append(all, users.IamGetUserList.Items...)
+
+		page := users.IamGetUserList.PageInfo
+		if !page.HasNextPage || page.EndCursor == "" {
+			return all, nil
+		}
+		cursor = page.EndCursor
+	}
+}
+
+// getAllUserGroups adds the groups of the user after the first page, which is
+// all the user lists return.
+func getAllUserGroups(ctx context.Context, qlient graphql.Client, user *client.IamUser) error {
+	for page := user.UserGroups.PageInfo; page.HasNextPage && page.EndCursor != ""; {
+		groups, err := client.GetUserGroups(ctx, qlient, user.Email, page.EndCursor)
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, item := range groups.IamGetUserList.Items {
+			if item.Id == user.Id {
+				user.UserGroups.Items = append(user.UserGroups.Items, item.UserGroups.Items...)
+				page = item.UserGroups.PageInfo
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("user %s was not found while reading its groups", user.Email)
+		}
+	}
+	return nil
+}
+
+// getAllInvitations returns all the open invitations to the account,
+// following the pages of the invitation list.
+func getAllInvitations(ctx context.Context, qlient graphql.Client) ([]client.IamInvitation, error) {
+	var all []client.IamInvitation
+
+	cursor := ""
+	for {
+		invitations, err := client.GetInvitations(ctx, qlient, cursor)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, invitations.IamGetAccount.AccountInvitations.Items...)
+
+		page := invitations.IamGetAccount.AccountInvitations.PageInfo
+		if !page.HasNextPage || page.EndCursor == "" {
+			return all, nil
+		}
+		cursor = page.EndCursor
+	}
+}
+
+// getAccountUsers returns the users of the account and the users that have
+// pending invitations to the account, sorted by email. The pending
+// invitations of the users of the account are set on them.
+func getAccountUsers(ctx context.Context, qlient graphql.Client) ([]UserModel, error) {
+	users, err := getAllUsers(ctx, qlient)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]UserModel, 0, len(users))
+	active := map[string]int{}
+	for i := range users {
+		models = append(models, newUserModel(&users[i]))
+		active[strings.ToLower(users[i].Email)] = i
+	}
+
+	pending, err := getPendingUsers(ctx, qlient, func(string) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range pending {
+		if i, ok := active[strings.ToLower(user.Email.ValueString())]; ok {
+			models[i].PendingGroupIds = user.PendingGroupIds
+		} else {
+			models = append(models, user)
+		}
+	}
+
+	sort.SliceStable(models, func(i, j int) bool {
+		return strings.ToLower(models[i].Email.ValueString()) < strings.ToLower(models[j].Email.ValueString())
+	})
+	return models, nil
+}
+
+// getPendingUsers returns a pending user for each email with pending
+// invitations accepted by the match function, with the groups of all its
+// invitations. Callers use the pending groups of the emails that belong to
+// users of the account.
+func getPendingUsers(ctx context.Context, qlient graphql.Client, match func(email string) bool) ([]UserModel, error) {
+	invitations, err := getAllInvitations(ctx, qlient)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []UserModel
+	index := map[string]int{}
+	for _, invite := range invitations {
+		if (invite.Status != "" && invite.Status != invitationStatusPending) || !match(invite.Email) {
+			continue
+		}
+
+		key := strings.ToLower(invite.Email)
+		i, ok := index[key]
+		if !ok {
+			i = len(users)
+			index[key] = i
+			users = append(users, UserModel{
+				Id:              types.StringNull(),
+				Email:           types.StringValue(invite.Email),
+				Name:            types.StringNull(),
+				Status:          types.StringValue(invitationStatusPending),
+				GroupIds:        []string{},
+				PendingGroupIds: []string{},
+			})
+		}
+		users[i].PendingGroupIds = appendGroupId(users[i].PendingGroupIds, invite.GroupId)
+	}
+
+	for i := range users {
+		sort.Strings(users[i].PendingGroupIds)
+	}
+	return users, nil
+}
+
+// newUserModel converts a user of the account.
+func newUserModel(user *client.IamUser) UserModel {
+	name := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	if name == "" {
+		name = user.NickName
+	}
+
+	groupIds := []string{}
+	for _, group := range user.UserGroups.Items {
+		groupIds = appendGroupId(groupIds, group.Id)
+	}
+	sort.Strings(groupIds)
+
+	return UserModel{
+		Id:              types.StringValue(user.Id),
+		Email:           types.StringValue(user.Email),
+		Name:            stringOrNull(name),
+		Status:          types.StringValue(userStatusActive),
+		GroupIds:        groupIds,
+		PendingGroupIds: []string{},
+	}
+}
+
+func appendGroupId(groupIds []string, id string) []string {
+	if id == "" {
+		return groupIds
+	}
+	for _, existing := range groupIds {
+		if existing == id {
+			return groupIds
+		}
+	}
+	return append(groupIds, id)
+}
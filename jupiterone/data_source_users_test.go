@@ -0,0 +1,183 @@
+package jupiterone
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountUsers(t *testing.T) {
+	ctx := context.TODO()
+
+	server, qlient := setupFakeServer(ctx, t)
+
+	admins, err := client.CreateUserGroup(ctx, qlient, "admins", "", nil, nil)
+	require.NoError(t, err)
+	adminsId := admins.CreateIamGroup.Id
+	readers, err := client.CreateUserGroup(ctx, qlient, "readers", "", nil, nil)
+	require.NoError(t, err)
+	readersId := readers.CreateIamGroup.Id
+	writers, err := client.CreateUserGroup(ctx, qlient, "writers", "", nil, nil)
+	require.NoError(t, err)
+	writersId := writers.CreateIamGroup.Id
+
+	userId := server.AddUser("b@example.com", adminsId, readersId)
+	_, err = client.InviteUser(ctx, qlient, "a@example.com", readersId)
+	require.NoError(t, err)
+	_, err = client.InviteUser(ctx, qlient, "A@example.com", adminsId)
+	require.NoError(t, err)
+	// Users of the account are not listed again for their invitations, which
+	// are set on them.
+	_, err = client.InviteUser(ctx, qlient, "B@example.com", writersId)
+	require.NoError(t, err)
+
+	users, err := getAccountUsers(ctx, qlient)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	assert.Equal(t, "a@example.com", users[0].Email.ValueString())
+	assert.True(t, users[0].Id.IsNull())
+	assert.Equal(t, invitationStatusPending, users[0].Status.ValueString())
+	assert.Empty(t, users[0].GroupIds)
+	assert.ElementsMatch(t, []string{adminsId, readersId}, users[0].PendingGroupIds)
+
+	assert.Equal(t, userId, users[1].Id.ValueString())
+	assert.Equal(t, userStatusActive, users[1].Status.ValueString())
+	assert.Equal(t, "b@example.com", users[1].Name.ValueString(), "the nickname is used without first and last name")
+	assert.ElementsMatch(t, []string{adminsId, readersId}, users[1].GroupIds)
+	assert.Equal(t, []string{writersId}, users[1].PendingGroupIds)
+
+	filters := UsersModel{Email: types.StringValue("A@EXAMPLE.COM")}
+	assert.True(t, filters.matches(users[0]))
+	assert.False(t, filters.matches(users[1]))
+
+	filters = UsersModel{Status: types.StringValue(userStatusActive), GroupId: types.StringValue(readersId)}
+	assert.False(t, filters.matches(users[0]))
+	assert.True(t, filters.matches(users[1]))
+
+	// The group filter agrees with the members and pending invitations of
+	// jupiterone_user_group_members.
+	filters = UsersModel{GroupId: types.StringValue(writersId)}
+	assert.False(t, filters.matches(users[0]))
+	assert.True(t, filters.matches(users[1]))
+	members, err := getGroupMembers(ctx, qlient, writersId)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b@example.com"}, members.keys())
+
+	user, err := getUserByEmail(ctx, qlient, "A@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, invitationStatusPending, user.Status.ValueString())
+
+	user, err = getUserByEmail(ctx, qlient, "b@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, userStatusActive, user.Status.ValueString())
+	assert.Equal(t, []string{writersId}, user.PendingGroupIds)
+
+	user, err = getUserByEmail(ctx, qlient, "missing@example.com")
+	require.NoError(t, err)
+	assert.Nil(t, user)
+}
+
+func TestGetAccountUsersFollowsPages(t *testing.T) {
+	ctx := context.TODO()
+
+	server, qlient := setupFakeServer(ctx, t)
+
+	var groupIds []string
+	for _, name := range []string{"admins", "readers", "writers"} {
+		group, err := client.CreateUserGroup(ctx, qlient, name, "", nil, nil)
+		require.NoError(t, err)
+		groupIds = append(groupIds, group.CreateIamGroup.Id)
+	}
+
+	server.AddUser("a@example.com", groupIds...)
+	server.AddUser("b@example.com", groupIds[0])
+	for _, email := range []string{"c@example.com", "d@example.com"} {
+		_, err := client.InviteUser(ctx, qlient, email, groupIds[2])
+		require.NoError(t, err)
+	}
+
+	server.SetPageSize(1)
+
+	users, err := getAccountUsers(ctx, qlient)
+	require.NoError(t, err)
+	require.Len(t, users, 4)
+	assert.ElementsMatch(t, groupIds, users[0].GroupIds, "the groups of a user are read from all their pages")
+	assert.Equal(t, "d@example.com", users[3].Email.ValueString(), "the invitations are read from all their pages")
+	assert.Equal(t, 2, server.Requests("GetUserGroups"))
+
+	user, err := getUserByEmail(ctx, qlient, "a@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.ElementsMatch(t, groupIds, user.GroupIds)
+
+	members, err := getGroupMembers(ctx, qlient, groupIds[2])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@example.com", "c@example.com", "d@example.com"}, members.keys())
+}
+
+func TestUsersDataSource_FakeServer(t *testing.T) {
+	ctx := context.TODO()
+
+	server, qlient := setupFakeServer(ctx, t)
+
+	group, err := client.CreateUserGroup(ctx, qlient, "readers", "", nil, nil)
+	require.NoError(t, err)
+	groupId := group.CreateIamGroup.Id
+
+	userId := server.AddUser("member@example.com", groupId)
+	_, err = client.InviteUser(ctx, qlient, "invited@example.com", groupId)
+	require.NoError(t, err)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(qlient),
+		Steps: []resource.TestStep{
+			{
+				Config: testUsersDataSourceConfig(groupId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jupiterone_user.test", "id", userId),
+					resource.TestCheckResourceAttr("data.jupiterone_user.test", "status", userStatusActive),
+					resource.TestCheckTypeSetElemAttr("data.jupiterone_user.test", "group_ids.*", groupId),
+					resource.TestCheckResourceAttr("data.jupiterone_users.pending", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.jupiterone_users.pending", "users.0.email", "invited@example.com"),
+					resource.TestCheckNoResourceAttr("data.jupiterone_users.pending", "users.0.id"),
+					resource.TestCheckTypeSetElemAttr("data.jupiterone_users.pending", "users.0.pending_group_ids.*", groupId),
+					resource.TestCheckResourceAttr("data.jupiterone_users.group", "users.#", "2"),
+				),
+			},
+			{
+				Config: `
+					data "jupiterone_user" "test" {
+						email = "missing@example.com"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`User Not Found`),
+			},
+		},
+	})
+}
+
+func testUsersDataSourceConfig(groupId string) string {
+	return fmt.Sprintf(`
+		data "jupiterone_user" "test" {
+			email = "Member@example.com"
+		}
+
+		data "jupiterone_users" "pending" {
+			status = "PENDING"
+		}
+
+		data "jupiterone_users" "group" {
+			group_id = %q
+		}
+	`, groupId)
+}
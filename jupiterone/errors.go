@@ -0,0 +1,133 @@
+package jupiterone
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+)
+
+// addAPIError adds the error of an API call to the diagnostics, explaining
+// what to do about the kinds of errors that are caused by the provider
+// configuration rather than the resource.
+func addAPIError(diags *diag.Diagnostics, summary string, err error) {
+	switch {
+	case errors.Is(err, client.ErrForbidden):
+		diags.AddError(summary,
+			"The JupiterOne credentials of the provider do not have permission for "+
+				"this operation. Grant the API key or OAuth client the required "+
+				"permission in the account, or set account_id to an account it can "+
+				"access.\n\n"+err.Error())
+	case errors.Is(err, client.ErrUnauthenticated):
+		diags.AddError(summary,
+			"The JupiterOne credentials of the provider were rejected. Check that "+
+				"the api_key or OAuth client credentials are valid and have not "+
+				"expired or been revoked.\n\n"+err.Error())
+	case errors.Is(err, client.ErrRateLimited):
+		diags.AddError(summary,
+			"The JupiterOne API kept rate limiting the request after all retries. "+
+				"Lower the Terraform -parallelism or raise retry_max_attempts.\n\n"+
+				err.Error())
+	default:
+		diags.AddError(summary, err.Error())
+	}
+}
+
+// schemaTypes is implemented by the schema of a plan or state.
+type schemaTypes interface {
+	TypeAtPath(context.Context, path.Path) (attr.Type, diag.Diagnostics)
+}
+
+// addInputError adds the error of a mutation to the diagnostics. GraphQL
+// errors about a field of the input are reported on the attribute the field
+// was built from, so the CLI points at the offending configuration. Input
+// field names are matched to attributes by converting them to snake case
+// unless they are listed in renames. A field renamed to "" is built from
+// several attributes, its errors are reported on the enclosing attribute.
+func addInputError(ctx context.Context, diags *diag.Diagnostics, schema schemaTypes, summary string, err error, renames map[string]string) {
+	fieldErrors, other := client.FieldErrors(err)
+	if len(fieldErrors) == 0 {
+		addAPIError(diags, summary, err)
+		return
+	}
+
+	for _, fieldErr := range fieldErrors {
+		if p, ok := attributePath(ctx, schema, fieldErr.Path, renames); ok {
+			diags.AddAttributeError(p, summary, fieldErr.String())
+		} else {
+			diags.AddError(summary, fieldErr.String())
+		}
+	}
+
+	if len(other) > 0 {
+		diags.AddError(summary, other.Error())
+	}
+}
+
+// attributePath follows the input field path through the schema as far as it
+// matches an attribute. Fields inside an attribute holding JSON, such as the
+// conditions of a rule, end at that attribute.
+func attributePath(ctx context.Context, schema schemaTypes, fieldPath []interface{}, renames map[string]string) (path.Path, bool) {
+	p := path.Empty()
+	matched := false
+
+	for _, segment := range fieldPath {
+		var next path.Path
+
+		switch s := segment.(type) {
+		case int:
+			next = p.AtListIndex(s)
+		case string:
+			name, ok := renames[s]
+			if !ok {
+				name = toSnakeCase(s)
+			}
+			if name == "" {
+				return p, matched
+			}
+
+			// Blocks are lists in the schema but single objects in the
+			// input, like the question of a rule.
+			if matched {
+				if t, d := schema.TypeAtPath(ctx, p); !d.HasError() {
+					if list, ok := t.(types.ListType); ok {
+						if _, ok := list.ElemType.(types.ObjectType); ok {
+							p = p.AtListIndex(0)
+						}
+					}
+				}
+			}
+			next = p.AtName(name)
+		default:
+			return p, matched
+		}
+
+		if _, d := schema.TypeAtPath(ctx, next); d.HasError() {
+			break
+		}
+		p = next
+		matched = true
+	}
+
+	return p, matched
+}
+
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
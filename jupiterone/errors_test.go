@@ -0,0 +1,109 @@
+package jupiterone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestAddAPIError(t *testing.T) {
+	var diags diag.Diagnostics
+	addAPIError(&diags, "failed to get question", &client.Error{Kind: client.ErrForbidden, StatusCode: 403, Message: "Forbidden"})
+	assert.Equal(t, "failed to get question", diags[0].Summary())
+	assert.Contains(t, diags[0].Detail(), "do not have permission")
+
+	diags = nil
+	addAPIError(&diags, "failed to get question", fmt.Errorf("wrapped: %w", errors.New("boom")))
+	assert.Equal(t, "wrapped: boom", diags[0].Detail())
+}
+
+// TestReadRemovesMissingObjects checks that reads recognize deleted objects
+// by the messages of the services that do not answer with a NOT_FOUND code.
+func TestReadRemovesMissingObjects(t *testing.T) {
+	ctx := context.TODO()
+	server, qlient := setupFakeServer(ctx, t)
+
+	// Recorded in cassettes/TestIntegration_Basic.yaml.
+	server.FailNext("GetIntegrationInstance", &gqlerror.Error{
+		Message:    "Integration instance not found",
+		Extensions: map[string]interface{}{"code": "BAD_USER_INPUT"},
+	})
+
+	for _, tt := range []struct {
+		name     string
+		resource resource.Resource
+		model    interface{}
+	}{
+		{"integration", &IntegrationResource{qlient: qlient}, &IntegrationModel{Id: types.StringValue("deleted")}},
+		{"resource group", &ResourceGroupResource{qlient: qlient}, &ResourceGroupModel{Id: types.StringValue("deleted")}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var schemaResp resource.SchemaResponse
+			tt.resource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			require.False(t, schemaResp.Diagnostics.HasError())
+
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			require.False(t, state.Set(ctx, tt.model).HasError())
+
+			resp := &resource.ReadResponse{State: state}
+			tt.resource.Read(ctx, resource.ReadRequest{State: state}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			assert.True(t, resp.State.Raw.IsNull(), "the resource is removed from state")
+		})
+	}
+}
+
+func TestAddInputError(t *testing.T) {
+	ctx := context.TODO()
+
+	var schemaResp resource.SchemaResponse
+	(&QuestionRuleResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	err := &client.Error{
+		Kind: client.ErrValidation,
+		GraphQLErrors: gqlerror.List{
+			{
+				Message: `Variable "$instance" got invalid value 1 at "instance.operations[2].actions[0]"; Expected type "JSON".`,
+			},
+			{
+				Message: `Variable "$instance" got invalid value "x" at "instance.triggerActionsOnNewEntitiesOnly"; Expected type "Boolean".`,
+			},
+			{
+				Message: `Variable "$instance" got invalid value 1 at "instance.question.queries[1].query"; Expected type "String".`,
+			},
+			{Message: "Something else"},
+		},
+	}
+
+	var diags diag.Diagnostics
+	addInputError(ctx, &diags, schemaResp.Schema, "failed to create rule", err, ruleInputRenames)
+	assert.Len(t, diags, 4)
+
+	// The actions may come from `actions` or `typed_actions`.
+	assert.Equal(t, path.Root("operations").AtListIndex(2), diags[0].(diag.DiagnosticWithPath).Path())
+	assert.Contains(t, diags[0].Detail(), "operations[2].actions[0]: ")
+
+	assert.Equal(t, path.Root("trigger_on_new_only"), diags[1].(diag.DiagnosticWithPath).Path())
+
+	assert.Equal(t, path.Root("question").AtListIndex(0).AtName("queries").AtListIndex(1).AtName("query"),
+		diags[2].(diag.DiagnosticWithPath).Path())
+
+	_, ok := diags[3].(diag.DiagnosticWithPath)
+	assert.False(t, ok)
+	assert.Contains(t, diags[3].Detail(), "Something else")
+}
@@ -0,0 +1,158 @@
+package jupiterone
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/j1ql"
+)
+
+var (
+	_ function.Function = &J1QLQuoteFunction{}
+	_ function.Function = &J1QLInListFunction{}
+	_ function.Function = &J1QLNormalizeFunction{}
+)
+
+func NewJ1QLQuoteFunction() function.Function {
+	return &J1QLQuoteFunction{}
+}
+
+// J1QLQuoteFunction quotes a value as a J1QL string literal.
+type J1QLQuoteFunction struct{}
+
+// Metadata implements function.Function
+func (*J1QLQuoteFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "j1ql_quote"
+}
+
+// Definition implements function.Function
+func (*J1QLQuoteFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Quote a value as a J1QL string",
+		Description: "Returns the value in single quotes with backslashes and single quotes escaped, so that it can be compared to a property in a query such as `FIND Host WITH name = ${provider::jupiterone::j1ql_quote(var.name)}`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "value",
+				Description: "The value to quote.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run implements function.Function
+func (*J1QLQuoteFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = req.Arguments.Get(ctx, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, j1ql.Quote(value))
+}
+
+func NewJ1QLInListFunction() function.Function {
+	return &J1QLInListFunction{}
+}
+
+// J1QLInListFunction renders a filter that matches a property to any value
+// of a list.
+type J1QLInListFunction struct{}
+
+// Metadata implements function.Function
+func (*J1QLInListFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "j1ql_in_list"
+}
+
+// Definition implements function.Function
+func (*J1QLInListFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Render a J1QL filter matching any value of a list",
+		Description: "Returns a comparison of the property to any of the values, such as `_type = ('aws_instance' or 'azure_vm')`, for use after WITH or WHERE. The values are quoted as strings.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "property",
+				Description: "The property to compare, such as `_type` or `[tag.CIS2.0]`.",
+			},
+			function.ListParameter{
+				Name:        "values",
+				Description: "The values to match, at least one.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run implements function.Function
+func (*J1QLInListFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var property string
+	var values []types.String
+	resp.Error = req.Arguments.Get(ctx, &property, &values)
+	if resp.Error != nil {
+		return
+	}
+
+	strs := make([]string, len(values))
+	for i, v := range values {
+		if v.IsNull() {
+			resp.Error = function.NewArgumentFuncError(1, "The list of values must not contain null values.")
+			return
+		}
+		strs[i] = v.ValueString()
+	}
+
+	filter, err := j1ql.InList(property, strs)
+	if err != nil {
+		resp.Error = function.NewFuncError("Invalid J1QL filter: " + err.Error() + ".")
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, filter)
+}
+
+func NewJ1QLNormalizeFunction() function.Function {
+	return &J1QLNormalizeFunction{}
+}
+
+// J1QLNormalizeFunction formats a query in the canonical form of
+// j1ql.Normalize.
+type J1QLNormalizeFunction struct{}
+
+// Metadata implements function.Function
+func (*J1QLNormalizeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "j1ql_normalize"
+}
+
+// Definition implements function.Function
+func (*J1QLNormalizeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Normalize the formatting of a J1QL query",
+		Description: "Returns the query on one line, with keywords, relationship verbs, functions and literals in upper case and single spaces between tokens, so that queries that differ only in formatting are equal.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "query",
+				Description: "The query to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run implements function.Function
+func (*J1QLNormalizeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var query string
+	resp.Error = req.Arguments.Get(ctx, &query)
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := j1ql.Normalize(query)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid J1QL query: "+err.Error()+".")
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
@@ -0,0 +1,68 @@
+package jupiterone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) (string, *function.FuncError) {
+	ctx := context.TODO()
+
+	definitionResp := &function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, definitionResp)
+	require.Len(t, args, len(definitionResp.Definition.Parameters))
+
+	req := function.RunRequest{Arguments: function.NewArgumentsData(args)}
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(ctx, req, resp)
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+
+	result, ok := resp.Result.Value().(types.String)
+	require.True(t, ok)
+	return result.ValueString(), nil
+}
+
+func TestJ1QLQuoteFunction(t *testing.T) {
+	result, err := runFunction(t, NewJ1QLQuoteFunction(), types.StringValue(`it's C:\`))
+	require.Nil(t, err)
+	assert.Equal(t, `'it\'s C:\\'`, result)
+}
+
+func TestJ1QLInListFunction(t *testing.T) {
+	values := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("aws_instance"),
+		types.StringValue("azure_vm"),
+	})
+	result, err := runFunction(t, NewJ1QLInListFunction(), types.StringValue("_type"), values)
+	require.Nil(t, err)
+	assert.Equal(t, "_type = ('aws_instance' or 'azure_vm')", result)
+
+	empty := types.ListValueMust(types.StringType, []attr.Value{})
+	_, err = runFunction(t, NewJ1QLInListFunction(), types.StringValue("_type"), empty)
+	require.NotNil(t, err)
+	assert.Equal(t, "Invalid J1QL filter: the list of values is empty.", err.Text)
+
+	withNull := types.ListValueMust(types.StringType, []attr.Value{types.StringNull()})
+	_, err = runFunction(t, NewJ1QLInListFunction(), types.StringValue("_type"), withNull)
+	require.NotNil(t, err)
+	require.NotNil(t, err.FunctionArgument)
+	assert.Equal(t, int64(1), *err.FunctionArgument)
+}
+
+func TestJ1QLNormalizeFunction(t *testing.T) {
+	result, err := runFunction(t, NewJ1QLNormalizeFunction(), types.StringValue("find Host\n  with active=true"))
+	require.Nil(t, err)
+	assert.Equal(t, "FIND Host WITH active = TRUE", result)
+
+	_, err = runFunction(t, NewJ1QLNormalizeFunction(), types.StringValue("FIND Host WITH name = 'web"))
+	require.NotNil(t, err)
+	assert.Equal(t, "Invalid J1QL query: line 1, column 23: string is not closed.", err.Text)
+}
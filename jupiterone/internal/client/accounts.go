@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+type accountContextKey struct{}
+
+// WithAccount scopes the API requests made with the returned context to the
+// given JupiterOne account instead of the provider account.
+func WithAccount(ctx context.Context, accountID string) context.Context {
+	return context.WithValue(ctx, accountContextKey{}, accountID)
+}
+
+// AccountFromContext returns the account set with WithAccount, if any.
+func AccountFromContext(ctx context.Context) string {
+	accountID, _ := ctx.Value(accountContextKey{}).(string)
+	return accountID
+}
+
+// AccountQlients is a graphql.Client that keeps one client per JupiterOne
+// account and sends each request with the client for the account of its
+// context. Requests without an account use the default client.
+type AccountQlients struct {
+	defaultAccountID string
+	defaultQlient    graphql.Client
+	// config is used to create the clients for other accounts, it is nil
+	// when the default client was created outside the provider.
+	config *JupiterOneClientConfig
+
+	mu      sync.Mutex
+	qlients map[string]graphql.Client
+}
+
+var _ graphql.Client = &AccountQlients{}
+
+// NewAccountQlients wraps the default client of the provider. When config is
+// nil requests for other accounts fail.
+func NewAccountQlients(defaultAccountID string, defaultQlient graphql.Client, config *JupiterOneClientConfig) *AccountQlients {
+	return &AccountQlients{
+		defaultAccountID: defaultAccountID,
+		defaultQlient:    defaultQlient,
+		config:           config,
+		qlients:          map[string]graphql.Client{},
+	}
+}
+
+// DefaultAccountID returns the account of the provider configuration.
+func (a *AccountQlients) DefaultAccountID() string {
+	return a.defaultAccountID
+}
+
+// Qlient returns the client for the account, creating it on first use.
+func (a *AccountQlients) Qlient(ctx context.Context, accountID string) (graphql.Client, error) {
+	if accountID == "" || accountID == a.defaultAccountID {
+		return a.defaultQlient, nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if q, ok := a.qlients[accountID]; ok {
+		return q, nil
+	}
+
+	if a.config == nil {
+		return nil, fmt.Errorf("cannot create a client for account %s, the provider client was preconfigured", accountID)
+	}
+
+	config := *a.config
+	config.AccountID = accountID
+
+	q, err := config.Qlient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a.qlients[accountID] = q
+	return q, nil
+}
+
+// MakeRequest implements graphql.Client
+func (a *AccountQlients) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	q, err := a.Qlient(ctx, AccountFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	return q.MakeRequest(ctx, req, resp)
+}
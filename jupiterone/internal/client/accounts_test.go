@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountQlientsRoutesByContextAccount(t *testing.T) {
+	var accounts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accounts = append(accounts, r.Header.Get("LifeOmic-Account"))
+		fmt.Fprint(w, `{"data":{"deleteQuestion":{"id":"1"}}}`)
+	}))
+	defer server.Close()
+
+	config := JupiterOneClientConfig{
+		APIKey:       "key",
+		AccountID:    "default",
+		RoundTripper: rewriteHostTransport{target: server.URL},
+	}
+
+	defaultQlient, err := config.Qlient(context.TODO())
+	assert.NoError(t, err)
+
+	qlients := NewAccountQlients(config.AccountID, defaultQlient, &config)
+
+	_, err = DeleteQuestion(context.TODO(), qlients, "1")
+	assert.NoError(t, err)
+	_, err = DeleteQuestion(WithAccount(context.TODO(), "other"), qlients, "1")
+	assert.NoError(t, err)
+	_, err = DeleteQuestion(WithAccount(context.TODO(), "default"), qlients, "1")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"default", "other", "default"}, accounts)
+
+	other, err := qlients.Qlient(context.TODO(), "other")
+	assert.NoError(t, err)
+	again, err := qlients.Qlient(context.TODO(), "other")
+	assert.NoError(t, err)
+	assert.Same(t, other, again, "clients should be reused per account")
+}
+
+func TestAccountQlientsWithoutConfig(t *testing.T) {
+	qlients := NewAccountQlients("default", nil, nil)
+
+	_, err := qlients.Qlient(context.TODO(), "other")
+	assert.ErrorContains(t, err, "preconfigured")
+}
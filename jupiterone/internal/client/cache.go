@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// CacheTTL is how long the response of a cached query is reused. Terraform
+// starts the provider for each plan or apply, so the cache never outlives one
+// run, the TTL only bounds how stale a response can get during a long apply.
+const CacheTTL = time.Minute
+
+// cachedQueries lists the idempotent queries whose responses are shared by
+// the reads of a run, and the mutations that change what they return.
+// Several resources read an object by listing all of them, such as the user
+// group memberships listing all invitations, so a refresh would otherwise
+// send the same query once per resource.
+var cachedQueries = map[string][]string{
+	"GetInvitations": {
+		"InviteUser",
+		"RevokeInvitation",
+		"DeleteUserGroup",
+	},
+	"GetUsersByEmail": {
+		"InviteUser",
+		"RemoveUserFromGroup",
+		"DeleteUserGroup",
+	},
+	"GetGroupsByName": {
+		"CreateUserGroup",
+		"UpdateUserGroup",
+		"DeleteUserGroup",
+	},
+	"GetResourceGroups": {
+		"CreateResourceGroup",
+		"UpdateResourceGroup",
+		"DeleteResourceGroup",
+	},
+}
+
+// invalidatedBy is the inverse of cachedQueries.
+var invalidatedBy = func() map[string][]string {
+	m := map[string][]string{}
+	for query, mutations := range cachedQueries {
+		for _, mutation := range mutations {
+			m[mutation] = append(m[mutation], query)
+		}
+	}
+	return m
+}()
+
+// cachingClient is a graphql.Client that reuses the responses of the queries
+// in cachedQueries. Concurrent requests for the same query and variables wait
+// for the one in flight instead of sending their own.
+type cachingClient struct {
+	next graphql.Client
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+}
+
+var _ graphql.Client = &cachingClient{}
+
+type cacheKey struct {
+	opName    string
+	variables string
+}
+
+type cacheEntry struct {
+	// done is closed once data and err are set.
+	done      chan struct{}
+	data      json.RawMessage
+	err       error
+	expiresAt time.Time
+}
+
+func newCachingClient(next graphql.Client, ttl time.Duration) *cachingClient {
+	return &cachingClient{
+		next:    next,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[cacheKey]*cacheEntry{},
+	}
+}
+
+// MakeRequest implements graphql.Client
+func (c *cachingClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	if queries, ok := invalidatedBy[req.OpName]; ok {
+		err := c.next.MakeRequest(ctx, req, resp)
+		// A failed mutation may still have been applied.
+		c.invalidate(queries)
+		return err
+	}
+
+	if _, ok := cachedQueries[req.OpName]; !ok {
+		return c.next.MakeRequest(ctx, req, resp)
+	}
+
+	variables, err := json.Marshal(req.Variables)
+	if err != nil {
+		return c.next.MakeRequest(ctx, req, resp)
+	}
+	key := cacheKey{opName: req.OpName, variables: string(variables)}
+
+	for {
+		entry, owner := c.entry(key)
+		if owner {
+			c.fill(ctx, key, entry, req, resp)
+			return entry.err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-entry.done:
+		}
+
+		if entry.err != nil {
+			// The error may be caused by the context of the request that
+			// sent the query, such as a timeout, so try again with ours.
+			continue
+		}
+
+		tflog.Debug(ctx, "Using cached response", map[string]interface{}{"operationName": req.OpName})
+		return json.Unmarshal(entry.data, resp.Data)
+	}
+}
+
+// entry returns the live entry for the key, or creates one and reports that
+// the caller has to fill it.
+func (c *cachingClient) entry(key cacheKey) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		select {
+		case <-entry.done:
+			if entry.err == nil && c.now().Before(entry.expiresAt) {
+				return entry, false
+			}
+		default:
+			return entry, false
+		}
+	}
+
+	entry := &cacheEntry{done: make(chan struct{})}
+	c.entries[key] = entry
+	return entry, true
+}
+
+func (c *cachingClient) fill(ctx context.Context, key cacheKey, entry *cacheEntry, req *graphql.Request, resp *graphql.Response) {
+	defer close(entry.done)
+
+	entry.err = c.next.MakeRequest(ctx, req, resp)
+	if entry.err == nil {
+		entry.data, entry.err = json.Marshal(resp.Data)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.expiresAt = c.now().Add(c.ttl)
+	if entry.err != nil && c.entries[key] == entry {
+		delete(c.entries, key)
+	}
+}
+
+func (c *cachingClient) invalidate(queries []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		for _, query := range queries {
+			if key.opName == query {
+				delete(c.entries, key)
+			}
+		}
+	}
+}
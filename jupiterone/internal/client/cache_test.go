@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQlientCachesListQueriesUntilMutated(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	config, done := newRetryTestQlient(func(w http.ResponseWriter, r *http.Request) {
+		var req graphql.Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		calls[req.OpName]++
+		mu.Unlock()
+
+		switch req.OpName {
+		case "GetInvitations":
+			fmt.Fprint(w, `{"data":{"iamGetAccount":{"id":"account","accountInvitations":{"items":[{"id":"1","email":"a@b.c","status":"PENDING"}]}}}}`)
+		case "RevokeInvitation":
+			fmt.Fprint(w, `{"data":{"updateInvitation":{"id":"1"}}}`)
+		default:
+			fmt.Fprint(w, `{"data":{}}`)
+		}
+	}, 1)
+	defer done()
+
+	qlient, err := config.Qlient(context.TODO())
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			invitations, err := GetInvitations(context.TODO(), qlient, "")
+			assert.NoError(t, err)
+			assert.Equal(t, "a@b.c", invitations.IamGetAccount.AccountInvitations.Items[0].Email)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, calls["GetInvitations"], "concurrent reads share one request")
+
+	_, err = RevokeInvitation(context.TODO(), qlient, "1")
+	assert.NoError(t, err)
+
+	_, err = GetInvitations(context.TODO(), qlient, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls["GetInvitations"], "mutations invalidate the queries they change")
+}
+
+type countingClient struct {
+	calls int32
+	err   error
+}
+
+func (c *countingClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	atomic.AddInt32(&c.calls, 1)
+	if c.err != nil {
+		return c.err
+	}
+	return json.Unmarshal([]byte(`{"iamGetAccount":{"id":"account"}}`), resp.Data)
+}
+
+func TestCachingClientExpiresEntries(t *testing.T) {
+	now := time.Unix(0, 0)
+	next := &countingClient{}
+	c := newCachingClient(next, time.Minute)
+	c.now = func() time.Time { return now }
+
+	_, err := GetInvitations(context.TODO(), c, "")
+	assert.NoError(t, err)
+	_, err = GetUsersByEmail(context.TODO(), c, "a@b.c")
+	assert.NoError(t, err)
+	_, err = GetInvitations(context.TODO(), c, "")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, next.calls)
+
+	now = now.Add(time.Minute)
+	_, err = GetInvitations(context.TODO(), c, "")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, next.calls, "expired entries are fetched again")
+}
+
+func TestCachingClientDoesNotCacheErrors(t *testing.T) {
+	next := &countingClient{err: fmt.Errorf("boom")}
+	c := newCachingClient(next, time.Minute)
+
+	_, err := GetInvitations(context.TODO(), c, "")
+	assert.Error(t, err)
+	_, err = GetInvitations(context.TODO(), c, "")
+	assert.Error(t, err)
+	assert.EqualValues(t, 2, next.calls)
+
+	_, err = GetCollector(context.TODO(), c, "1")
+	assert.Error(t, err)
+	_, err = GetCollector(context.TODO(), c, "1")
+	assert.Error(t, err)
+	assert.EqualValues(t, 4, next.calls, "other queries are never cached")
+}
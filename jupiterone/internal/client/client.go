@@ -7,126 +7,104 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"math"
-	"math/rand"
 	"net/http"
 	"os"
-	"strconv"
-	"sync"
+	"strings"
 	"time"
 
 	"github.com/Khan/genqlient/graphql"
-	genql "github.com/Khan/genqlient/graphql"
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 )
 
 const DefaultRegion string = "us"
-const MaxBackoff = 60 * time.Second
-const MinBackoff = 15 * time.Second
-const PowerOfTwo = 2
-
-var (
-	lastNon429Response time.Time
-	timestampMutex     sync.Mutex
-)
-
-func updateLastNon429Response() {
-	timestampMutex.Lock()
-	defer timestampMutex.Unlock()
-	lastNon429Response = time.Now()
-}
 
 type JupiterOneClientConfig struct {
 	APIKey    string
 	AccountID string
 	Region    string
+	// Endpoint overrides the regional GraphQL endpoint URL when set.
+	Endpoint string
+	// ClientID and ClientSecret enable the OAuth client credentials flow in
+	// place of a static APIKey. Access tokens are requested from TokenURL, or
+	// the regional default when it is empty.
+	ClientID     string
+	ClientSecret string
+	TokenURL     string
+	// APIKeyCommand is a credential helper command that prints the API key as
+	// JSON. It is run again whenever the API rejects the current key, so keys
+	// rotated during an apply are picked up. APIKey, when set, is used until
+	// the first rejection.
+	APIKeyCommand string
+	// ProxyURL routes API requests through an HTTP proxy instead of the one
+	// from the HTTP_PROXY/HTTPS_PROXY environment variables.
+	ProxyURL string
+	// CACertFile is a PEM bundle of additional certificate authorities to
+	// trust, for example the CA of a TLS intercepting egress proxy.
+	CACertFile string
+	// ClientCertFile and ClientKeyFile are the PEM encoded certificate and
+	// key presented to the endpoint for mutual TLS.
+	ClientCertFile string
+	ClientKeyFile  string
+	// MaxAttempts, MinBackoff and MaxBackoff configure the retries of rate
+	// limited and failed requests, zero values use the defaults.
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
 	// RoundTripper is mostly used to inject the `go-vcr` transport recorder
 	// for testing
 	RoundTripper http.RoundTripper
 }
 
 type jupiterOneTransport struct {
-	apiKey, accountID string
-	base              http.RoundTripper
+	accountID string
+	tokens    tokenSource
+	base      http.RoundTripper
 }
 
 func (t *jupiterOneTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req.Header.Set("LifeOmic-Account", t.accountID)
-	req.Header.Set("Cache-Control", "no-cache")
-	req.Header.Set("Authorization", "Bearer "+t.apiKey)
-	return t.base.RoundTrip(req)
-}
-
-// RetryTransport is a custom RoundTripper that adds retry logic with backoff.
-type RetryTransport struct {
-	Transport  http.RoundTripper
-	MinBackoff time.Duration
-	MaxBackoff time.Duration
-}
-
-func (rt *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	var resp *http.Response
-	var err error
-
-	ctx := req.Context()
+	refreshable, ok := t.tokens.(refreshableTokenSource)
+	if !ok {
+		return t.roundTrip(req)
+	}
 
-	// We need to keep a copy of the body because each request it gets consumed
+	// Keep a copy of the body so the request can be sent again with a new
+	// token if the current one is rejected.
 	var bodyBytes []byte
 	if req.Body != nil {
+		var err error
 		bodyBytes, err = io.ReadAll(req.Body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read request body: %v", err)
 		}
-	}
-
-	for i := 0; i >= 0; i++ {
-		// Setting the body for the request
 		req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+	}
 
-		resp, err = rt.Transport.RoundTrip(req)
-		if err != nil {
-			return nil, err
-		}
-
-		if resp.StatusCode != http.StatusTooManyRequests {
-			updateLastNon429Response()
-			return resp, nil
-		}
-
-		// If this is not the first try, and the lastNon429Response
-		// was more than 90 seconds ago, we should break out of the loop
-		// and return the last response.
-		timestampMutex.Lock()
-		if i > 0 && time.Since(lastNon429Response) > 90*time.Second {
-			timestampMutex.Unlock()
-			tflog.Warn(ctx, "Not going to retry, we haven't got a non 429 in a while")
-			return resp, nil
-		}
-		timestampMutex.Unlock()
-
-		tflog.Debug(ctx, "Retrying after getting a 429 response")
-
-		// Calculate the backoff time using exponential backoff with jitter.
-		backoff := rt.MinBackoff * time.Duration(math.Pow(PowerOfTwo, float64(i)))
-		jitter := time.Duration(rand.Int63n(int64(rt.MinBackoff)))
-		sleepDuration := backoff + jitter
+	resp, err := t.roundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
 
-		// Ensure we do not exceed the maximum backoff time.
-		if sleepDuration > rt.MaxBackoff {
-			sleepDuration = rt.MaxBackoff
-		}
+	tflog.Debug(req.Context(), "Credentials were rejected, refreshing them and retrying")
 
-		// Convert duration to seconds
-		var backoffSeconds = int(sleepDuration.Seconds())
+	refreshable.Invalidate(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+	resp.Body.Close()
 
-		tflog.Debug(ctx, "Backoff info", map[string]interface{}{"sleepDurationSeconds": backoffSeconds, "retryCount": i})
+	req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+	return t.roundTrip(req)
+}
 
-		time.Sleep(sleepDuration)
+func (t *jupiterOneTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.tokens.Token(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
 	}
 
-	return resp, fmt.Errorf("after many attempts, last status: %s", strconv.Itoa(resp.StatusCode))
+	req.Header.Set("LifeOmic-Account", t.accountID)
+	req.Header.Set("Cache-Control", "no-cache")
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(req)
 }
 
 func (c *JupiterOneClientConfig) getRegion(ctx context.Context) string {
@@ -141,40 +119,103 @@ func (c *JupiterOneClientConfig) getRegion(ctx context.Context) string {
 }
 
 func (c *JupiterOneClientConfig) getGraphQLEndpoint(ctx context.Context) string {
+	if c.Endpoint != "" {
+		tflog.Info(ctx, "Utilizing endpoint", map[string]interface{}{"endpoint": c.Endpoint})
+		return c.Endpoint
+	}
 	return "https://graphql." + c.getRegion(ctx) + ".jupiterone.io/"
 }
 
+func (c *JupiterOneClientConfig) getTokenEndpoint(ctx context.Context) string {
+	if c.TokenURL != "" {
+		return c.TokenURL
+	}
+	return "https://api." + c.getRegion(ctx) + ".jupiterone.io/oauth/token"
+}
+
+// UsesClientCredentials reports whether the config authenticates with OAuth
+// client credentials instead of an API key.
+func (c *JupiterOneClientConfig) UsesClientCredentials() bool {
+	return c.ClientID != "" || c.ClientSecret != ""
+}
+
+func (c *JupiterOneClientConfig) tokenSource(ctx context.Context, base http.RoundTripper) tokenSource {
+	if c.APIKeyCommand != "" {
+		return newCommandTokenSource(c.APIKeyCommand, c.APIKey)
+	}
+
+	if !c.UsesClientCredentials() {
+		return staticTokenSource(c.APIKey)
+	}
+
+	// The token endpoint is called with the same base transport as the API so
+	// that test recorders and custom transports also see token requests.
+	tokenClient := cleanhttp.DefaultClient()
+	tokenClient.Transport = logging.NewLoggingHTTPTransport(base)
+
+	return newOAuthTokenSource(c.getTokenEndpoint(ctx), c.ClientID, c.ClientSecret, tokenClient)
+}
+
 // NewQlientFromEnv configures the J1 client itself from the environment
 // variables for use in testing.
-func NewQlientFromEnv(ctx context.Context, transport http.RoundTripper) graphql.Client {
+func NewQlientFromEnv(ctx context.Context, transport http.RoundTripper) (graphql.Client, error) {
 	config := JupiterOneClientConfig{
 		APIKey:       os.Getenv("JUPITERONE_API_KEY"),
 		AccountID:    os.Getenv("JUPITERONE_ACCOUNT_ID"),
 		Region:       os.Getenv("JUPITERONE_REGION"),
+		ClientID:     os.Getenv("JUPITERONE_CLIENT_ID"),
+		ClientSecret: os.Getenv("JUPITERONE_CLIENT_SECRET"),
+		TokenURL:     os.Getenv("JUPITERONE_OAUTH_TOKEN_URL"),
 		RoundTripper: transport,
 	}
 
 	return config.Qlient(ctx)
 }
 
-func (c *JupiterOneClientConfig) Qlient(ctx context.Context) graphql.Client {
+func (c *JupiterOneClientConfig) Qlient(ctx context.Context) (graphql.Client, error) {
 	endpoint := c.getGraphQLEndpoint(ctx)
 
-	httpClient := cleanhttp.DefaultClient()
-	if c.RoundTripper != nil {
-		httpClient.Transport = c.RoundTripper
+	base, err := c.baseTransport()
+	if err != nil {
+		return nil, err
 	}
 
-	httpClient.Transport = &jupiterOneTransport{apiKey: c.APIKey, accountID: c.AccountID, base: httpClient.Transport}
+	httpClient := cleanhttp.DefaultClient()
+	httpClient.Transport = base
+
+	httpClient.Transport = &jupiterOneTransport{
+		accountID: c.AccountID,
+		tokens:    c.tokenSource(ctx, httpClient.Transport),
+		base:      httpClient.Transport,
+	}
 	httpClient.Transport = logging.NewLoggingHTTPTransport(httpClient.Transport)
 
-	httpClient.Transport = &RetryTransport{
-		Transport:  httpClient.Transport,
-		MinBackoff: MinBackoff,
-		MaxBackoff: MaxBackoff,
+	// The limiter is shared by all retries of a request so that they also
+	// respect the advertised budget.
+	httpClient.Transport = &rateLimitTransport{
+		limiter: newRateLimiter(),
+		next:    httpClient.Transport,
 	}
 
-	client := genql.NewClient(endpoint, httpClient)
+	retry := &RetryTransport{
+		Transport:   httpClient.Transport,
+		MinBackoff:  c.MinBackoff,
+		MaxBackoff:  c.MaxBackoff,
+		MaxAttempts: c.MaxAttempts,
+	}
+	if retry.MinBackoff == 0 {
+		retry.MinBackoff = MinBackoff
+	}
+	if retry.MaxBackoff == 0 {
+		retry.MaxBackoff = MaxBackoff
+	}
+	if retry.MaxAttempts == 0 {
+		retry.MaxAttempts = DefaultMaxAttempts
+	}
+	httpClient.Transport = retry
 
-	return client
+	return newCachingClient(&graphQLClient{
+		endpoint:   endpoint,
+		httpClient: httpClient,
+	}, CacheTTL), nil
 }
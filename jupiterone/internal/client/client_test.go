@@ -2,7 +2,13 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -21,3 +27,195 @@ func TestGetGraphqlEndpointNoOverride(t *testing.T) {
 	endpoint := config.getGraphQLEndpoint(context.TODO())
 	assert.Equal(t, endpoint, "https://graphql.dev.jupiterone.io/", "Endpoints should match")
 }
+
+func TestGetTokenEndpointDefaultsToRegion(t *testing.T) {
+	config := JupiterOneClientConfig{
+		Region: "dev",
+	}
+
+	endpoint := config.getTokenEndpoint(context.TODO())
+	assert.Equal(t, "https://api.dev.jupiterone.io/oauth/token", endpoint, "Endpoints should match")
+
+	config.TokenURL = "http://localhost:8080/token"
+	endpoint = config.getTokenEndpoint(context.TODO())
+	assert.Equal(t, "http://localhost:8080/token", endpoint, "Endpoints should match")
+}
+
+func TestOAuthTokenSourceCachesAndRefreshes(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		id, secret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "client", id)
+		assert.Equal(t, "secret", secret)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":300}`, requests)
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	source := newOAuthTokenSource(server.URL, "client", "secret", server.Client())
+	source.now = func() time.Time { return now }
+
+	token, err := source.Token(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// still well within the expiry window, so the cached token is reused
+	now = now.Add(200 * time.Second)
+	token, err = source.Token(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// inside TokenExpiryDelta of the expiry, so a new token is requested
+	now = now.Add(60 * time.Second)
+	token, err = source.Token(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, 2, requests)
+}
+
+func TestOAuthTokenSourceDefaultsLifetime(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer"}`, requests)
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	source := newOAuthTokenSource(server.URL, "client", "secret", server.Client())
+	source.now = func() time.Time { return now }
+
+	token, err := source.Token(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// without expires_in the token is cached for DefaultTokenLifetime
+	now = now.Add(DefaultTokenLifetime - 2*TokenExpiryDelta)
+	token, err = source.Token(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	now = now.Add(TokenExpiryDelta)
+	token, err = source.Token(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+}
+
+func TestOAuthTokenSourceReturnsEndpointErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":"invalid_client"}`)
+	}))
+	defer server.Close()
+
+	source := newOAuthTokenSource(server.URL, "client", "wrong", server.Client())
+
+	_, err := source.Token(context.TODO())
+	assert.ErrorContains(t, err, "invalid_client")
+}
+
+func TestQlientUsesOAuthAccessToken(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			fmt.Fprint(w, `{"access_token":"short-lived","token_type":"bearer","expires_in":3600}`)
+			return
+		}
+		authorization = r.Header.Get("Authorization")
+		fmt.Fprint(w, `{"data":{"deleteQuestion":{"id":"1"}}}`)
+	}))
+	defer server.Close()
+
+	config := JupiterOneClientConfig{
+		AccountID:    "account",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		TokenURL:     server.URL + "/token",
+		RoundTripper: rewriteHostTransport{target: server.URL},
+	}
+
+	qlient, err := config.Qlient(context.TODO())
+	assert.NoError(t, err)
+
+	_, err = DeleteQuestion(context.TODO(), qlient, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer short-lived", authorization)
+}
+
+// rewriteHostTransport sends every request to the test server regardless of
+// the regional endpoint the client was built with.
+type rewriteHostTransport struct {
+	target string
+}
+
+func (t rewriteHostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, err := url.Parse(t.target)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.Scheme = target.Scheme
+	req.URL.Host = target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRunCredentialCommand(t *testing.T) {
+	creds, err := RunCredentialCommand(context.TODO(), `echo '{"api_key":"key","account_id":"account"}'`)
+	assert.NoError(t, err)
+	assert.Equal(t, "key", creds.APIKey)
+	assert.Equal(t, "account", creds.AccountID)
+
+	_, err = RunCredentialCommand(context.TODO(), `echo '{"account_id":"account"}'`)
+	assert.ErrorContains(t, err, "did not include an api_key")
+
+	_, err = RunCredentialCommand(context.TODO(), `echo "vault is sealed" >&2; exit 2`)
+	assert.ErrorContains(t, err, "vault is sealed")
+}
+
+func TestQlientRerunsCredentialCommandOnUnauthorized(t *testing.T) {
+	// The helper prints a new key each time it is run
+	counter := filepath.Join(t.TempDir(), "count")
+	command := fmt.Sprintf(`echo x >> %s; printf '{"api_key":"key-%%s"}' "$(wc -l < %s | tr -d ' ')"`, counter, counter)
+
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") != "Bearer key-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"errors":[{"message":"Unauthorized"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"deleteQuestion":{"id":"1"}}}`)
+	}))
+	defer server.Close()
+
+	config := JupiterOneClientConfig{
+		AccountID:     "account",
+		APIKeyCommand: command,
+		RoundTripper:  rewriteHostTransport{target: server.URL},
+	}
+
+	qlient, err := config.Qlient(context.TODO())
+	assert.NoError(t, err)
+
+	_, err = DeleteQuestion(context.TODO(), qlient, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bearer key-1", "Bearer key-2"}, authorizations)
+}
+
+func TestGetGraphqlEndpointOverride(t *testing.T) {
+	config := JupiterOneClientConfig{
+		Region:   "dev",
+		Endpoint: "https://graphql.staging.example.com/",
+	}
+
+	endpoint := config.getGraphQLEndpoint(context.TODO())
+	assert.Equal(t, endpoint, "https://graphql.staging.example.com/", "Endpoints should match")
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// CredentialCommandTimeout bounds how long a credential helper command may
+// run before it is killed.
+const CredentialCommandTimeout = 30 * time.Second
+
+// CommandCredentials is the JSON document a credential helper command must
+// print to stdout.
+type CommandCredentials struct {
+	APIKey    string `json:"api_key"`
+	AccountID string `json:"account_id,omitempty"`
+}
+
+// RunCredentialCommand runs the credential helper command through the system
+// shell, the same way git runs credential helpers, and parses the credentials
+// it prints to stdout.
+func RunCredentialCommand(ctx context.Context, command string) (*CommandCredentials, error) {
+	ctx, cancel := context.WithTimeout(ctx, CredentialCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("credential command failed: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("credential command failed: %w", err)
+	}
+
+	var creds CommandCredentials
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return nil, fmt.Errorf("credential command output is not valid JSON: %w", err)
+	}
+
+	if creds.APIKey == "" {
+		return nil, fmt.Errorf("credential command output did not include an api_key")
+	}
+
+	return &creds, nil
+}
+
+// commandTokenSource uses the API key printed by a credential helper command
+// and runs the command again after the key has been rejected by the API.
+type commandTokenSource struct {
+	command string
+
+	mu     sync.Mutex
+	apiKey string
+}
+
+func newCommandTokenSource(command, apiKey string) *commandTokenSource {
+	return &commandTokenSource{
+		command: command,
+		apiKey:  apiKey,
+	}
+}
+
+// Token implements tokenSource
+func (s *commandTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.apiKey != "" {
+		return s.apiKey, nil
+	}
+
+	tflog.Debug(ctx, "Running credential command to fetch API key")
+
+	creds, err := RunCredentialCommand(ctx, s.command)
+	if err != nil {
+		return "", err
+	}
+
+	s.apiKey = creds.APIKey
+	return s.apiKey, nil
+}
+
+// Invalidate implements refreshableTokenSource
+func (s *commandTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Only drop the key if it is the one that was rejected, concurrent
+	// requests may have already fetched a fresh one.
+	if s.apiKey == token {
+		s.apiKey = ""
+	}
+}
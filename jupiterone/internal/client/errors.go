@@ -0,0 +1,260 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// The kinds of errors returned by the API. Use errors.Is to check the kind of
+// an error returned by one of the operations:
+//
+//	if errors.Is(err, client.ErrNotFound) {
+//		resp.State.RemoveResource(ctx)
+//	}
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrForbidden       = errors.New("forbidden")
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrConflict        = errors.New("conflict")
+	ErrValidation      = errors.New("validation failed")
+	ErrRateLimited     = errors.New("rate limited")
+)
+
+// Error is an error response from the API, classified from the HTTP status
+// or the extensions of the GraphQL errors.
+type Error struct {
+	// Kind is one of the Err* values, or nil when the error could not be
+	// classified.
+	Kind       error
+	StatusCode int
+	Message    string
+	// GraphQLErrors holds the errors of the GraphQL response, it is empty
+	// for HTTP errors.
+	GraphQLErrors gqlerror.List
+}
+
+func (e *Error) Error() string {
+	if len(e.GraphQLErrors) > 0 {
+		return e.GraphQLErrors.Error()
+	}
+	return fmt.Sprintf("returned error %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Is reports whether the error is of the kind target.
+func (e *Error) Is(target error) bool {
+	return e.Kind != nil && e.Kind == target
+}
+
+// Unwrap returns the GraphQL errors so they can be inspected with errors.As.
+func (e *Error) Unwrap() error {
+	if len(e.GraphQLErrors) == 0 {
+		return nil
+	}
+	return e.GraphQLErrors
+}
+
+// IsNotFound reports whether the API said the requested object does not exist
+// with a NOT_FOUND code. Not every service uses the code, for example a
+// missing integration instance is a BAD_USER_INPUT error, so reads also check
+// the message of their service.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsForbidden reports whether the credentials lack the permission for the
+// request.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// newHTTPError classifies a non-200 response.
+func newHTTPError(statusCode int, body []byte) *Error {
+	return &Error{
+		Kind:       statusKind(statusCode),
+		StatusCode: statusCode,
+		Message:    strings.TrimSpace(string(body)),
+	}
+}
+
+// newGraphQLError classifies the errors of a GraphQL response by the first
+// error with a recognized code.
+func newGraphQLError(statusCode int, errs gqlerror.List) *Error {
+	e := &Error{
+		StatusCode:    statusCode,
+		Message:       errs.Error(),
+		GraphQLErrors: errs,
+	}
+
+	for _, gqlErr := range errs {
+		if kind := graphQLErrorKind(gqlErr); kind != nil {
+			e.Kind = kind
+			break
+		}
+	}
+
+	return e
+}
+
+func statusKind(statusCode int) error {
+	switch statusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusUnauthorized:
+		return ErrUnauthenticated
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrValidation
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	return nil
+}
+
+func graphQLErrorKind(err *gqlerror.Error) error {
+	if err == nil {
+		return nil
+	}
+
+	code, _ := err.Extensions["code"].(string)
+	switch strings.ToUpper(code) {
+	case "NOT_FOUND":
+		return ErrNotFound
+	case "FORBIDDEN":
+		return ErrForbidden
+	case "UNAUTHENTICATED", "UNAUTHORIZED":
+		return ErrUnauthenticated
+	case "CONFLICT", "ALREADY_EXISTS":
+		return ErrConflict
+	case "BAD_USER_INPUT", "GRAPHQL_VALIDATION_FAILED", "VALIDATION_ERROR":
+		return ErrValidation
+	case "RATE_LIMITED", "TOO_MANY_REQUESTS":
+		return ErrRateLimited
+	}
+
+	// The message is not used: it may mention an unrelated object, such as
+	// a validation error about a missing reference, and classifying that as
+	// a missing resource would drop the resource from state.
+	return nil
+}
+
+// FieldError is an error about one field of the input variables of an
+// operation.
+type FieldError struct {
+	// Path is the location of the field in the input variable, made of
+	// field names and list indexes, such as operations[2].actions[0] for
+	// []interface{}{"operations", 2, "actions", 0}.
+	Path    []interface{}
+	Message string
+}
+
+func (e FieldError) String() string {
+	return FormatFieldPath(e.Path) + ": " + e.Message
+}
+
+var (
+	// graphql-js reports invalid variables as:
+	// Variable "$instance" got invalid value 1 at "instance.operations[2].actions[0]"; Expected type "String".
+	variablePattern = regexp.MustCompile(`Variable "\$(\w+)" got invalid value`)
+	atPattern       = regexp.MustCompile(` at "([^"]+)"`)
+	// Missing and unknown fields are only named in the message:
+	// Field "config" of required type "JSON!" was not provided.
+	fieldPattern = regexp.MustCompile(`Field "(\w+)" (?:of required type|is not defined)`)
+)
+
+// FieldErrors splits the GraphQL errors of err into the variable validation
+// errors of graphql-js, which name the offending input field in their message,
+// and the remaining errors. No recorded error of the API names the field in
+// its extensions.
+func FieldErrors(err error) ([]FieldError, gqlerror.List) {
+	var errs gqlerror.List
+	if !errors.As(err, &errs) {
+		return nil, nil
+	}
+
+	var fieldErrors []FieldError
+	var other gqlerror.List
+	for _, gqlErr := range errs {
+		if p := inputPath(gqlErr); len(p) > 0 {
+			fieldErrors = append(fieldErrors, FieldError{Path: p, Message: gqlErr.Message})
+		} else {
+			other = append(other, gqlErr)
+		}
+	}
+
+	return fieldErrors, other
+}
+
+func inputPath(err *gqlerror.Error) []interface{} {
+	variable := variablePattern.FindStringSubmatch(err.Message)
+	if variable == nil {
+		return nil
+	}
+
+	var p []interface{}
+	if at := atPattern.FindStringSubmatch(err.Message); at != nil {
+		p = ParseFieldPath(at[1])
+		// The location starts with the name of the variable itself.
+		if len(p) > 0 && p[0] == variable[1] {
+			p = p[1:]
+		}
+	}
+	if field := fieldPattern.FindStringSubmatch(err.Message); field != nil {
+		p = append(p, field[1])
+	}
+
+	return p
+}
+
+// ParseFieldPath parses a path such as operations[2].actions[0].
+func ParseFieldPath(s string) []interface{} {
+	var p []interface{}
+	for _, part := range strings.Split(s, ".") {
+		for part != "" {
+			open := strings.IndexByte(part, '[')
+			if open < 0 {
+				p = append(p, part)
+				break
+			}
+			if open > 0 {
+				p = append(p, part[:open])
+			}
+			end := strings.IndexByte(part[open:], ']')
+			if end < 0 {
+				return p
+			}
+			index, err := strconv.Atoi(part[open+1 : open+end])
+			if err != nil {
+				return p
+			}
+			p = append(p, index)
+			part = part[open+end+1:]
+		}
+	}
+	return p
+}
+
+// FormatFieldPath is the inverse of ParseFieldPath.
+func FormatFieldPath(p []interface{}) string {
+	var b strings.Builder
+	for _, segment := range p {
+		switch s := segment.(type) {
+		case int:
+			fmt.Fprintf(&b, "[%d]", s)
+		default:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			fmt.Fprint(&b, s)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestQlientClassifiesErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		kind   error
+	}{
+		{"not found code", http.StatusOK, `{"errors":[{"message":"Control not found","extensions":{"code":"NOT_FOUND"}}]}`, ErrNotFound},
+		{"not found message", http.StatusOK, `{"errors":[{"message":"Rule instance does not exist"}]}`, nil},
+		{"validation message about a missing object", http.StatusOK, `{"errors":[{"message":"Question not found for rule"}]}`, nil},
+		{"forbidden code", http.StatusOK, `{"errors":[{"message":"Nope","extensions":{"code":"FORBIDDEN"}}]}`, ErrForbidden},
+		{"validation code", http.StatusOK, `{"errors":[{"message":"Bad","extensions":{"code":"BAD_USER_INPUT"}}]}`, ErrValidation},
+		{"conflict code", http.StatusOK, `{"errors":[{"message":"Parameter already exists","extensions":{"code":"CONFLICT"}}]}`, ErrConflict},
+		{"conflict message", http.StatusOK, `{"errors":[{"message":"Parameter already exists"}]}`, nil},
+		{"forbidden status", http.StatusForbidden, `Forbidden`, ErrForbidden},
+		{"unauthenticated status", http.StatusUnauthorized, `Unauthorized`, ErrUnauthenticated},
+		{"rate limited status", http.StatusTooManyRequests, `Slow down`, ErrRateLimited},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+			defer server.Close()
+
+			config := JupiterOneClientConfig{
+				APIKey:       "key",
+				AccountID:    "account",
+				MaxAttempts:  1,
+				RoundTripper: rewriteHostTransport{target: server.URL},
+			}
+
+			qlient, err := config.Qlient(context.TODO())
+			assert.NoError(t, err)
+
+			_, err = GetCollector(context.TODO(), qlient, "1")
+
+			var apiErr *Error
+			assert.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tc.status, apiErr.StatusCode)
+			if tc.kind == nil {
+				assert.Nil(t, apiErr.Kind, "only error codes are classified")
+			} else {
+				assert.ErrorIs(t, err, tc.kind)
+			}
+		})
+	}
+}
+
+func TestErrorUnwrapsGraphQLErrors(t *testing.T) {
+	err := newGraphQLError(http.StatusOK, gqlerror.List{{Message: "Something broke"}})
+
+	assert.False(t, IsNotFound(err))
+	assert.Nil(t, err.Kind)
+	assert.Contains(t, err.Error(), "Something broke")
+
+	var list gqlerror.List
+	assert.True(t, errors.As(err, &list))
+}
+
+func TestFieldErrors(t *testing.T) {
+	err := &Error{GraphQLErrors: gqlerror.List{
+		{Message: `Variable "$instance" got invalid value 1 at "instance.operations[2].actions[0]"; Expected type "String".`},
+		{Message: `Variable "$instance" got invalid value {}; Field "config" of required type "JSON!" was not provided.`},
+		{Message: "Invalid cron", Extensions: map[string]interface{}{"code": "BAD_USER_INPUT"}},
+		{Message: "Internal error"},
+	}}
+
+	fieldErrors, other := FieldErrors(fmt.Errorf("wrapped: %w", err))
+	assert.Equal(t, []FieldError{
+		{Path: []interface{}{"operations", 2, "actions", 0}, Message: err.GraphQLErrors[0].Message},
+		{Path: []interface{}{"config"}, Message: err.GraphQLErrors[1].Message},
+	}, fieldErrors)
+	assert.Len(t, other, 2)
+
+	fieldErrors, other = FieldErrors(errors.New("boom"))
+	assert.Nil(t, fieldErrors)
+	assert.Nil(t, other)
+}
+
+func TestParseFieldPath(t *testing.T) {
+	p := ParseFieldPath("operations[2].actions[0].type")
+	assert.Equal(t, []interface{}{"operations", 2, "actions", 0, "type"}, p)
+	assert.Equal(t, "operations[2].actions[0].type", FormatFieldPath(p))
+
+	assert.Equal(t, []interface{}{"queries", 0, 1}, ParseFieldPath("queries[0][1]"))
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/jsonvalue"
 )
 
 // ArchiveCustomIntegrationDefinitionArchiveCustomIntegrationDefinitionArchiveCustomIntegrationDefinitionResult includes the requested fields of the GraphQL type ArchiveCustomIntegrationDefinitionResult.
@@ -222,7 +223,7 @@ type CreateComplianceFrameworkInput struct {
 	Version       string                  `json:"version"`
 	FrameworkType ComplianceFrameworkType `json:"frameworkType"`
 	WebLink       string                  `json:"webLink"`
-	ScopeFilters  []interface{}           `json:"scopeFilters"`
+	ScopeFilters  []jsonvalue.JSON        `json:"scopeFilters"`
 }
 
 // GetName returns CreateComplianceFrameworkInput.Name, and is useful for accessing the field via an interface.
@@ -240,7 +241,7 @@ func (v *CreateComplianceFrameworkInput) GetFrameworkType() ComplianceFrameworkT
 func (v *CreateComplianceFrameworkInput) GetWebLink() string { return v.WebLink }
 
 // GetScopeFilters returns CreateComplianceFrameworkInput.ScopeFilters, and is useful for accessing the field via an interface.
-func (v *CreateComplianceFrameworkInput) GetScopeFilters() []interface{} { return v.ScopeFilters }
+func (v *CreateComplianceFrameworkInput) GetScopeFilters() []jsonvalue.JSON { return v.ScopeFilters }
 
 // CreateComplianceFrameworkItemCreateComplianceFrameworkItem includes the requested fields of the GraphQL type ComplianceFrameworkItem.
 type CreateComplianceFrameworkItemCreateComplianceFrameworkItem struct {
@@ -916,7 +917,7 @@ type CreateInlineQuestionRuleInstanceCreateQuestionRuleInstance struct {
 	ResourceGroupId                 string                                                                                `json:"resourceGroupId"`
 	QuestionId                      string                                                                                `json:"questionId"`
 	QuestionName                    string                                                                                `json:"questionName"`
-	Templates                       interface{}                                                                           `json:"templates"`
+	Templates                       jsonvalue.JSON                                                                        `json:"templates"`
 	Question                        CreateInlineQuestionRuleInstanceCreateQuestionRuleInstanceQuestionRuleQuestionDetails `json:"question"`
 	Operations                      []CreateInlineQuestionRuleInstanceCreateQuestionRuleInstanceOperationsRuleOperation   `json:"operations"`
 	Labels                          []CreateInlineQuestionRuleInstanceCreateQuestionRuleInstanceLabelsRuleInstanceLabel   `json:"labels"`
@@ -1054,7 +1055,7 @@ func (v *CreateInlineQuestionRuleInstanceCreateQuestionRuleInstance) GetQuestion
 }
 
 // GetTemplates returns CreateInlineQuestionRuleInstanceCreateQuestionRuleInstance.Templates, and is useful for accessing the field via an interface.
-func (v *CreateInlineQuestionRuleInstanceCreateQuestionRuleInstance) GetTemplates() interface{} {
+func (v *CreateInlineQuestionRuleInstanceCreateQuestionRuleInstance) GetTemplates() jsonvalue.JSON {
 	return v.Templates
 }
 
@@ -1091,17 +1092,17 @@ func (v *CreateInlineQuestionRuleInstanceCreateQuestionRuleInstanceLabelsRuleIns
 
 // CreateInlineQuestionRuleInstanceCreateQuestionRuleInstanceOperationsRuleOperation includes the requested fields of the GraphQL type RuleOperation.
 type CreateInlineQuestionRuleInstanceCreateQuestionRuleInstanceOperationsRuleOperation struct {
-	When    interface{}   `json:"when"`
-	Actions []interface{} `json:"actions"`
+	When    jsonvalue.JSON   `json:"when"`
+	Actions []jsonvalue.JSON `json:"actions"`
 }
 
 // GetWhen returns CreateInlineQuestionRuleInstanceCreateQuestionRuleInstanceOperationsRuleOperation.When, and is useful for accessing the field via an interface.
-func (v *CreateInlineQuestionRuleInstanceCreateQuestionRuleInstanceOperationsRuleOperation) GetWhen() interface{} {
+func (v *CreateInlineQuestionRuleInstanceCreateQuestionRuleInstanceOperationsRuleOperation) GetWhen() jsonvalue.JSON {
 	return v.When
 }
 
 // GetActions returns CreateInlineQuestionRuleInstanceCreateQuestionRuleInstanceOperationsRuleOperation.Actions, and is useful for accessing the field via an interface.
-func (v *CreateInlineQuestionRuleInstanceCreateQuestionRuleInstanceOperationsRuleOperation) GetActions() []interface{} {
+func (v *CreateInlineQuestionRuleInstanceCreateQuestionRuleInstanceOperationsRuleOperation) GetActions() []jsonvalue.JSON {
 	return v.Actions
 }
 
@@ -1145,7 +1146,7 @@ func (v *CreateInlineQuestionRuleInstanceCreateQuestionRuleInstanceQuestionRuleQ
 
 type CreateInlineQuestionRuleInstanceInput struct {
 	Question                        RuleQuestionDetailsInput `json:"question"`
-	Templates                       interface{}              `json:"templates"`
+	Templates                       jsonvalue.JSON           `json:"templates"`
 	Tags                            []string                 `json:"tags"`
 	Name                            string                   `json:"name"`
 	Description                     string                   `json:"description"`
@@ -1168,7 +1169,7 @@ func (v *CreateInlineQuestionRuleInstanceInput) GetQuestion() RuleQuestionDetail
 }
 
 // GetTemplates returns CreateInlineQuestionRuleInstanceInput.Templates, and is useful for accessing the field via an interface.
-func (v *CreateInlineQuestionRuleInstanceInput) GetTemplates() interface{} { return v.Templates }
+func (v *CreateInlineQuestionRuleInstanceInput) GetTemplates() jsonvalue.JSON { return v.Templates }
 
 // GetTags returns CreateInlineQuestionRuleInstanceInput.Tags, and is useful for accessing the field via an interface.
 func (v *CreateInlineQuestionRuleInstanceInput) GetTags() []string { return v.Tags }
@@ -1357,7 +1358,7 @@ func (v *CreateInsightsDashboardLayoutItem) GetI() string { return v.I }
 
 type CreateInsightsWidgetConfigInput struct {
 	Queries                   []CreateInsightsWidgetConfigQueryInput `json:"queries"`
-	Settings                  interface{}                            `json:"settings"`
+	Settings                  jsonvalue.JSON                         `json:"settings"`
 	PostQueryFilters          []string                               `json:"postQueryFilters"`
 	DisableQueryPolicyFilters bool                                   `json:"disableQueryPolicyFilters"`
 }
@@ -1368,7 +1369,7 @@ func (v *CreateInsightsWidgetConfigInput) GetQueries() []CreateInsightsWidgetCon
 }
 
 // GetSettings returns CreateInsightsWidgetConfigInput.Settings, and is useful for accessing the field via an interface.
-func (v *CreateInsightsWidgetConfigInput) GetSettings() interface{} { return v.Settings }
+func (v *CreateInsightsWidgetConfigInput) GetSettings() jsonvalue.JSON { return v.Settings }
 
 // GetPostQueryFilters returns CreateInsightsWidgetConfigInput.PostQueryFilters, and is useful for accessing the field via an interface.
 func (v *CreateInsightsWidgetConfigInput) GetPostQueryFilters() []string { return v.PostQueryFilters }
@@ -1435,7 +1436,7 @@ type CreateIntegrationInstanceCreateIntegrationInstance struct {
 	PollingInterval               IntegrationPollingInterval                                                                                              `json:"pollingInterval"`
 	IntegrationDefinitionId       string                                                                                                                  `json:"integrationDefinitionId"`
 	Description                   string                                                                                                                  `json:"description"`
-	Config                        interface{}                                                                                                             `json:"config"`
+	Config                        jsonvalue.JSON                                                                                                          `json:"config"`
 	IngestionSourcesOverrides     []CreateIntegrationInstanceCreateIntegrationInstanceIngestionSourcesOverrides                                           `json:"ingestionSourcesOverrides"`
 	SourceIntegrationInstanceId   string                                                                                                                  `json:"sourceIntegrationInstanceId"`
 	CollectorPoolId               string                                                                                                                  `json:"collectorPoolId"`
@@ -1465,7 +1466,9 @@ func (v *CreateIntegrationInstanceCreateIntegrationInstance) GetDescription() st
 }
 
 // GetConfig returns CreateIntegrationInstanceCreateIntegrationInstance.Config, and is useful for accessing the field via an interface.
-func (v *CreateIntegrationInstanceCreateIntegrationInstance) GetConfig() interface{} { return v.Config }
+func (v *CreateIntegrationInstanceCreateIntegrationInstance) GetConfig() jsonvalue.JSON {
+	return v.Config
+}
 
 // GetIngestionSourcesOverrides returns CreateIntegrationInstanceCreateIntegrationInstance.IngestionSourcesOverrides, and is useful for accessing the field via an interface.
 func (v *CreateIntegrationInstanceCreateIntegrationInstance) GetIngestionSourcesOverrides() []CreateIntegrationInstanceCreateIntegrationInstanceIngestionSourcesOverrides {
@@ -1531,7 +1534,7 @@ type CreateIntegrationInstanceInput struct {
 	PollingIntervalCronExpression IntegrationPollingIntervalCronExpressionInput `json:"pollingIntervalCronExpression,omitempty"`
 	IntegrationDefinitionId       string                                        `json:"integrationDefinitionId"`
 	Description                   string                                        `json:"description"`
-	Config                        interface{}                                   `json:"config"`
+	Config                        jsonvalue.JSON                                `json:"config"`
 	OffsiteComplete               bool                                          `json:"offsiteComplete,omitempty"`
 	IngestionSourcesOverrides     []IngestionSourcesOverridesInput              `json:"ingestionSourcesOverrides,omitempty"`
 	CollectorPoolId               string                                        `json:"collectorPoolId,omitempty"`
@@ -1565,7 +1568,7 @@ func (v *CreateIntegrationInstanceInput) GetIntegrationDefinitionId() string {
 func (v *CreateIntegrationInstanceInput) GetDescription() string { return v.Description }
 
 // GetConfig returns CreateIntegrationInstanceInput.Config, and is useful for accessing the field via an interface.
-func (v *CreateIntegrationInstanceInput) GetConfig() interface{} { return v.Config }
+func (v *CreateIntegrationInstanceInput) GetConfig() jsonvalue.JSON { return v.Config }
 
 // GetOffsiteComplete returns CreateIntegrationInstanceInput.OffsiteComplete, and is useful for accessing the field via an interface.
 func (v *CreateIntegrationInstanceInput) GetOffsiteComplete() bool { return v.OffsiteComplete }
@@ -1681,7 +1684,7 @@ type CreateReferencedQuestionRuleInstanceCreateQuestionRuleInstance struct {
 	ResourceGroupId                 string                                                                                  `json:"resourceGroupId"`
 	QuestionId                      string                                                                                  `json:"questionId"`
 	QuestionName                    string                                                                                  `json:"questionName"`
-	Templates                       interface{}                                                                             `json:"templates"`
+	Templates                       jsonvalue.JSON                                                                          `json:"templates"`
 	Operations                      []RuleOperationOutput                                                                   `json:"operations"`
 	Labels                          []CreateReferencedQuestionRuleInstanceCreateQuestionRuleInstanceLabelsRuleInstanceLabel `json:"labels"`
 }
@@ -1820,7 +1823,7 @@ func (v *CreateReferencedQuestionRuleInstanceCreateQuestionRuleInstance) GetQues
 }
 
 // GetTemplates returns CreateReferencedQuestionRuleInstanceCreateQuestionRuleInstance.Templates, and is useful for accessing the field via an interface.
-func (v *CreateReferencedQuestionRuleInstanceCreateQuestionRuleInstance) GetTemplates() interface{} {
+func (v *CreateReferencedQuestionRuleInstanceCreateQuestionRuleInstance) GetTemplates() jsonvalue.JSON {
 	return v.Templates
 }
 
@@ -1852,7 +1855,7 @@ func (v *CreateReferencedQuestionRuleInstanceCreateQuestionRuleInstanceLabelsRul
 
 type CreateReferencedQuestionRuleInstanceInput struct {
 	QuestionId                      string                   `json:"questionId"`
-	Templates                       interface{}              `json:"templates"`
+	Templates                       jsonvalue.JSON           `json:"templates"`
 	Tags                            []string                 `json:"tags"`
 	Name                            string                   `json:"name"`
 	Description                     string                   `json:"description"`
@@ -1872,7 +1875,7 @@ type CreateReferencedQuestionRuleInstanceInput struct {
 func (v *CreateReferencedQuestionRuleInstanceInput) GetQuestionId() string { return v.QuestionId }
 
 // GetTemplates returns CreateReferencedQuestionRuleInstanceInput.Templates, and is useful for accessing the field via an interface.
-func (v *CreateReferencedQuestionRuleInstanceInput) GetTemplates() interface{} { return v.Templates }
+func (v *CreateReferencedQuestionRuleInstanceInput) GetTemplates() jsonvalue.JSON { return v.Templates }
 
 // GetTags returns CreateReferencedQuestionRuleInstanceInput.Tags, and is useful for accessing the field via an interface.
 func (v *CreateReferencedQuestionRuleInstanceInput) GetTags() []string { return v.Tags }
@@ -2797,7 +2800,7 @@ func (v *DeleteWidgetResponse) GetDeleteWidget() DeleteWidgetDeleteWidgetDeleteR
 type DropRuleConditionInputBeta struct {
 	Property string         `json:"property"`
 	Op       DropRuleOpBeta `json:"op"`
-	Value    interface{}    `json:"value"`
+	Value    jsonvalue.JSON `json:"value"`
 }
 
 // GetProperty returns DropRuleConditionInputBeta.Property, and is useful for accessing the field via an interface.
@@ -2807,7 +2810,7 @@ func (v *DropRuleConditionInputBeta) GetProperty() string { return v.Property }
 func (v *DropRuleConditionInputBeta) GetOp() DropRuleOpBeta { return v.Op }
 
 // GetValue returns DropRuleConditionInputBeta.Value, and is useful for accessing the field via an interface.
-func (v *DropRuleConditionInputBeta) GetValue() interface{} { return v.Value }
+func (v *DropRuleConditionInputBeta) GetValue() jsonvalue.JSON { return v.Value }
 
 type DropRuleInputBeta struct {
 	Id         string                       `json:"id"`
@@ -2907,7 +2910,7 @@ func (v *DropRulesConfigRulesDropRuleBeta) GetConditions() []DropRulesConfigRule
 type DropRulesConfigRulesDropRuleBetaConditionsDropRuleConditionBeta struct {
 	Property string         `json:"property"`
 	Op       DropRuleOpBeta `json:"op"`
-	Value    interface{}    `json:"value"`
+	Value    jsonvalue.JSON `json:"value"`
 }
 
 // GetProperty returns DropRulesConfigRulesDropRuleBetaConditionsDropRuleConditionBeta.Property, and is useful for accessing the field via an interface.
@@ -2921,25 +2924,25 @@ func (v *DropRulesConfigRulesDropRuleBetaConditionsDropRuleConditionBeta) GetOp(
 }
 
 // GetValue returns DropRulesConfigRulesDropRuleBetaConditionsDropRuleConditionBeta.Value, and is useful for accessing the field via an interface.
-func (v *DropRulesConfigRulesDropRuleBetaConditionsDropRuleConditionBeta) GetValue() interface{} {
+func (v *DropRulesConfigRulesDropRuleBetaConditionsDropRuleConditionBeta) GetValue() jsonvalue.JSON {
 	return v.Value
 }
 
 // ExecuteQueryQueryV1QueryV1Response includes the requested fields of the GraphQL type QueryV1Response.
 type ExecuteQueryQueryV1QueryV1Response struct {
-	Type          string      `json:"type"`
-	Data          interface{} `json:"data"`
-	Url           string      `json:"url"`
-	TotalCount    int64       `json:"totalCount"`
-	Cursor        string      `json:"cursor"`
-	CorrelationId string      `json:"correlationId"`
+	Type          string         `json:"type"`
+	Data          jsonvalue.JSON `json:"data"`
+	Url           string         `json:"url"`
+	TotalCount    int64          `json:"totalCount"`
+	Cursor        string         `json:"cursor"`
+	CorrelationId string         `json:"correlationId"`
 }
 
 // GetType returns ExecuteQueryQueryV1QueryV1Response.Type, and is useful for accessing the field via an interface.
 func (v *ExecuteQueryQueryV1QueryV1Response) GetType() string { return v.Type }
 
 // GetData returns ExecuteQueryQueryV1QueryV1Response.Data, and is useful for accessing the field via an interface.
-func (v *ExecuteQueryQueryV1QueryV1Response) GetData() interface{} { return v.Data }
+func (v *ExecuteQueryQueryV1QueryV1Response) GetData() jsonvalue.JSON { return v.Data }
 
 // GetUrl returns ExecuteQueryQueryV1QueryV1Response.Url, and is useful for accessing the field via an interface.
 func (v *ExecuteQueryQueryV1QueryV1Response) GetUrl() string { return v.Url }
@@ -3044,7 +3047,7 @@ type GetComplianceFrameworkByIdComplianceFramework struct {
 	Version       string                                                     `json:"version"`
 	FrameworkType ComplianceFrameworkType                                    `json:"frameworkType"`
 	WebLink       string                                                     `json:"webLink"`
-	ScopeFilters  []interface{}                                              `json:"scopeFilters"`
+	ScopeFilters  []jsonvalue.JSON                                           `json:"scopeFilters"`
 	SummaryConfig GetComplianceFrameworkByIdComplianceFrameworkSummaryConfig `json:"summaryConfig"`
 }
 
@@ -3066,7 +3069,7 @@ func (v *GetComplianceFrameworkByIdComplianceFramework) GetFrameworkType() Compl
 func (v *GetComplianceFrameworkByIdComplianceFramework) GetWebLink() string { return v.WebLink }
 
 // GetScopeFilters returns GetComplianceFrameworkByIdComplianceFramework.ScopeFilters, and is useful for accessing the field via an interface.
-func (v *GetComplianceFrameworkByIdComplianceFramework) GetScopeFilters() []interface{} {
+func (v *GetComplianceFrameworkByIdComplianceFramework) GetScopeFilters() []jsonvalue.JSON {
 	return v.ScopeFilters
 }
 
@@ -3679,11 +3682,11 @@ func (v *GetGroupsByNameIamGetGroupListIamGroupPageItemsIamGroupGroupAbacPermiss
 
 // GetGroupsByNameIamGetGroupListIamGroupPageItemsIamGroupGroupQueryPolicyIamQueryPolicy includes the requested fields of the GraphQL type IamQueryPolicy.
 type GetGroupsByNameIamGetGroupListIamGroupPageItemsIamGroupGroupQueryPolicyIamQueryPolicy struct {
-	Statement []interface{} `json:"statement"`
+	Statement []jsonvalue.JSON `json:"statement"`
 }
 
 // GetStatement returns GetGroupsByNameIamGetGroupListIamGroupPageItemsIamGroupGroupQueryPolicyIamQueryPolicy.Statement, and is useful for accessing the field via an interface.
-func (v *GetGroupsByNameIamGetGroupListIamGroupPageItemsIamGroupGroupQueryPolicyIamQueryPolicy) GetStatement() []interface{} {
+func (v *GetGroupsByNameIamGetGroupListIamGroupPageItemsIamGroupGroupQueryPolicyIamQueryPolicy) GetStatement() []jsonvalue.JSON {
 	return v.Statement
 }
 
@@ -3720,7 +3723,7 @@ type GetIntegrationInstanceIntegrationInstance struct {
 	PollingInterval               IntegrationPollingInterval                                                                                     `json:"pollingInterval"`
 	IntegrationDefinitionId       string                                                                                                         `json:"integrationDefinitionId"`
 	Description                   string                                                                                                         `json:"description"`
-	Config                        interface{}                                                                                                    `json:"config"`
+	Config                        jsonvalue.JSON                                                                                                 `json:"config"`
 	IngestionSourcesOverrides     []GetIntegrationInstanceIntegrationInstanceIngestionSourcesOverrides                                           `json:"ingestionSourcesOverrides"`
 	SourceIntegrationInstanceId   string                                                                                                         `json:"sourceIntegrationInstanceId"`
 	CollectorPoolId               string                                                                                                         `json:"collectorPoolId"`
@@ -3749,7 +3752,7 @@ func (v *GetIntegrationInstanceIntegrationInstance) GetIntegrationDefinitionId()
 func (v *GetIntegrationInstanceIntegrationInstance) GetDescription() string { return v.Description }
 
 // GetConfig returns GetIntegrationInstanceIntegrationInstance.Config, and is useful for accessing the field via an interface.
-func (v *GetIntegrationInstanceIntegrationInstance) GetConfig() interface{} { return v.Config }
+func (v *GetIntegrationInstanceIntegrationInstance) GetConfig() jsonvalue.JSON { return v.Config }
 
 // GetIngestionSourcesOverrides returns GetIntegrationInstanceIntegrationInstance.IngestionSourcesOverrides, and is useful for accessing the field via an interface.
 func (v *GetIntegrationInstanceIntegrationInstance) GetIngestionSourcesOverrides() []GetIntegrationInstanceIntegrationInstanceIngestionSourcesOverrides {
@@ -3839,12 +3842,12 @@ func (v *GetInvitationsIamGetAccountIamAccount) GetAccountInvitations() GetInvit
 
 // GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPage includes the requested fields of the GraphQL type IamInvitationPage.
 type GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPage struct {
-	Items    []GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPageItemsIamInvitation `json:"items"`
-	PageInfo GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPagePageInfo             `json:"pageInfo"`
+	Items    []IamInvitation                                                                  `json:"items"`
+	PageInfo GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPagePageInfo `json:"pageInfo"`
 }
 
 // GetItems returns GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPage.Items, and is useful for accessing the field via an interface.
-func (v *GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPage) GetItems() []GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPageItemsIamInvitation {
+func (v *GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPage) GetItems() []IamInvitation {
 	return v.Items
 }
 
@@ -3853,37 +3856,10 @@ func (v *GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPag
 	return v.PageInfo
 }
 
-// GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPageItemsIamInvitation includes the requested fields of the GraphQL type IamInvitation.
-type GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPageItemsIamInvitation struct {
-	Id      string `json:"id"`
-	GroupId string `json:"groupId"`
-	Email   string `json:"email"`
-	Status  string `json:"status"`
-}
-
-// GetId returns GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPageItemsIamInvitation.Id, and is useful for accessing the field via an interface.
-func (v *GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPageItemsIamInvitation) GetId() string {
-	return v.Id
-}
-
-// GetGroupId returns GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPageItemsIamInvitation.GroupId, and is useful for accessing the field via an interface.
-func (v *GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPageItemsIamInvitation) GetGroupId() string {
-	return v.GroupId
-}
-
-// GetEmail returns GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPageItemsIamInvitation.Email, and is useful for accessing the field via an interface.
-func (v *GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPageItemsIamInvitation) GetEmail() string {
-	return v.Email
-}
-
-// GetStatus returns GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPageItemsIamInvitation.Status, and is useful for accessing the field via an interface.
-func (v *GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPageItemsIamInvitation) GetStatus() string {
-	return v.Status
-}
-
 // GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPagePageInfo includes the requested fields of the GraphQL type PageInfo.
 type GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPagePageInfo struct {
-	EndCursor string `json:"endCursor"`
+	EndCursor   string `json:"endCursor"`
+	HasNextPage bool   `json:"hasNextPage"`
 }
 
 // GetEndCursor returns GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPagePageInfo.EndCursor, and is useful for accessing the field via an interface.
@@ -3891,6 +3867,11 @@ func (v *GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPag
 	return v.EndCursor
 }
 
+// GetHasNextPage returns GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPagePageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *GetInvitationsIamGetAccountIamAccountAccountInvitationsIamInvitationPagePageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
 // GetInvitationsResponse is returned by GetInvitations on success.
 type GetInvitationsResponse struct {
 	IamGetAccount GetInvitationsIamGetAccountIamAccount `json:"iamGetAccount"`
@@ -3957,9 +3938,15 @@ type GetQuestionRuleInstanceQuestionRuleInstance struct {
 	SpecVersion                     int                                                                    `json:"specVersion"`
 	Latest                          bool                                                                   `json:"latest"`
 	PollingInterval                 SchedulerPollingInterval                                               `json:"pollingInterval"`
+	LatestAlertId                   string                                                                 `json:"latestAlertId"`
+	LatestAlertIsActive             bool                                                                   `json:"latestAlertIsActive"`
+	LastEvaluationEndOn             int64                                                                  `json:"lastEvaluationEndOn"`
+	LastSuccessfulEvaluationEndOn   int64                                                                  `json:"lastSuccessfulEvaluationEndOn"`
+	LastFailedEvaluationEndOn       int64                                                                  `json:"lastFailedEvaluationEndOn"`
+	EvaluationStep                  RuleEvaluationStep                                                     `json:"evaluationStep"`
 	Deleted                         bool                                                                   `json:"deleted"`
 	Type                            RuleInstanceType                                                       `json:"type"`
-	Templates                       interface{}                                                            `json:"templates"`
+	Templates                       jsonvalue.JSON                                                         `json:"templates"`
 	NotifyOnFailure                 bool                                                                   `json:"notifyOnFailure"`
 	TriggerActionsOnNewEntitiesOnly bool                                                                   `json:"triggerActionsOnNewEntitiesOnly"`
 	IgnorePreviousResults           bool                                                                   `json:"ignorePreviousResults"`
@@ -3999,6 +3986,36 @@ func (v *GetQuestionRuleInstanceQuestionRuleInstance) GetPollingInterval() Sched
 	return v.PollingInterval
 }
 
+// GetLatestAlertId returns GetQuestionRuleInstanceQuestionRuleInstance.LatestAlertId, and is useful for accessing the field via an interface.
+func (v *GetQuestionRuleInstanceQuestionRuleInstance) GetLatestAlertId() string {
+	return v.LatestAlertId
+}
+
+// GetLatestAlertIsActive returns GetQuestionRuleInstanceQuestionRuleInstance.LatestAlertIsActive, and is useful for accessing the field via an interface.
+func (v *GetQuestionRuleInstanceQuestionRuleInstance) GetLatestAlertIsActive() bool {
+	return v.LatestAlertIsActive
+}
+
+// GetLastEvaluationEndOn returns GetQuestionRuleInstanceQuestionRuleInstance.LastEvaluationEndOn, and is useful for accessing the field via an interface.
+func (v *GetQuestionRuleInstanceQuestionRuleInstance) GetLastEvaluationEndOn() int64 {
+	return v.LastEvaluationEndOn
+}
+
+// GetLastSuccessfulEvaluationEndOn returns GetQuestionRuleInstanceQuestionRuleInstance.LastSuccessfulEvaluationEndOn, and is useful for accessing the field via an interface.
+func (v *GetQuestionRuleInstanceQuestionRuleInstance) GetLastSuccessfulEvaluationEndOn() int64 {
+	return v.LastSuccessfulEvaluationEndOn
+}
+
+// GetLastFailedEvaluationEndOn returns GetQuestionRuleInstanceQuestionRuleInstance.LastFailedEvaluationEndOn, and is useful for accessing the field via an interface.
+func (v *GetQuestionRuleInstanceQuestionRuleInstance) GetLastFailedEvaluationEndOn() int64 {
+	return v.LastFailedEvaluationEndOn
+}
+
+// GetEvaluationStep returns GetQuestionRuleInstanceQuestionRuleInstance.EvaluationStep, and is useful for accessing the field via an interface.
+func (v *GetQuestionRuleInstanceQuestionRuleInstance) GetEvaluationStep() RuleEvaluationStep {
+	return v.EvaluationStep
+}
+
 // GetDeleted returns GetQuestionRuleInstanceQuestionRuleInstance.Deleted, and is useful for accessing the field via an interface.
 func (v *GetQuestionRuleInstanceQuestionRuleInstance) GetDeleted() bool { return v.Deleted }
 
@@ -4006,7 +4023,9 @@ func (v *GetQuestionRuleInstanceQuestionRuleInstance) GetDeleted() bool { return
 func (v *GetQuestionRuleInstanceQuestionRuleInstance) GetType() RuleInstanceType { return v.Type }
 
 // GetTemplates returns GetQuestionRuleInstanceQuestionRuleInstance.Templates, and is useful for accessing the field via an interface.
-func (v *GetQuestionRuleInstanceQuestionRuleInstance) GetTemplates() interface{} { return v.Templates }
+func (v *GetQuestionRuleInstanceQuestionRuleInstance) GetTemplates() jsonvalue.JSON {
+	return v.Templates
+}
 
 // GetNotifyOnFailure returns GetQuestionRuleInstanceQuestionRuleInstance.NotifyOnFailure, and is useful for accessing the field via an interface.
 func (v *GetQuestionRuleInstanceQuestionRuleInstance) GetNotifyOnFailure() bool {
@@ -4506,11 +4525,11 @@ func (v *GetUserGroupIamGetGroupIamGroupGroupAbacPermissionIamAbacPermission) Ge
 
 // GetUserGroupIamGetGroupIamGroupGroupQueryPolicyIamQueryPolicy includes the requested fields of the GraphQL type IamQueryPolicy.
 type GetUserGroupIamGetGroupIamGroupGroupQueryPolicyIamQueryPolicy struct {
-	Statement []interface{} `json:"statement"`
+	Statement []jsonvalue.JSON `json:"statement"`
 }
 
 // GetStatement returns GetUserGroupIamGetGroupIamGroupGroupQueryPolicyIamQueryPolicy.Statement, and is useful for accessing the field via an interface.
-func (v *GetUserGroupIamGetGroupIamGroupGroupQueryPolicyIamQueryPolicy) GetStatement() []interface{} {
+func (v *GetUserGroupIamGetGroupIamGroupGroupQueryPolicyIamQueryPolicy) GetStatement() []jsonvalue.JSON {
 	return v.Statement
 }
 
@@ -4522,121 +4541,105 @@ type GetUserGroupResponse struct {
 // GetIamGetGroup returns GetUserGroupResponse.IamGetGroup, and is useful for accessing the field via an interface.
 func (v *GetUserGroupResponse) GetIamGetGroup() GetUserGroupIamGetGroupIamGroup { return v.IamGetGroup }
 
-// GetUsersByEmailIamGetUserListIamAccountUserPage includes the requested fields of the GraphQL type IamAccountUserPage.
-type GetUsersByEmailIamGetUserListIamAccountUserPage struct {
-	Items []GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser `json:"items"`
+// GetUserGroupsIamGetUserListIamAccountUserPage includes the requested fields of the GraphQL type IamAccountUserPage.
+type GetUserGroupsIamGetUserListIamAccountUserPage struct {
+	Items []GetUserGroupsIamGetUserListIamAccountUserPageItemsIamAccountUser `json:"items"`
 }
 
-// GetItems returns GetUsersByEmailIamGetUserListIamAccountUserPage.Items, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailIamGetUserListIamAccountUserPage) GetItems() []GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser {
+// GetItems returns GetUserGroupsIamGetUserListIamAccountUserPage.Items, and is useful for accessing the field via an interface.
+func (v *GetUserGroupsIamGetUserListIamAccountUserPage) GetItems() []GetUserGroupsIamGetUserListIamAccountUserPageItemsIamAccountUser {
 	return v.Items
 }
 
-// GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser includes the requested fields of the GraphQL type IamAccountUser.
-type GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser struct {
-	Id          string                                                                                   `json:"id"`
-	Email       string                                                                                   `json:"email"`
-	NickName    string                                                                                   `json:"nickName"`
-	FirstName   string                                                                                   `json:"firstName"`
-	LastName    string                                                                                   `json:"lastName"`
-	UserGroups  GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPage `json:"userGroups"`
-	TimeCreated string                                                                                   `json:"_timeCreated"`
-	TimeUpdated string                                                                                   `json:"_timeUpdated"`
+// GetUserGroupsIamGetUserListIamAccountUserPageItemsIamAccountUser includes the requested fields of the GraphQL type IamAccountUser.
+type GetUserGroupsIamGetUserListIamAccountUserPageItemsIamAccountUser struct {
+	Id         string           `json:"id"`
+	UserGroups IamUserGroupPage `json:"userGroups"`
 }
 
-// GetId returns GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser.Id, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser) GetId() string {
+// GetId returns GetUserGroupsIamGetUserListIamAccountUserPageItemsIamAccountUser.Id, and is useful for accessing the field via an interface.
+func (v *GetUserGroupsIamGetUserListIamAccountUserPageItemsIamAccountUser) GetId() string {
 	return v.Id
 }
 
-// GetEmail returns GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser.Email, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser) GetEmail() string {
-	return v.Email
+// GetUserGroups returns GetUserGroupsIamGetUserListIamAccountUserPageItemsIamAccountUser.UserGroups, and is useful for accessing the field via an interface.
+func (v *GetUserGroupsIamGetUserListIamAccountUserPageItemsIamAccountUser) GetUserGroups() IamUserGroupPage {
+	return v.UserGroups
 }
 
-// GetNickName returns GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser.NickName, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser) GetNickName() string {
-	return v.NickName
+// GetUserGroupsResponse is returned by GetUserGroups on success.
+type GetUserGroupsResponse struct {
+	IamGetUserList GetUserGroupsIamGetUserListIamAccountUserPage `json:"iamGetUserList"`
 }
 
-// GetFirstName returns GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser.FirstName, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser) GetFirstName() string {
-	return v.FirstName
+// GetIamGetUserList returns GetUserGroupsResponse.IamGetUserList, and is useful for accessing the field via an interface.
+func (v *GetUserGroupsResponse) GetIamGetUserList() GetUserGroupsIamGetUserListIamAccountUserPage {
+	return v.IamGetUserList
 }
 
-// GetLastName returns GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser.LastName, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser) GetLastName() string {
-	return v.LastName
+// GetUsersByEmailIamGetUserListIamAccountUserPage includes the requested fields of the GraphQL type IamAccountUserPage.
+type GetUsersByEmailIamGetUserListIamAccountUserPage struct {
+	Items []IamUser `json:"items"`
 }
 
-// GetUserGroups returns GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser.UserGroups, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser) GetUserGroups() GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPage {
-	return v.UserGroups
-}
+// GetItems returns GetUsersByEmailIamGetUserListIamAccountUserPage.Items, and is useful for accessing the field via an interface.
+func (v *GetUsersByEmailIamGetUserListIamAccountUserPage) GetItems() []IamUser { return v.Items }
 
-// GetTimeCreated returns GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser.TimeCreated, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser) GetTimeCreated() string {
-	return v.TimeCreated
+// GetUsersByEmailResponse is returned by GetUsersByEmail on success.
+type GetUsersByEmailResponse struct {
+	IamGetUserList GetUsersByEmailIamGetUserListIamAccountUserPage `json:"iamGetUserList"`
 }
 
-// GetTimeUpdated returns GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser.TimeUpdated, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUser) GetTimeUpdated() string {
-	return v.TimeUpdated
+// GetIamGetUserList returns GetUsersByEmailResponse.IamGetUserList, and is useful for accessing the field via an interface.
+func (v *GetUsersByEmailResponse) GetIamGetUserList() GetUsersByEmailIamGetUserListIamAccountUserPage {
+	return v.IamGetUserList
 }
 
-// GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPage includes the requested fields of the GraphQL type IamGroupPage.
-type GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPage struct {
-	Items    []GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPageItemsIamGroup `json:"items"`
-	PageInfo GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPagePageInfo        `json:"pageInfo"`
+// GetUsersIamGetUserListIamAccountUserPage includes the requested fields of the GraphQL type IamAccountUserPage.
+type GetUsersIamGetUserListIamAccountUserPage struct {
+	Items    []IamUser                                        `json:"items"`
+	PageInfo GetUsersIamGetUserListIamAccountUserPagePageInfo `json:"pageInfo"`
 }
 
-// GetItems returns GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPage.Items, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPage) GetItems() []GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPageItemsIamGroup {
-	return v.Items
-}
+// GetItems returns GetUsersIamGetUserListIamAccountUserPage.Items, and is useful for accessing the field via an interface.
+func (v *GetUsersIamGetUserListIamAccountUserPage) GetItems() []IamUser { return v.Items }
 
-// GetPageInfo returns GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPage.PageInfo, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPage) GetPageInfo() GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPagePageInfo {
+// GetPageInfo returns GetUsersIamGetUserListIamAccountUserPage.PageInfo, and is useful for accessing the field via an interface.
+func (v *GetUsersIamGetUserListIamAccountUserPage) GetPageInfo() GetUsersIamGetUserListIamAccountUserPagePageInfo {
 	return v.PageInfo
 }
 
-// GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPageItemsIamGroup includes the requested fields of the GraphQL type IamGroup.
-type GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPageItemsIamGroup struct {
-	Id string `json:"id"`
-}
-
-// GetId returns GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPageItemsIamGroup.Id, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPageItemsIamGroup) GetId() string {
-	return v.Id
+// GetUsersIamGetUserListIamAccountUserPagePageInfo includes the requested fields of the GraphQL type PageInfo.
+type GetUsersIamGetUserListIamAccountUserPagePageInfo struct {
+	EndCursor   string `json:"endCursor"`
+	HasNextPage bool   `json:"hasNextPage"`
 }
 
-// GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPagePageInfo includes the requested fields of the GraphQL type PageInfo.
-type GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPagePageInfo struct {
-	EndCursor string `json:"endCursor"`
-}
+// GetEndCursor returns GetUsersIamGetUserListIamAccountUserPagePageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *GetUsersIamGetUserListIamAccountUserPagePageInfo) GetEndCursor() string { return v.EndCursor }
 
-// GetEndCursor returns GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPagePageInfo.EndCursor, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailIamGetUserListIamAccountUserPageItemsIamAccountUserUserGroupsIamGroupPagePageInfo) GetEndCursor() string {
-	return v.EndCursor
+// GetHasNextPage returns GetUsersIamGetUserListIamAccountUserPagePageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *GetUsersIamGetUserListIamAccountUserPagePageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
 }
 
-// GetUsersByEmailResponse is returned by GetUsersByEmail on success.
-type GetUsersByEmailResponse struct {
-	IamGetUserList GetUsersByEmailIamGetUserListIamAccountUserPage `json:"iamGetUserList"`
+// GetUsersResponse is returned by GetUsers on success.
+type GetUsersResponse struct {
+	IamGetUserList GetUsersIamGetUserListIamAccountUserPage `json:"iamGetUserList"`
 }
 
-// GetIamGetUserList returns GetUsersByEmailResponse.IamGetUserList, and is useful for accessing the field via an interface.
-func (v *GetUsersByEmailResponse) GetIamGetUserList() GetUsersByEmailIamGetUserListIamAccountUserPage {
+// GetIamGetUserList returns GetUsersResponse.IamGetUserList, and is useful for accessing the field via an interface.
+func (v *GetUsersResponse) GetIamGetUserList() GetUsersIamGetUserListIamAccountUserPage {
 	return v.IamGetUserList
 }
 
 // GetWidgetGetWidgetGetWidgetResult includes the requested fields of the GraphQL type GetWidgetResult.
 type GetWidgetGetWidgetGetWidgetResult struct {
-	Widget interface{} `json:"widget"`
+	Widget jsonvalue.JSON `json:"widget"`
 }
 
 // GetWidget returns GetWidgetGetWidgetGetWidgetResult.Widget, and is useful for accessing the field via an interface.
-func (v *GetWidgetGetWidgetGetWidgetResult) GetWidget() interface{} { return v.Widget }
+func (v *GetWidgetGetWidgetGetWidgetResult) GetWidget() jsonvalue.JSON { return v.Widget }
 
 // GetWidgetResponse is returned by GetWidget on success.
 type GetWidgetResponse struct {
@@ -4646,6 +4649,94 @@ type GetWidgetResponse struct {
 // GetGetWidget returns GetWidgetResponse.GetWidget, and is useful for accessing the field via an interface.
 func (v *GetWidgetResponse) GetGetWidget() GetWidgetGetWidgetGetWidgetResult { return v.GetWidget }
 
+// IamInvitation includes the requested fields of the GraphQL type IamInvitation.
+type IamInvitation struct {
+	Id      string `json:"id"`
+	GroupId string `json:"groupId"`
+	Email   string `json:"email"`
+	Status  string `json:"status"`
+}
+
+// GetId returns IamInvitation.Id, and is useful for accessing the field via an interface.
+func (v *IamInvitation) GetId() string { return v.Id }
+
+// GetGroupId returns IamInvitation.GroupId, and is useful for accessing the field via an interface.
+func (v *IamInvitation) GetGroupId() string { return v.GroupId }
+
+// GetEmail returns IamInvitation.Email, and is useful for accessing the field via an interface.
+func (v *IamInvitation) GetEmail() string { return v.Email }
+
+// GetStatus returns IamInvitation.Status, and is useful for accessing the field via an interface.
+func (v *IamInvitation) GetStatus() string { return v.Status }
+
+// IamUser includes the requested fields of the GraphQL type IamAccountUser.
+type IamUser struct {
+	Id          string           `json:"id"`
+	Email       string           `json:"email"`
+	NickName    string           `json:"nickName"`
+	FirstName   string           `json:"firstName"`
+	LastName    string           `json:"lastName"`
+	UserGroups  IamUserGroupPage `json:"userGroups"`
+	TimeCreated string           `json:"_timeCreated"`
+	TimeUpdated string           `json:"_timeUpdated"`
+}
+
+// GetId returns IamUser.Id, and is useful for accessing the field via an interface.
+func (v *IamUser) GetId() string { return v.Id }
+
+// GetEmail returns IamUser.Email, and is useful for accessing the field via an interface.
+func (v *IamUser) GetEmail() string { return v.Email }
+
+// GetNickName returns IamUser.NickName, and is useful for accessing the field via an interface.
+func (v *IamUser) GetNickName() string { return v.NickName }
+
+// GetFirstName returns IamUser.FirstName, and is useful for accessing the field via an interface.
+func (v *IamUser) GetFirstName() string { return v.FirstName }
+
+// GetLastName returns IamUser.LastName, and is useful for accessing the field via an interface.
+func (v *IamUser) GetLastName() string { return v.LastName }
+
+// GetUserGroups returns IamUser.UserGroups, and is useful for accessing the field via an interface.
+func (v *IamUser) GetUserGroups() IamUserGroupPage { return v.UserGroups }
+
+// GetTimeCreated returns IamUser.TimeCreated, and is useful for accessing the field via an interface.
+func (v *IamUser) GetTimeCreated() string { return v.TimeCreated }
+
+// GetTimeUpdated returns IamUser.TimeUpdated, and is useful for accessing the field via an interface.
+func (v *IamUser) GetTimeUpdated() string { return v.TimeUpdated }
+
+// IamUserGroupPage includes the requested fields of the GraphQL type IamGroupPage.
+type IamUserGroupPage struct {
+	Items    []IamUserGroupPageItemsIamGroup `json:"items"`
+	PageInfo IamUserGroupPagePageInfo        `json:"pageInfo"`
+}
+
+// GetItems returns IamUserGroupPage.Items, and is useful for accessing the field via an interface.
+func (v *IamUserGroupPage) GetItems() []IamUserGroupPageItemsIamGroup { return v.Items }
+
+// GetPageInfo returns IamUserGroupPage.PageInfo, and is useful for accessing the field via an interface.
+func (v *IamUserGroupPage) GetPageInfo() IamUserGroupPagePageInfo { return v.PageInfo }
+
+// IamUserGroupPageItemsIamGroup includes the requested fields of the GraphQL type IamGroup.
+type IamUserGroupPageItemsIamGroup struct {
+	Id string `json:"id"`
+}
+
+// GetId returns IamUserGroupPageItemsIamGroup.Id, and is useful for accessing the field via an interface.
+func (v *IamUserGroupPageItemsIamGroup) GetId() string { return v.Id }
+
+// IamUserGroupPagePageInfo includes the requested fields of the GraphQL type PageInfo.
+type IamUserGroupPagePageInfo struct {
+	EndCursor   string `json:"endCursor"`
+	HasNextPage bool   `json:"hasNextPage"`
+}
+
+// GetEndCursor returns IamUserGroupPagePageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *IamUserGroupPagePageInfo) GetEndCursor() string { return v.EndCursor }
+
+// GetHasNextPage returns IamUserGroupPagePageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *IamUserGroupPagePageInfo) GetHasNextPage() bool { return v.HasNextPage }
+
 type IngestionSourcesOverridesInput struct {
 	IngestionSourceId string `json:"ingestionSourceId"`
 	Enabled           bool   `json:"enabled"`
@@ -5174,27 +5265,27 @@ const (
 )
 
 type RuleOperationInput struct {
-	When    interface{}   `json:"when,omitempty"`
-	Actions []interface{} `json:"actions"`
+	When    jsonvalue.JSON   `json:"when,omitempty"`
+	Actions []jsonvalue.JSON `json:"actions"`
 }
 
 // GetWhen returns RuleOperationInput.When, and is useful for accessing the field via an interface.
-func (v *RuleOperationInput) GetWhen() interface{} { return v.When }
+func (v *RuleOperationInput) GetWhen() jsonvalue.JSON { return v.When }
 
 // GetActions returns RuleOperationInput.Actions, and is useful for accessing the field via an interface.
-func (v *RuleOperationInput) GetActions() []interface{} { return v.Actions }
+func (v *RuleOperationInput) GetActions() []jsonvalue.JSON { return v.Actions }
 
 // RuleOperationOutput includes the requested fields of the GraphQL type RuleOperation.
 type RuleOperationOutput struct {
-	When    interface{}   `json:"when"`
-	Actions []interface{} `json:"actions"`
+	When    jsonvalue.JSON   `json:"when"`
+	Actions []jsonvalue.JSON `json:"actions"`
 }
 
 // GetWhen returns RuleOperationOutput.When, and is useful for accessing the field via an interface.
-func (v *RuleOperationOutput) GetWhen() interface{} { return v.When }
+func (v *RuleOperationOutput) GetWhen() jsonvalue.JSON { return v.When }
 
 // GetActions returns RuleOperationOutput.Actions, and is useful for accessing the field via an interface.
-func (v *RuleOperationOutput) GetActions() []interface{} { return v.Actions }
+func (v *RuleOperationOutput) GetActions() []jsonvalue.JSON { return v.Actions }
 
 type RuleQuestionDetailsInput struct {
 	Queries []J1QueryInput `json:"queries"`
@@ -5204,11 +5295,11 @@ type RuleQuestionDetailsInput struct {
 func (v *RuleQuestionDetailsInput) GetQueries() []J1QueryInput { return v.Queries }
 
 type RuleStateInput struct {
-	Actions interface{} `json:"actions"`
+	Actions jsonvalue.JSON `json:"actions"`
 }
 
 // GetActions returns RuleStateInput.Actions, and is useful for accessing the field via an interface.
-func (v *RuleStateInput) GetActions() interface{} { return v.Actions }
+func (v *RuleStateInput) GetActions() jsonvalue.JSON { return v.Actions }
 
 // SaveDropRulesConfigResponse is returned by SaveDropRulesConfig on success.
 type SaveDropRulesConfigResponse struct {
@@ -5551,9 +5642,9 @@ func (v *UpdateCollectorUpdateCollector) GetIntegrationInstanceCount() int64 {
 func (v *UpdateCollectorUpdateCollector) GetLastHeartbeatAt() int64 { return v.LastHeartbeatAt }
 
 type UpdateComplianceFrameworkFields struct {
-	Name         string        `json:"name"`
-	WebLink      string        `json:"webLink"`
-	ScopeFilters []interface{} `json:"scopeFilters"`
+	Name         string           `json:"name"`
+	WebLink      string           `json:"webLink"`
+	ScopeFilters []jsonvalue.JSON `json:"scopeFilters"`
 }
 
 // GetName returns UpdateComplianceFrameworkFields.Name, and is useful for accessing the field via an interface.
@@ -5563,7 +5654,7 @@ func (v *UpdateComplianceFrameworkFields) GetName() string { return v.Name }
 func (v *UpdateComplianceFrameworkFields) GetWebLink() string { return v.WebLink }
 
 // GetScopeFilters returns UpdateComplianceFrameworkFields.ScopeFilters, and is useful for accessing the field via an interface.
-func (v *UpdateComplianceFrameworkFields) GetScopeFilters() []interface{} { return v.ScopeFilters }
+func (v *UpdateComplianceFrameworkFields) GetScopeFilters() []jsonvalue.JSON { return v.ScopeFilters }
 
 type UpdateComplianceFrameworkInput struct {
 	Id      string                          `json:"id"`
@@ -6208,7 +6299,7 @@ type UpdateInlineQuestionRuleInstanceInput struct {
 	Version                         int                      `json:"version"`
 	State                           RuleStateInput           `json:"state,omitempty"`
 	LatestAlertId                   string                   `json:"latestAlertId,omitempty"`
-	Templates                       interface{}              `json:"templates"`
+	Templates                       jsonvalue.JSON           `json:"templates"`
 	Tags                            []string                 `json:"tags"`
 	Name                            string                   `json:"name"`
 	Description                     string                   `json:"description"`
@@ -6242,7 +6333,7 @@ func (v *UpdateInlineQuestionRuleInstanceInput) GetState() RuleStateInput { retu
 func (v *UpdateInlineQuestionRuleInstanceInput) GetLatestAlertId() string { return v.LatestAlertId }
 
 // GetTemplates returns UpdateInlineQuestionRuleInstanceInput.Templates, and is useful for accessing the field via an interface.
-func (v *UpdateInlineQuestionRuleInstanceInput) GetTemplates() interface{} { return v.Templates }
+func (v *UpdateInlineQuestionRuleInstanceInput) GetTemplates() jsonvalue.JSON { return v.Templates }
 
 // GetTags returns UpdateInlineQuestionRuleInstanceInput.Tags, and is useful for accessing the field via an interface.
 func (v *UpdateInlineQuestionRuleInstanceInput) GetTags() []string { return v.Tags }
@@ -6332,7 +6423,7 @@ type UpdateInlineQuestionRuleInstanceUpdateInlineQuestionRuleInstance struct {
 	ResourceGroupId                 string                                                                                      `json:"resourceGroupId"`
 	QuestionId                      string                                                                                      `json:"questionId"`
 	QuestionName                    string                                                                                      `json:"questionName"`
-	Templates                       interface{}                                                                                 `json:"templates"`
+	Templates                       jsonvalue.JSON                                                                              `json:"templates"`
 	Question                        UpdateInlineQuestionRuleInstanceUpdateInlineQuestionRuleInstanceQuestionRuleQuestionDetails `json:"question"`
 	Operations                      []RuleOperationOutput                                                                       `json:"operations"`
 	Labels                          []UpdateInlineQuestionRuleInstanceUpdateInlineQuestionRuleInstanceLabelsRuleInstanceLabel   `json:"labels"`
@@ -6474,7 +6565,7 @@ func (v *UpdateInlineQuestionRuleInstanceUpdateInlineQuestionRuleInstance) GetQu
 }
 
 // GetTemplates returns UpdateInlineQuestionRuleInstanceUpdateInlineQuestionRuleInstance.Templates, and is useful for accessing the field via an interface.
-func (v *UpdateInlineQuestionRuleInstanceUpdateInlineQuestionRuleInstance) GetTemplates() interface{} {
+func (v *UpdateInlineQuestionRuleInstanceUpdateInlineQuestionRuleInstance) GetTemplates() jsonvalue.JSON {
 	return v.Templates
 }
 
@@ -6553,7 +6644,7 @@ type UpdateIntegrationInstanceInput struct {
 	PollingInterval               IntegrationPollingInterval                    `json:"pollingInterval"`
 	PollingIntervalCronExpression IntegrationPollingIntervalCronExpressionInput `json:"pollingIntervalCronExpression,omitempty"`
 	Description                   string                                        `json:"description"`
-	Config                        interface{}                                   `json:"config"`
+	Config                        jsonvalue.JSON                                `json:"config"`
 	OffsiteComplete               bool                                          `json:"offsiteComplete,omitempty"`
 	CollectorPoolId               string                                        `json:"collectorPoolId,omitempty"`
 	IngestionSourcesOverrides     []IngestionSourcesOverridesInput              `json:"ingestionSourcesOverrides,omitempty"`
@@ -6584,7 +6675,7 @@ func (v *UpdateIntegrationInstanceInput) GetPollingIntervalCronExpression() Inte
 func (v *UpdateIntegrationInstanceInput) GetDescription() string { return v.Description }
 
 // GetConfig returns UpdateIntegrationInstanceInput.Config, and is useful for accessing the field via an interface.
-func (v *UpdateIntegrationInstanceInput) GetConfig() interface{} { return v.Config }
+func (v *UpdateIntegrationInstanceInput) GetConfig() jsonvalue.JSON { return v.Config }
 
 // GetOffsiteComplete returns UpdateIntegrationInstanceInput.OffsiteComplete, and is useful for accessing the field via an interface.
 func (v *UpdateIntegrationInstanceInput) GetOffsiteComplete() bool { return v.OffsiteComplete }
@@ -6625,7 +6716,7 @@ type UpdateIntegrationInstanceUpdateIntegrationInstance struct {
 	PollingInterval               IntegrationPollingInterval                                                                                              `json:"pollingInterval"`
 	IntegrationDefinitionId       string                                                                                                                  `json:"integrationDefinitionId"`
 	Description                   string                                                                                                                  `json:"description"`
-	Config                        interface{}                                                                                                             `json:"config"`
+	Config                        jsonvalue.JSON                                                                                                          `json:"config"`
 	IngestionSourcesOverrides     []UpdateIntegrationInstanceUpdateIntegrationInstanceIngestionSourcesOverrides                                           `json:"ingestionSourcesOverrides"`
 	SourceIntegrationInstanceId   string                                                                                                                  `json:"sourceIntegrationInstanceId"`
 	CollectorPoolId               string                                                                                                                  `json:"collectorPoolId"`
@@ -6655,7 +6746,9 @@ func (v *UpdateIntegrationInstanceUpdateIntegrationInstance) GetDescription() st
 }
 
 // GetConfig returns UpdateIntegrationInstanceUpdateIntegrationInstance.Config, and is useful for accessing the field via an interface.
-func (v *UpdateIntegrationInstanceUpdateIntegrationInstance) GetConfig() interface{} { return v.Config }
+func (v *UpdateIntegrationInstanceUpdateIntegrationInstance) GetConfig() jsonvalue.JSON {
+	return v.Config
+}
 
 // GetIngestionSourcesOverrides returns UpdateIntegrationInstanceUpdateIntegrationInstance.IngestionSourcesOverrides, and is useful for accessing the field via an interface.
 func (v *UpdateIntegrationInstanceUpdateIntegrationInstance) GetIngestionSourcesOverrides() []UpdateIntegrationInstanceUpdateIntegrationInstanceIngestionSourcesOverrides {
@@ -6738,7 +6831,7 @@ type UpdateReferencedQuestionRuleInstanceInput struct {
 	Version                         int                      `json:"version"`
 	State                           RuleStateInput           `json:"state,omitempty"`
 	LatestAlertId                   string                   `json:"latestAlertId,omitempty"`
-	Templates                       interface{}              `json:"templates"`
+	Templates                       jsonvalue.JSON           `json:"templates"`
 	Tags                            []string                 `json:"tags"`
 	Name                            string                   `json:"name"`
 	Description                     string                   `json:"description"`
@@ -6770,7 +6863,7 @@ func (v *UpdateReferencedQuestionRuleInstanceInput) GetState() RuleStateInput {
 func (v *UpdateReferencedQuestionRuleInstanceInput) GetLatestAlertId() string { return v.LatestAlertId }
 
 // GetTemplates returns UpdateReferencedQuestionRuleInstanceInput.Templates, and is useful for accessing the field via an interface.
-func (v *UpdateReferencedQuestionRuleInstanceInput) GetTemplates() interface{} { return v.Templates }
+func (v *UpdateReferencedQuestionRuleInstanceInput) GetTemplates() jsonvalue.JSON { return v.Templates }
 
 // GetTags returns UpdateReferencedQuestionRuleInstanceInput.Tags, and is useful for accessing the field via an interface.
 func (v *UpdateReferencedQuestionRuleInstanceInput) GetTags() []string { return v.Tags }
@@ -6866,7 +6959,7 @@ type UpdateReferencedQuestionRuleInstanceUpdateReferencedQuestionRuleInstance st
 	ResourceGroupId                 string                                                                                            `json:"resourceGroupId"`
 	QuestionId                      string                                                                                            `json:"questionId"`
 	QuestionName                    string                                                                                            `json:"questionName"`
-	Templates                       interface{}                                                                                       `json:"templates"`
+	Templates                       jsonvalue.JSON                                                                                    `json:"templates"`
 	Operations                      []RuleOperationOutput                                                                             `json:"operations"`
 	Labels                          []UpdateReferencedQuestionRuleInstanceUpdateReferencedQuestionRuleInstanceLabelsRuleInstanceLabel `json:"labels"`
 }
@@ -7007,7 +7100,7 @@ func (v *UpdateReferencedQuestionRuleInstanceUpdateReferencedQuestionRuleInstanc
 }
 
 // GetTemplates returns UpdateReferencedQuestionRuleInstanceUpdateReferencedQuestionRuleInstance.Templates, and is useful for accessing the field via an interface.
-func (v *UpdateReferencedQuestionRuleInstanceUpdateReferencedQuestionRuleInstance) GetTemplates() interface{} {
+func (v *UpdateReferencedQuestionRuleInstanceUpdateReferencedQuestionRuleInstance) GetTemplates() jsonvalue.JSON {
 	return v.Templates
 }
 
@@ -7336,17 +7429,17 @@ func (v *Widget) GetNoResultMessage() string { return v.NoResultMessage }
 func (v *Widget) GetIncludeDeleted() bool { return v.IncludeDeleted }
 
 type WidgetConfig struct {
-	Queries                   []WidgetQuery `json:"queries"`
-	Settings                  interface{}   `json:"settings"`
-	PostQueryFilters          []string      `json:"postQueryFilters"`
-	DisableQueryPolicyFilters bool          `json:"disableQueryPolicyFilters"`
+	Queries                   []WidgetQuery  `json:"queries"`
+	Settings                  jsonvalue.JSON `json:"settings"`
+	PostQueryFilters          []string       `json:"postQueryFilters"`
+	DisableQueryPolicyFilters bool           `json:"disableQueryPolicyFilters"`
 }
 
 // GetQueries returns WidgetConfig.Queries, and is useful for accessing the field via an interface.
 func (v *WidgetConfig) GetQueries() []WidgetQuery { return v.Queries }
 
 // GetSettings returns WidgetConfig.Settings, and is useful for accessing the field via an interface.
-func (v *WidgetConfig) GetSettings() interface{} { return v.Settings }
+func (v *WidgetConfig) GetSettings() jsonvalue.JSON { return v.Settings }
 
 // GetPostQueryFilters returns WidgetConfig.PostQueryFilters, and is useful for accessing the field via an interface.
 func (v *WidgetConfig) GetPostQueryFilters() []string { return v.PostQueryFilters }
@@ -7553,10 +7646,10 @@ func (v *__CreateSmartClassTagInput) GetInput() CreateSmartClassTagInput { retur
 
 // __CreateUserGroupInput is used internally by genqlient
 type __CreateUserGroupInput struct {
-	Name            string        `json:"name"`
-	Description     string        `json:"description"`
-	QueryPolicy     []interface{} `json:"queryPolicy"`
-	AbacPermissions []string      `json:"abacPermissions"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description"`
+	QueryPolicy     []jsonvalue.JSON `json:"queryPolicy"`
+	AbacPermissions []string         `json:"abacPermissions"`
 }
 
 // GetName returns __CreateUserGroupInput.Name, and is useful for accessing the field via an interface.
@@ -7566,7 +7659,7 @@ func (v *__CreateUserGroupInput) GetName() string { return v.Name }
 func (v *__CreateUserGroupInput) GetDescription() string { return v.Description }
 
 // GetQueryPolicy returns __CreateUserGroupInput.QueryPolicy, and is useful for accessing the field via an interface.
-func (v *__CreateUserGroupInput) GetQueryPolicy() []interface{} { return v.QueryPolicy }
+func (v *__CreateUserGroupInput) GetQueryPolicy() []jsonvalue.JSON { return v.QueryPolicy }
 
 // GetAbacPermissions returns __CreateUserGroupInput.AbacPermissions, and is useful for accessing the field via an interface.
 func (v *__CreateUserGroupInput) GetAbacPermissions() []string { return v.AbacPermissions }
@@ -7891,6 +7984,14 @@ type __GetIntegrationInstanceInput struct {
 // GetId returns __GetIntegrationInstanceInput.Id, and is useful for accessing the field via an interface.
 func (v *__GetIntegrationInstanceInput) GetId() string { return v.Id }
 
+// __GetInvitationsInput is used internally by genqlient
+type __GetInvitationsInput struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// GetCursor returns __GetInvitationsInput.Cursor, and is useful for accessing the field via an interface.
+func (v *__GetInvitationsInput) GetCursor() string { return v.Cursor }
+
 // __GetQuestionByIdInput is used internally by genqlient
 type __GetQuestionByIdInput struct {
 	Id string `json:"id"`
@@ -7963,6 +8064,18 @@ type __GetUserGroupInput struct {
 // GetId returns __GetUserGroupInput.Id, and is useful for accessing the field via an interface.
 func (v *__GetUserGroupInput) GetId() string { return v.Id }
 
+// __GetUserGroupsInput is used internally by genqlient
+type __GetUserGroupsInput struct {
+	Email  string `json:"email"`
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// GetEmail returns __GetUserGroupsInput.Email, and is useful for accessing the field via an interface.
+func (v *__GetUserGroupsInput) GetEmail() string { return v.Email }
+
+// GetCursor returns __GetUserGroupsInput.Cursor, and is useful for accessing the field via an interface.
+func (v *__GetUserGroupsInput) GetCursor() string { return v.Cursor }
+
 // __GetUsersByEmailInput is used internally by genqlient
 type __GetUsersByEmailInput struct {
 	Email string `json:"email"`
@@ -7971,6 +8084,14 @@ type __GetUsersByEmailInput struct {
 // GetEmail returns __GetUsersByEmailInput.Email, and is useful for accessing the field via an interface.
 func (v *__GetUsersByEmailInput) GetEmail() string { return v.Email }
 
+// __GetUsersInput is used internally by genqlient
+type __GetUsersInput struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// GetCursor returns __GetUsersInput.Cursor, and is useful for accessing the field via an interface.
+func (v *__GetUsersInput) GetCursor() string { return v.Cursor }
+
 // __GetWidgetInput is used internally by genqlient
 type __GetWidgetInput struct {
 	BoardId   string `json:"boardId"`
@@ -8249,11 +8370,11 @@ func (v *__UpdateSmartClassTagInput) GetInput() PatchSmartClassTagInput { return
 
 // __UpdateUserGroupInput is used internally by genqlient
 type __UpdateUserGroupInput struct {
-	Id              string        `json:"id"`
-	Name            string        `json:"name"`
-	Description     string        `json:"description"`
-	QueryPolicy     []interface{} `json:"queryPolicy"`
-	AbacPermissions []string      `json:"abacPermissions"`
+	Id              string           `json:"id"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description"`
+	QueryPolicy     []jsonvalue.JSON `json:"queryPolicy"`
+	AbacPermissions []string         `json:"abacPermissions"`
 }
 
 // GetId returns __UpdateUserGroupInput.Id, and is useful for accessing the field via an interface.
@@ -8266,7 +8387,7 @@ func (v *__UpdateUserGroupInput) GetName() string { return v.Name }
 func (v *__UpdateUserGroupInput) GetDescription() string { return v.Description }
 
 // GetQueryPolicy returns __UpdateUserGroupInput.QueryPolicy, and is useful for accessing the field via an interface.
-func (v *__UpdateUserGroupInput) GetQueryPolicy() []interface{} { return v.QueryPolicy }
+func (v *__UpdateUserGroupInput) GetQueryPolicy() []jsonvalue.JSON { return v.QueryPolicy }
 
 // GetAbacPermissions returns __UpdateUserGroupInput.AbacPermissions, and is useful for accessing the field via an interface.
 func (v *__UpdateUserGroupInput) GetAbacPermissions() []string { return v.AbacPermissions }
@@ -9132,7 +9253,7 @@ func CreateUserGroup(
 	client graphql.Client,
 	name string,
 	description string,
-	queryPolicy []interface{},
+	queryPolicy []jsonvalue.JSON,
 	abacPermissions []string,
 ) (*CreateUserGroupResponse, error) {
 	req := &graphql.Request{
@@ -10593,14 +10714,15 @@ query GetIntegrationInstance ($id: String!) {
 func GetInvitations(
 	ctx context.Context,
 	client graphql.Client,
+	cursor string,
 ) (*GetInvitationsResponse, error) {
 	req := &graphql.Request{
 		OpName: "GetInvitations",
 		Query: `
-query GetInvitations {
+query GetInvitations ($cursor: String) {
 	iamGetAccount {
 		id
-		accountInvitations(limit: 10000) {
+		accountInvitations(limit: 10000, cursor: $cursor) {
 			items {
 				id
 				groupId
@@ -10609,11 +10731,15 @@ query GetInvitations {
 			}
 			pageInfo {
 				endCursor
+				hasNextPage
 			}
 		}
 	}
 }
 `,
+		Variables: &__GetInvitationsInput{
+			Cursor: cursor,
+		},
 	}
 	var err error
 
@@ -10696,6 +10822,12 @@ query GetQuestionRuleInstance ($id: ID!) {
 		specVersion
 		latest
 		pollingInterval
+		latestAlertId
+		latestAlertIsActive
+		lastEvaluationEndOn
+		lastSuccessfulEvaluationEndOn
+		lastFailedEvaluationEndOn
+		evaluationStep
 		deleted
 		type
 		templates
@@ -11007,6 +11139,106 @@ query GetUserGroup ($id: String!) {
 	return &data, err
 }
 
+// GetUserGroups returns the groups of the users with the email from the cursor
+// on. The user lists only return the first page of the groups of each user.
+func GetUserGroups(
+	ctx context.Context,
+	client graphql.Client,
+	email string,
+	cursor string,
+) (*GetUserGroupsResponse, error) {
+	req := &graphql.Request{
+		OpName: "GetUserGroups",
+		Query: `
+query GetUserGroups ($email: String!, $cursor: String) {
+	iamGetUserList(emailFilter: $email, limit: 1000) {
+		items {
+			id
+			userGroups(limit: 3000, cursor: $cursor) {
+				items {
+					id
+				}
+				pageInfo {
+					endCursor
+					hasNextPage
+				}
+			}
+		}
+	}
+}
+`,
+		Variables: &__GetUserGroupsInput{
+			Email:  email,
+			Cursor: cursor,
+		},
+	}
+	var err error
+
+	var data GetUserGroupsResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+func GetUsers(
+	ctx context.Context,
+	client graphql.Client,
+	cursor string,
+) (*GetUsersResponse, error) {
+	req := &graphql.Request{
+		OpName: "GetUsers",
+		Query: `
+query GetUsers ($cursor: String) {
+	iamGetUserList(limit: 1000, cursor: $cursor) {
+		items {
+			id
+			email
+			nickName
+			firstName
+			lastName
+			userGroups(limit: 3000) {
+				items {
+					id
+				}
+				pageInfo {
+					endCursor
+					hasNextPage
+				}
+			}
+			_timeCreated
+			_timeUpdated
+		}
+		pageInfo {
+			endCursor
+			hasNextPage
+		}
+	}
+}
+`,
+		Variables: &__GetUsersInput{
+			Cursor: cursor,
+		},
+	}
+	var err error
+
+	var data GetUsersResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 func GetUsersByEmail(
 	ctx context.Context,
 	client graphql.Client,
@@ -11029,6 +11261,7 @@ query GetUsersByEmail ($email: String!) {
 				}
 				pageInfo {
 					endCursor
+					hasNextPage
 				}
 			}
 			_timeCreated
@@ -12178,7 +12411,7 @@ func UpdateUserGroup(
 	id string,
 	name string,
 	description string,
-	queryPolicy []interface{},
+	queryPolicy []jsonvalue.JSON,
 	abacPermissions []string,
 ) (*UpdateUserGroupResponse, error) {
 	req := &graphql.Request{
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// graphQLClient sends the genqlient operations like the client of genqlient,
+// but returns an *Error classified from the HTTP status or GraphQL errors.
+type graphQLClient struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+var _ graphql.Client = &graphQLClient{}
+
+// MakeRequest implements graphql.Client
+func (c *graphQLClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		respBody, err := io.ReadAll(httpResp.Body)
+		if err != nil {
+			respBody = []byte(fmt.Sprintf("<unreadable: %v>", err))
+		}
+		return newHTTPError(httpResp.StatusCode, respBody)
+	}
+
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		return newGraphQLError(httpResp.StatusCode, resp.Errors)
+	}
+	return nil
+}
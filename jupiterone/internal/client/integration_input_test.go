@@ -20,7 +20,7 @@ func TestUpdateIntegrationInstanceInputOmitsEmptyUpdateMode(t *testing.T) {
 		Name:            "example",
 		PollingInterval: IntegrationPollingIntervalThirtyMinutes,
 		Description:     "example",
-		Config:          map[string]interface{}{"foo": "bar"},
+		Config:          JSON(`{"foo":"bar"}`),
 	}
 
 	b, err := json.Marshal(input)
@@ -0,0 +1,18 @@
+package client
+
+import "github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/jsonvalue"
+
+// JSON is a value of the JSON scalar of the API, see jsonvalue.JSON. The
+// generated code refers to jsonvalue.JSON directly, as genqlient cannot bind
+// a scalar to a type of the package it generates.
+type JSON = jsonvalue.JSON
+
+// NewJSON encodes v as JSON.
+func NewJSON(v interface{}) (JSON, error) {
+	return jsonvalue.New(v)
+}
+
+// ParseJSON checks that s is valid JSON and returns it as a JSON value.
+func ParseJSON(s string) (JSON, error) {
+	return jsonvalue.Parse(s)
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	var output RuleOperationOutput
+	require.NoError(t, json.Unmarshal([]byte(`{"when":null,"actions":[{"id":"1", "type":"CREATE_ALERT"}]}`), &output))
+
+	assert.True(t, output.When.IsNull())
+	require.Len(t, output.Actions, 1)
+	assert.Equal(t, `{"id":"1", "type":"CREATE_ALERT"}`, output.Actions[0].String(), "values are kept as read")
+
+	var action map[string]interface{}
+	require.NoError(t, output.Actions[0].Decode(&action))
+	assert.Equal(t, "CREATE_ALERT", action["type"])
+
+	b, err := json.Marshal(RuleOperationInput{Actions: output.Actions})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"actions":[{"id":"1","type":"CREATE_ALERT"}]}`, string(b), "empty values are omitted")
+
+	b, err = json.Marshal(RuleStateInput{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"actions":null}`, string(b), "the zero value is null")
+
+	_, err = ParseJSON(`{"a":`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// TokenExpiryDelta is how long before the reported expiry a cached access
+// token is considered stale and refreshed, so that in-flight requests never
+// carry a token that expires on the way to the API.
+const TokenExpiryDelta = 60 * time.Second
+
+// DefaultTokenLifetime is how long an access token is cached when the token
+// endpoint does not report its lifetime with expires_in. A token revoked
+// earlier is still replaced when the API rejects it.
+const DefaultTokenLifetime = time.Hour
+
+// tokenSource supplies the bearer token sent with every API request.
+type tokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// refreshableTokenSource is a tokenSource that can replace a token the API
+// rejected with a 401 response.
+type refreshableTokenSource interface {
+	tokenSource
+	Invalidate(token string)
+}
+
+// staticTokenSource always returns the configured API key.
+type staticTokenSource string
+
+func (s staticTokenSource) Token(context.Context) (string, error) {
+	return string(s), nil
+}
+
+type oauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// oauthTokenSource exchanges OAuth client credentials for short-lived access
+// tokens and caches them until shortly before they expire.
+type oauthTokenSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	httpClient   *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiry      time.Time
+	now         func() time.Time
+}
+
+func newOAuthTokenSource(tokenURL, clientID, clientSecret string, httpClient *http.Client) *oauthTokenSource {
+	return &oauthTokenSource{
+		tokenURL:     tokenURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		httpClient:   httpClient,
+		now:          time.Now,
+	}
+}
+
+// Token returns the cached access token, fetching a new one from the token
+// endpoint when there is none or it is about to expire.
+func (s *oauthTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accessToken != "" && s.now().Add(TokenExpiryDelta).Before(s.expiry) {
+		return s.accessToken, nil
+	}
+
+	tflog.Debug(ctx, "Requesting new OAuth access token", map[string]interface{}{"tokenUrl": s.tokenURL})
+
+	token, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.accessToken = token.AccessToken
+	lifetime := time.Duration(token.ExpiresIn) * time.Second
+	if token.ExpiresIn <= 0 {
+		lifetime = DefaultTokenLifetime
+	}
+	s.expiry = s.now().Add(lifetime)
+
+	return s.accessToken, nil
+}
+
+// Invalidate implements refreshableTokenSource
+func (s *oauthTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accessToken == token {
+		s.accessToken = ""
+	}
+}
+
+func (s *oauthTokenSource) fetch(ctx context.Context) (*oauthTokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.clientID), url.QueryEscape(s.clientSecret))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var token oauthTokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token endpoint response did not include an access_token")
+	}
+
+	if token.TokenType != "" && !strings.EqualFold(token.TokenType, "bearer") {
+		return nil, fmt.Errorf("unsupported token type %q", token.TokenType)
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const DefaultProfile string = "default"
+
+// Profile holds the settings for one named profile in the shared JupiterOne
+// config file.
+type Profile struct {
+	Name      string
+	APIKey    string
+	AccountID string
+	Region    string
+	Endpoint  string
+}
+
+// DefaultConfigFilePath returns the location of the shared config file,
+// `~/.jupiterone/config` unless overridden by JUPITERONE_CONFIG_FILE.
+func DefaultConfigFilePath() (string, error) {
+	if p := os.Getenv("JUPITERONE_CONFIG_FILE"); p != "" {
+		return p, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".jupiterone", "config"), nil
+}
+
+// LoadProfile reads the named profile from the config file at path.
+//
+// A missing file or profile is only an error when a profile was explicitly
+// requested; when name is empty the "default" profile is used if present and
+// nil is returned otherwise.
+func LoadProfile(path, name string) (*Profile, error) {
+	explicit := name != ""
+	if !explicit {
+		name = DefaultProfile
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !explicit {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	profiles, err := parseProfiles(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		if !explicit {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("profile %q not found in config file %s", name, path)
+	}
+
+	return profile, nil
+}
+
+// parseProfiles parses the INI style config file:
+//
+//	[default]
+//	api_key    = xxxx
+//	account_id = j1dev
+//	region     = us
+//
+//	[staging]
+//	endpoint = https://graphql.staging.example.com/
+func parseProfiles(r io.Reader) (map[string]*Profile, error) {
+	profiles := map[string]*Profile{}
+
+	var current *Profile
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid profile header %q", lineNumber, line)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", lineNumber)
+			}
+
+			current = profiles[name]
+			if current == nil {
+				current = &Profile{Name: name}
+				profiles[name] = current
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNumber)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: setting outside of a profile", lineNumber)
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+
+		switch key {
+		case "api_key":
+			current.APIKey = value
+		case "account_id":
+			current.AccountID = value
+		case "region":
+			current.Region = value
+		case "endpoint":
+			current.Endpoint = value
+		default:
+			return nil, fmt.Errorf("line %d: unknown setting %q", lineNumber, key)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
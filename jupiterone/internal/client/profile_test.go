@@ -0,0 +1,68 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testConfigFile = `
+# shared JupiterOne settings
+[default]
+api_key    = default-key
+account_id = default-account
+
+[staging]
+api_key    = "staging-key"
+account_id = staging-account
+region     = dev
+endpoint   = https://graphql.staging.example.com/
+`
+
+func writeTestConfigFile(t *testing.T, content string) string {
+	configPath := filepath.Join(t.TempDir(), "config")
+	err := os.WriteFile(configPath, []byte(content), 0600)
+	assert.NoError(t, err)
+	return configPath
+}
+
+func TestLoadProfile(t *testing.T) {
+	configPath := writeTestConfigFile(t, testConfigFile)
+
+	profile, err := LoadProfile(configPath, "")
+	assert.NoError(t, err)
+	assert.Equal(t, &Profile{Name: "default", APIKey: "default-key", AccountID: "default-account"}, profile)
+
+	profile, err = LoadProfile(configPath, "staging")
+	assert.NoError(t, err)
+	assert.Equal(t, &Profile{
+		Name:      "staging",
+		APIKey:    "staging-key",
+		AccountID: "staging-account",
+		Region:    "dev",
+		Endpoint:  "https://graphql.staging.example.com/",
+	}, profile)
+
+	_, err = LoadProfile(configPath, "prod")
+	assert.ErrorContains(t, err, `profile "prod" not found`)
+}
+
+func TestLoadProfileMissingFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config")
+
+	profile, err := LoadProfile(configPath, "")
+	assert.NoError(t, err)
+	assert.Nil(t, profile)
+
+	_, err = LoadProfile(configPath, "staging")
+	assert.Error(t, err)
+}
+
+func TestLoadProfileInvalidFile(t *testing.T) {
+	configPath := writeTestConfigFile(t, "[default]\napi_secret = nope\n")
+
+	_, err := LoadProfile(configPath, "")
+	assert.ErrorContains(t, err, `line 2: unknown setting "api_secret"`)
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// rateLimiter is a token bucket sized from the rate limit budget the API
+// advertises in the Ratelimit-Limit, Ratelimit-Remaining and Ratelimit-Reset
+// response headers. Requests wait for a token once the budget of the current
+// window is spent instead of being sent only to be rejected with a 429.
+//
+// Until the first response with the headers is seen the budget is unknown and
+// requests are only held back after a 429 response. The same applies when the
+// API advertises a limit without a positive Ratelimit-Reset: a window of 0 is
+// treated as unlimited rather than refilling the bucket on every request.
+type rateLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	tokens  int
+	resetAt time.Time
+	// window is the longest reset delay seen, used as the length of the
+	// next window when the current one ends before a response says so.
+	window time.Duration
+	now    func() time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{now: time.Now}
+}
+
+// Wait blocks until a request may be sent or the context is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return nil
+		}
+
+		tflog.Debug(ctx, "Waiting for the rate limit window to reset",
+			map[string]interface{}{"waitMilliseconds": delay.Milliseconds()})
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token and returns zero, or returns how long to wait until
+// the window resets when there are none left.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	if l.limit == 0 || l.window == 0 {
+		// Without a known budget only a 429 response holds requests back.
+		if now.Before(l.resetAt) {
+			return l.resetAt.Sub(now)
+		}
+		return 0
+	}
+
+	if !now.Before(l.resetAt) {
+		l.tokens = l.limit
+		l.resetAt = now.Add(l.window)
+	}
+
+	if l.tokens > 0 {
+		l.tokens--
+		return 0
+	}
+
+	return l.resetAt.Sub(now)
+}
+
+// Update resizes the bucket from the headers of a response. A 429 response
+// empties the bucket until the advertised reset.
+func (l *rateLimiter) Update(resp *http.Response) {
+	limit, hasLimit := headerInt(resp.Header, "Ratelimit-Limit")
+	remaining, hasRemaining := headerInt(resp.Header, "Ratelimit-Remaining")
+	reset, hasReset := headerDelay(resp)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	if hasLimit {
+		l.limit = limit
+	}
+	if hasReset {
+		l.resetAt = now.Add(reset)
+		if reset > l.window && resp.StatusCode != http.StatusTooManyRequests {
+			l.window = reset
+		}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		l.tokens = 0
+		if !hasReset {
+			// Without a hint from the API wait at least a second
+			// before sending the next request.
+			l.resetAt = now.Add(time.Second)
+		}
+	case hasRemaining:
+		// The budget may be shared with other clients of the account, so
+		// the count from the API wins over the local one.
+		l.tokens = remaining
+	}
+}
+
+// rateLimitTransport throttles requests with the limiter of the client.
+type rateLimitTransport struct {
+	limiter *rateLimiter
+	next    http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err == nil {
+		t.limiter.Update(resp)
+	}
+	return resp, err
+}
+
+func headerInt(h http.Header, name string) (int, bool) {
+	v := strings.TrimSpace(h.Get(name))
+	if v == "" {
+		return 0, false
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil || i < 0 {
+		return 0, false
+	}
+	return i, true
+}
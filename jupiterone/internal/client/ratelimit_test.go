@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rateLimitResponse(status int, headers map[string]string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: http.Header{}}
+	for k, v := range headers {
+		resp.Header.Set(k, v)
+	}
+	return resp
+}
+
+func TestRateLimiterFollowsAdvertisedBudget(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newRateLimiter()
+	l.now = func() time.Time { return now }
+
+	assert.Zero(t, l.reserve(), "unknown budgets are not throttled")
+
+	l.Update(rateLimitResponse(http.StatusOK, map[string]string{
+		"Ratelimit-Limit":     "10",
+		"Ratelimit-Remaining": "2",
+		"Ratelimit-Reset":     "5",
+	}))
+
+	assert.Zero(t, l.reserve())
+	assert.Zero(t, l.reserve())
+	assert.Equal(t, 5*time.Second, l.reserve(), "waits for the window to reset once the budget is spent")
+
+	now = now.Add(5 * time.Second)
+	for i := 0; i < 10; i++ {
+		assert.Zero(t, l.reserve(), "the bucket refills to the limit")
+	}
+	assert.Greater(t, l.reserve(), time.Duration(0))
+}
+
+func TestRateLimiterWithoutWindowIsUnlimited(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newRateLimiter()
+	l.now = func() time.Time { return now }
+
+	l.Update(rateLimitResponse(http.StatusOK, map[string]string{
+		"Ratelimit-Limit":     "1",
+		"Ratelimit-Remaining": "0",
+		"Ratelimit-Reset":     "0",
+	}))
+	for i := 0; i < 3; i++ {
+		assert.Zero(t, l.reserve(), "a limit without a reset window is not throttled")
+	}
+
+	l.Update(rateLimitResponse(http.StatusTooManyRequests, map[string]string{"Retry-After": "2"}))
+	assert.Equal(t, 2*time.Second, l.reserve(), "a 429 response still holds requests back")
+}
+
+func TestRateLimiterHoldsRequestsAfterTooManyRequests(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newRateLimiter()
+	l.now = func() time.Time { return now }
+
+	l.Update(rateLimitResponse(http.StatusTooManyRequests, map[string]string{"Retry-After": "3"}))
+	assert.Equal(t, 3*time.Second, l.reserve())
+
+	now = now.Add(3 * time.Second)
+	assert.Zero(t, l.reserve())
+	assert.Zero(t, l.reserve(), "without a known budget requests are not throttled after the reset")
+}
+
+func TestRateLimiterWaitStopsWhenContextIsDone(t *testing.T) {
+	l := newRateLimiter()
+	l.Update(rateLimitResponse(http.StatusTooManyRequests, map[string]string{"Retry-After": "60"}))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+}
@@ -0,0 +1,204 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const MaxBackoff = 60 * time.Second
+const MinBackoff = 15 * time.Second
+const DefaultMaxAttempts = 5
+const PowerOfTwo = 2
+
+// RetryTransport is a custom RoundTripper that adds retry logic with backoff.
+//
+// Rate limited requests are always retried since the API rejected them before
+// doing any work. Server errors and dropped connections are only retried for
+// queries, a mutation may have been applied before the failure.
+type RetryTransport struct {
+	Transport  http.RoundTripper
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// MaxAttempts is the number of times a request is sent, including the
+	// first attempt, before the last response is returned.
+	MaxAttempts int
+}
+
+func (rt *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	// We need to keep a copy of the body because each request it gets consumed
+	var bodyBytes []byte
+	if req.Body != nil {
+		var err error
+		bodyBytes, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %v", err)
+		}
+	}
+
+	idempotent := !isMutation(bodyBytes)
+
+	maxAttempts := rt.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		// Setting the body for the request
+		req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+
+		resp, err := rt.Transport.RoundTrip(req)
+
+		reason := retryReason(ctx, resp, err, idempotent)
+		if reason == "" {
+			return resp, err
+		}
+
+		if attempt >= maxAttempts {
+			tflog.Warn(ctx, "Not going to retry, the maximum number of attempts was reached",
+				map[string]interface{}{"reason": reason, "attempts": attempt})
+			return resp, err
+		}
+
+		sleepDuration := rt.backoff(attempt, resp)
+
+		if resp != nil {
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		tflog.Debug(ctx, "Retrying request", map[string]interface{}{
+			"reason":               reason,
+			"retryCount":           attempt,
+			"sleepDurationSeconds": int(sleepDuration.Seconds()),
+		})
+
+		if err := sleepContext(ctx, sleepDuration); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryReason describes why the request should be sent again, or returns an
+// empty string when the response or error is final.
+func retryReason(ctx context.Context, resp *http.Response, err error, idempotent bool) string {
+	if ctx.Err() != nil {
+		return ""
+	}
+
+	if err != nil {
+		if idempotent && isConnectionReset(err) {
+			return err.Error()
+		}
+		return ""
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return resp.Status
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if idempotent {
+			return resp.Status
+		}
+	}
+
+	return ""
+}
+
+// backoff returns how long to wait before the next attempt. The delay
+// advertised by the API in the Retry-After or Ratelimit-Reset headers is
+// preferred over exponential backoff with jitter. Either way it is capped at
+// MaxBackoff.
+func (rt *RetryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	sleepDuration, ok := headerDelay(resp)
+	if !ok {
+		// Calculate the backoff time using exponential backoff with jitter.
+		sleepDuration = rt.MinBackoff * time.Duration(math.Pow(PowerOfTwo, float64(attempt-1)))
+		if rt.MinBackoff > 0 {
+			sleepDuration += time.Duration(rand.Int63n(int64(rt.MinBackoff)))
+		}
+	}
+
+	// Ensure we do not exceed the maximum backoff time.
+	if sleepDuration > rt.MaxBackoff {
+		sleepDuration = rt.MaxBackoff
+	}
+
+	return sleepDuration
+}
+
+// headerDelay reads the delay from the Retry-After header, either in seconds
+// or as an HTTP date, or from the seconds until the rate limit window resets.
+func headerDelay(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	if v := strings.TrimSpace(resp.Header.Get("Retry-After")); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(v); err == nil {
+			if d := time.Until(at); d > 0 {
+				return d, true
+			}
+			return 0, true
+		}
+	}
+
+	if v := strings.TrimSpace(resp.Header.Get("Ratelimit-Reset")); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for the duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isMutation reports whether the GraphQL request body holds a mutation.
+// Bodies that cannot be parsed are treated as mutations so they are never
+// sent twice.
+func isMutation(body []byte) bool {
+	var request struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return true
+	}
+
+	return strings.HasPrefix(strings.TrimSpace(request.Query), "mutation")
+}
+
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
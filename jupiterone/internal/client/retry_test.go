@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newRetryTestQlient(handler http.HandlerFunc, maxAttempts int) (*JupiterOneClientConfig, func()) {
+	server := httptest.NewServer(handler)
+
+	config := &JupiterOneClientConfig{
+		APIKey:       "key",
+		AccountID:    "account",
+		MaxAttempts:  maxAttempts,
+		MinBackoff:   time.Millisecond,
+		MaxBackoff:   10 * time.Millisecond,
+		RoundTripper: rewriteHostTransport{target: server.URL},
+	}
+	return config, server.Close
+}
+
+func TestRetryTransportRetriesRateLimitedRequests(t *testing.T) {
+	calls := 0
+	config, done := newRetryTestQlient(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"deleteQuestion":{"id":"1"}}}`)
+	}, 3)
+	defer done()
+
+	qlient, err := config.Qlient(context.TODO())
+	assert.NoError(t, err)
+
+	_, err = DeleteQuestion(context.TODO(), qlient, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls, "rate limited mutations are retried")
+}
+
+func TestRetryTransportOnlyRetriesServerErrorsForQueries(t *testing.T) {
+	calls := 0
+	config, done := newRetryTestQlient(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, 3)
+	defer done()
+
+	qlient, err := config.Qlient(context.TODO())
+	assert.NoError(t, err)
+
+	_, err = DeleteQuestion(context.TODO(), qlient, "1")
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls, "mutations are not retried on server errors")
+
+	calls = 0
+	_, err = GetCollector(context.TODO(), qlient, "1")
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls, "queries are retried up to the max attempts")
+}
+
+func TestRetryTransportStopsWhenContextIsCanceled(t *testing.T) {
+	config, done := newRetryTestQlient(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, 5)
+	defer done()
+	config.MaxBackoff = time.Minute
+
+	qlient, err := config.Qlient(context.TODO())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = GetCollector(ctx, qlient, "1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	rt := &RetryTransport{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "3")
+	assert.Equal(t, 3*time.Second, rt.backoff(1, resp))
+
+	resp.Header.Set("Retry-After", "120")
+	assert.Equal(t, 10*time.Second, rt.backoff(1, resp), "header delays are capped at MaxBackoff")
+
+	resp = &http.Response{Header: http.Header{}}
+	resp.Header.Set("Ratelimit-Reset", "2")
+	assert.Equal(t, 2*time.Second, rt.backoff(1, resp))
+
+	d := rt.backoff(3, &http.Response{Header: http.Header{}})
+	assert.GreaterOrEqual(t, d, 4*time.Second)
+	assert.Less(t, d, 5*time.Second)
+}
+
+func TestIsMutation(t *testing.T) {
+	assert.True(t, isMutation([]byte(`{"query":"\nmutation DeleteQuestion ($id: ID!) {}"}`)))
+	assert.False(t, isMutation([]byte(`{"query":"\nquery GetCollector ($id: String!) {}"}`)))
+	assert.True(t, isMutation([]byte(`not json`)))
+}
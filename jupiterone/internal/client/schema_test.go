@@ -0,0 +1,232 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+	"gopkg.in/yaml.v3"
+)
+
+// schemaSnapshot is the SDL of the API the operations are generated from, it
+// is updated with `make jupiterone/internal/client/schema.graphql`.
+const schemaSnapshot = "schema.graphql"
+
+// operationFiles returns the operation files of the client, every .graphql
+// file but the schema.
+func operationFiles(t *testing.T) []string {
+	files, err := filepath.Glob("*.graphql")
+	require.NoError(t, err)
+
+	var operations []string
+	for _, file := range files {
+		if file != schemaSnapshot {
+			operations = append(operations, file)
+		}
+	}
+	return operations
+}
+
+func TestGenqlientConfigListsEveryOperationFile(t *testing.T) {
+	b, err := os.ReadFile("genqlient.yaml")
+	require.NoError(t, err)
+
+	var config struct {
+		Operations []string `yaml:"operations"`
+	}
+	require.NoError(t, yaml.Unmarshal(b, &config))
+
+	files := operationFiles(t)
+	assert.ElementsMatch(t, files, config.Operations,
+		"every operation file must be listed in genqlient.yaml to be generated")
+
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		_, err = parser.ParseQuery(&ast.Source{Name: file, Input: string(b)})
+		assert.NoError(t, err, file)
+	}
+}
+
+func TestOperationsMatchSchemaSnapshot(t *testing.T) {
+	b, err := os.ReadFile(schemaSnapshot)
+	if os.IsNotExist(err) {
+		t.Skipf("%s is missing, fetch it with `make jupiterone/internal/client/schema.graphql` and commit it", schemaSnapshot)
+	}
+	require.NoError(t, err)
+
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: schemaSnapshot, Input: string(b)})
+	require.NoError(t, err)
+
+	var docs []*ast.QueryDocument
+	for _, file := range operationFiles(t) {
+		doc, errs := loadOperations(schema, file)
+		for _, err := range errs {
+			t.Errorf("%s: %s", file, formatValidationError(err))
+		}
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+
+	unused, deprecated := schemaCoverage(schema, docs)
+	for _, field := range deprecated {
+		t.Logf("deprecated field still used: %s", field)
+	}
+	for _, typeName := range sortedKeys(unused) {
+		t.Logf("fields of %s never queried: %s", typeName, strings.Join(unused[typeName], ", "))
+	}
+}
+
+// loadOperations parses and validates an operation file. The returned
+// document has the schema definitions of its fields set.
+func loadOperations(schema *ast.Schema, file string) (*ast.QueryDocument, gqlerror.List) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, gqlerror.List{gqlerror.Errorf("%s", err)}
+	}
+	return loadOperationsSource(schema, &ast.Source{Name: file, Input: string(b)})
+}
+
+func loadOperationsSource(schema *ast.Schema, source *ast.Source) (*ast.QueryDocument, gqlerror.List) {
+	doc, err := parser.ParseQuery(source)
+	if err != nil {
+		if gqlErr, ok := err.(*gqlerror.Error); ok {
+			return nil, gqlerror.List{gqlErr}
+		}
+		return nil, gqlerror.List{gqlerror.Errorf("%s", err)}
+	}
+
+	if errs := validator.Validate(schema, doc); len(errs) > 0 {
+		return nil, errs
+	}
+	return doc, nil
+}
+
+func formatValidationError(err *gqlerror.Error) string {
+	if len(err.Locations) == 0 {
+		return err.Message
+	}
+	return fmt.Sprintf("%d:%d: %s", err.Locations[0].Line, err.Locations[0].Column, err.Message)
+}
+
+// schemaCoverage returns, for every object type the operations select fields
+// of, the fields they never select, and the deprecated fields they select.
+func schemaCoverage(schema *ast.Schema, docs []*ast.QueryDocument) (map[string][]string, []string) {
+	used := map[string]map[string]bool{}
+	deprecated := map[string]bool{}
+
+	var walk func(selections ast.SelectionSet)
+	walk = func(selections ast.SelectionSet) {
+		for _, selection := range selections {
+			switch sel := selection.(type) {
+			case *ast.Field:
+				if sel.Definition != nil && sel.ObjectDefinition != nil && !strings.HasPrefix(sel.Name, "__") {
+					typeName := sel.ObjectDefinition.Name
+					if used[typeName] == nil {
+						used[typeName] = map[string]bool{}
+					}
+					used[typeName][sel.Name] = true
+
+					if d := sel.Definition.Directives.ForName("deprecated"); d != nil {
+						field := typeName + "." + sel.Name
+						if reason := d.Arguments.ForName("reason"); reason != nil {
+							field += " (" + reason.Value.Raw + ")"
+						}
+						deprecated[field] = true
+					}
+				}
+				walk(sel.SelectionSet)
+			case *ast.InlineFragment:
+				walk(sel.SelectionSet)
+			}
+		}
+	}
+
+	for _, doc := range docs {
+		for _, op := range doc.Operations {
+			walk(op.SelectionSet)
+		}
+		for _, fragment := range doc.Fragments {
+			walk(fragment.SelectionSet)
+		}
+	}
+
+	unused := map[string][]string{}
+	for typeName, fields := range used {
+		for _, field := range schema.Types[typeName].Fields {
+			if !fields[field.Name] && !strings.HasPrefix(field.Name, "__") {
+				unused[typeName] = append(unused[typeName], field.Name)
+			}
+		}
+	}
+
+	return unused, sortedKeys(deprecated)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestSchemaCoverage(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Name: "schema.graphql", Input: `
+		type Query {
+			question(id: ID!): Question
+			questions: [Question!]!
+		}
+		type Question {
+			id: ID!
+			title: String!
+			name: String @deprecated(reason: "Use title")
+			tags: [String!]
+		}
+	`})
+
+	doc, errs := loadOperationsSource(schema, &ast.Source{Name: "question.graphql", Input: `
+		query GetQuestion($id: ID!) {
+			question(id: $id) {
+				...Question
+				name
+			}
+		}
+		fragment Question on Question {
+			id
+			title
+		}
+	`})
+	require.Empty(t, errs)
+
+	unused, deprecated := schemaCoverage(schema, []*ast.QueryDocument{doc})
+	assert.Equal(t, map[string][]string{
+		"Query":    {"questions"},
+		"Question": {"tags"},
+	}, unused)
+	assert.Equal(t, []string{"Question.name (Use title)"}, deprecated)
+
+	_, errs = loadOperationsSource(schema, &ast.Source{Name: "question.graphql", Input: `
+		query GetQuestion($id: ID!) {
+			question(id: $id) {
+				id
+				description
+			}
+		}
+	`})
+	require.Len(t, errs, 1)
+	assert.Equal(t, `5:5: Cannot query field "description" on type "Question".`, formatValidationError(errs[0]))
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQlientTrustsCACertFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"deleteQuestion":{"id":"1"}}}`)
+	}))
+	defer server.Close()
+
+	config := JupiterOneClientConfig{
+		APIKey:    "key",
+		AccountID: "account",
+		Endpoint:  server.URL,
+	}
+
+	// without the CA the self-signed test server is rejected
+	qlient, err := config.Qlient(context.TODO())
+	assert.NoError(t, err)
+	_, err = DeleteQuestion(context.TODO(), qlient, "1")
+	assert.ErrorContains(t, err, "certificate")
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(caFile, caPEM, 0600))
+
+	config.CACertFile = caFile
+	qlient, err = config.Qlient(context.TODO())
+	assert.NoError(t, err)
+	_, err = DeleteQuestion(context.TODO(), qlient, "1")
+	assert.NoError(t, err)
+}
+
+func TestQlientUsesProxyURL(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		fmt.Fprint(w, `{"data":{"deleteQuestion":{"id":"1"}}}`)
+	}))
+	defer proxy.Close()
+
+	config := JupiterOneClientConfig{
+		APIKey:    "key",
+		AccountID: "account",
+		Endpoint:  "http://graphql.private.example.com/",
+		ProxyURL:  proxy.URL,
+	}
+
+	qlient, err := config.Qlient(context.TODO())
+	assert.NoError(t, err)
+	_, err = DeleteQuestion(context.TODO(), qlient, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://graphql.private.example.com/", proxied)
+}
+
+func TestQlientInvalidTLSFiles(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "ca.pem")
+	assert.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0600))
+
+	config := JupiterOneClientConfig{CACertFile: notPEM}
+	_, err := config.Qlient(context.TODO())
+	assert.ErrorContains(t, err, "no PEM certificates")
+
+	config = JupiterOneClientConfig{CACertFile: filepath.Join(dir, "missing.pem")}
+	_, err = config.Qlient(context.TODO())
+	assert.ErrorContains(t, err, "failed to read CA certificate file")
+
+	config = JupiterOneClientConfig{ClientCertFile: filepath.Join(dir, "client.pem")}
+	_, err = config.Qlient(context.TODO())
+	assert.ErrorContains(t, err, "both a client certificate and client key file")
+}
@@ -0,0 +1,757 @@
+package fakeserver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// The kinds of objects kept by the server.
+const (
+	kindQuestion      = "question"
+	kindRule          = "rule"
+	kindDashboard     = "dashboard"
+	kindWidget        = "widget"
+	kindGroup         = "group"
+	kindUser          = "user"
+	kindInvitation    = "invitation"
+	kindResourceGroup = "resource_group"
+	kindFramework     = "framework"
+	kindComplianceGrp = "compliance_group"
+	kindFrameworkItem = "framework_item"
+	kindLibraryItem   = "library_item"
+	kindControl       = "control"
+	kindDropRules     = "drop_rules"
+)
+
+// dropRulesID is the id of the drop rules config, there is one per account.
+const dropRulesID = "config"
+
+// resolvers maps the root fields of the API to their implementation.
+var resolvers = map[string]resolver{
+	// questions
+	"question":       getQuestion,
+	"createQuestion": createQuestion,
+	"updateQuestion": updateQuestion,
+	"deleteQuestion": deleteQuestion,
+
+	// rules
+	"questionRuleInstance":                 getRule,
+	"createInlineQuestionRuleInstance":     createRule,
+	"createReferencedQuestionRuleInstance": createRule,
+	"updateInlineQuestionRuleInstance":     updateRule,
+	"updateReferencedQuestionRuleInstance": updateRule,
+	"deleteRuleInstance":                   deleteRule,
+
+	// dashboards and widgets
+	"getDashboard":    getDashboard,
+	"createDashboard": createDashboard,
+	"patchDashboard":  patchDashboard,
+	"deleteDashboard": deleteDashboard,
+	"getWidget":       getWidget,
+	"createWidget":    createWidget,
+	"updateWidget":    updateWidget,
+	"deleteWidget":    deleteWidget,
+
+	// user groups, users and invitations
+	"createIamGroup":      createGroup,
+	"updateIamGroup":      updateGroup,
+	"deleteIamGroup":      deleteGroup,
+	"iamGetGroup":         getGroup,
+	"iamGetGroupList":     listGroups,
+	"invite":              invite,
+	"iamGetUserList":      listUsers,
+	"iamDeleteGroupUsers": removeGroupUsers,
+	"iamGetAccount":       getAccount,
+	"updateInvitation":    updateInvitation,
+
+	// resource groups
+	"resourceGroups":      listResourceGroups,
+	"resourceGroup":       getResourceGroup,
+	"createResourceGroup": createResourceGroup,
+	"updateResourceGroup": updateResourceGroup,
+	"deleteResourceGroup": deleteResourceGroup,
+
+	// compliance
+	"complianceFramework":           getFramework,
+	"createComplianceFramework":     createFramework,
+	"updateComplianceFramework":     updateFramework,
+	"deleteComplianceFramework":     deleteFramework,
+	"createComplianceGroup":         createComplianceGroup,
+	"updateComplianceGroup":         updateComplianceGroup,
+	"deleteComplianceGroup":         deleteComplianceGroup,
+	"complianceFrameworkItem":       getFrameworkItem,
+	"createComplianceFrameworkItem": createFrameworkItem,
+	"updateComplianceFrameworkItem": updateFrameworkItem,
+	"deleteComplianceFrameworkItem": deleteFrameworkItem,
+	"complianceLibraryItem":         getLibraryItem,
+	"createComplianceLibraryItem":   createLibraryItem,
+	"updateComplianceLibraryItem":   updateLibraryItem,
+	"deleteComplianceLibraryItem":   deleteLibraryItem,
+
+	// controls
+	"control":                getControl,
+	"createControl":          createControl,
+	"updateControl":          updateControl,
+	"deleteControl":          deleteControl,
+	"transitionControlState": transitionControlState,
+
+	// drop rules
+	"dropRulesConfigBeta":     getDropRules,
+	"saveDropRulesConfigBeta": saveDropRules,
+
+	// queries
+	"queryV1": executeQuery,
+}
+
+func str(args object, key string) string {
+	s, _ := args[key].(string)
+	return s
+}
+
+func obj(args object, key string) object {
+	o, _ := args[key].(object)
+	if o == nil {
+		o = object{}
+	}
+	return o
+}
+
+func success() object {
+	return object{"success": true}
+}
+
+// questions
+
+func getQuestion(s *Server, args object) (interface{}, *gqlerror.Error) {
+	question, ok := s.get(kindQuestion, str(args, "id"))
+	if !ok {
+		return nil, missingError("Cannot fetch question that does not exist")
+	}
+	return question, nil
+}
+
+func createQuestion(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "question")
+	if err := validateQueries(input); err != nil {
+		return nil, err
+	}
+	id := s.insert(kindQuestion, input)
+	return s.objects[kindQuestion][id], nil
+}
+
+func updateQuestion(s *Server, args object) (interface{}, *gqlerror.Error) {
+	question, ok := s.get(kindQuestion, str(args, "id"))
+	if !ok {
+		return nil, missingError("Cannot update question that does not exist")
+	}
+	update := obj(args, "update")
+	if err := validateQueries(update); err != nil {
+		return nil, err
+	}
+	merge(question, update)
+	return question, nil
+}
+
+func deleteQuestion(s *Server, args object) (interface{}, *gqlerror.Error) {
+	question, ok := s.get(kindQuestion, str(args, "id"))
+	if !ok {
+		return nil, missingError("Cannot delete question that does not exist")
+	}
+	s.remove(kindQuestion, str(args, "id"))
+	return question, nil
+}
+
+// validateQueries rejects queries without J1QL, the API validates queries
+// more thoroughly.
+func validateQueries(input object) *gqlerror.Error {
+	queries, _ := input["queries"].([]interface{})
+	for _, q := range queries {
+		query, _ := q.(object)
+		if strings.TrimSpace(str(query, "query")) == "" {
+			return inputError("Query must not be empty")
+		}
+	}
+	return nil
+}
+
+// queries
+
+func executeQuery(s *Server, args object) (interface{}, *gqlerror.Error) {
+	return object{
+		"type":       "table",
+		"data":       []interface{}{},
+		"totalCount": s.totals[str(args, "query")],
+	}, nil
+}
+
+// rules
+
+func getRule(s *Server, args object) (interface{}, *gqlerror.Error) {
+	rule, ok := s.get(kindRule, str(args, "id"))
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("Rule instance %s does not exist", str(args, "id")))
+	}
+	return rule, nil
+}
+
+func createRule(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "instance")
+	if err := validateOperations(input); err != nil {
+		return nil, err
+	}
+
+	merge(input, object{
+		"accountId": AccountID,
+		"version":   1,
+		"latest":    true,
+		"deleted":   false,
+		"type":      "QUESTION",
+	})
+	id := s.insert(kindRule, input)
+	return s.objects[kindRule][id], nil
+}
+
+func updateRule(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "instance")
+	rule, ok := s.get(kindRule, str(input, "id"))
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("Rule instance %s does not exist", str(input, "id")))
+	}
+	if input["version"] != rule["version"] {
+		return nil, conflictError("Rule instance version does not match the latest version")
+	}
+	if err := validateOperations(input); err != nil {
+		return nil, err
+	}
+
+	version, _ := rule["version"].(float64)
+	merge(rule, input)
+	rule["version"] = version + 1
+	return rule, nil
+}
+
+func deleteRule(s *Server, args object) (interface{}, *gqlerror.Error) {
+	rule, ok := s.get(kindRule, str(args, "id"))
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("Rule instance %s does not exist", str(args, "id")))
+	}
+	s.remove(kindRule, str(args, "id"))
+	return rule, nil
+}
+
+// validateOperations requires every action of the rule to have a type.
+func validateOperations(input object) *gqlerror.Error {
+	operations, _ := input["operations"].([]interface{})
+	for _, o := range operations {
+		operation, _ := o.(object)
+		actions, _ := operation["actions"].([]interface{})
+		for _, a := range actions {
+			action, _ := a.(object)
+			if str(action, "type") == "" {
+				return inputError("Action is missing a type")
+			}
+		}
+	}
+	return nil
+}
+
+// dashboards and widgets
+
+func getDashboard(s *Server, args object) (interface{}, *gqlerror.Error) {
+	dashboard, ok := s.get(kindDashboard, str(args, "dashboardId"))
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("Dashboard with id %s not found", str(args, "dashboardId")))
+	}
+	return dashboard, nil
+}
+
+func createDashboard(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "input")
+	delete(input, "widgets")
+	id := s.insert(kindDashboard, input)
+	return s.objects[kindDashboard][id], nil
+}
+
+func patchDashboard(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "input")
+	dashboard, ok := s.get(kindDashboard, str(input, "dashboardId"))
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("Dashboard with id %s not found", str(input, "dashboardId")))
+	}
+	delete(input, "dashboardId")
+	delete(input, "widgets")
+	merge(dashboard, input)
+	return dashboard, nil
+}
+
+func deleteDashboard(s *Server, args object) (interface{}, *gqlerror.Error) {
+	id := str(args, "dashboardId")
+	if _, ok := s.get(kindDashboard, id); !ok {
+		return nil, notFoundError(fmt.Sprintf("Dashboard with id %s not found", id))
+	}
+	s.remove(kindDashboard, id)
+	for _, w := range s.list(kindWidget, nil) {
+		if widget := w.(object); widget["dashboardId"] == id {
+			s.remove(kindWidget, widget["id"].(string))
+		}
+	}
+	return success(), nil
+}
+
+func getWidget(s *Server, args object) (interface{}, *gqlerror.Error) {
+	widget, ok := s.get(kindWidget, str(args, "widgetId"))
+	if !ok || widget["dashboardId"] != str(args, "boardId") {
+		return nil, notFoundError(fmt.Sprintf("Widget with id %s not found", str(args, "widgetId")))
+	}
+	return object{"widget": widget}, nil
+}
+
+func createWidget(s *Server, args object) (interface{}, *gqlerror.Error) {
+	dashboardID := str(args, "dashboardId")
+	if _, ok := s.get(kindDashboard, dashboardID); !ok {
+		return nil, notFoundError(fmt.Sprintf("Dashboard with id %s not found", dashboardID))
+	}
+
+	input := obj(args, "input")
+	input["dashboardId"] = dashboardID
+	id := s.insert(kindWidget, input)
+	widget := s.objects[kindWidget][id]
+	widget["widgetId"] = id
+	return widget, nil
+}
+
+func updateWidget(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "newWidget")
+	widget, ok := s.get(kindWidget, str(input, "id"))
+	if !ok || widget["dashboardId"] != str(args, "boardId") {
+		return nil, notFoundError(fmt.Sprintf("Widget with id %s not found", str(input, "id")))
+	}
+	merge(widget, input)
+	return object{"resultCode": "SUCCESS"}, nil
+}
+
+func deleteWidget(s *Server, args object) (interface{}, *gqlerror.Error) {
+	widget, ok := s.get(kindWidget, str(args, "widgetId"))
+	if !ok || widget["dashboardId"] != str(args, "dashboardId") {
+		return nil, notFoundError(fmt.Sprintf("Widget with id %s not found", str(args, "widgetId")))
+	}
+	s.remove(kindWidget, str(args, "widgetId"))
+	return success(), nil
+}
+
+// user groups, users and invitations
+
+func createGroup(s *Server, args object) (interface{}, *gqlerror.Error) {
+	name := str(args, "name")
+	if len(s.list(kindGroup, func(g object) bool { return g["groupName"] == name })) > 0 {
+		return nil, conflictError(fmt.Sprintf("Group %s already exists", name))
+	}
+
+	id := s.insert(kindGroup, object{"status": "ACTIVE"})
+	group := s.objects[kindGroup][id]
+	setGroupFields(group, args)
+	return group, nil
+}
+
+func updateGroup(s *Server, args object) (interface{}, *gqlerror.Error) {
+	group, ok := s.get(kindGroup, str(args, "id"))
+	if !ok {
+		return nil, missingError(fmt.Sprintf("Group %s does not exist", str(args, "id")))
+	}
+	setGroupFields(group, args)
+	return group, nil
+}
+
+func setGroupFields(group, args object) {
+	if v, ok := args["name"]; ok && v != nil {
+		group["groupName"] = v
+	}
+	if v, ok := args["description"]; ok {
+		group["groupDescription"] = v
+	}
+	if v, ok := args["queryPolicy"]; ok {
+		group["groupQueryPolicy"] = object{"statement": v}
+	}
+	if v, ok := args["abacPermissions"]; ok {
+		group["groupAbacPermission"] = object{"statement": v}
+	}
+}
+
+func deleteGroup(s *Server, args object) (interface{}, *gqlerror.Error) {
+	name := str(args, "name")
+	groups := s.list(kindGroup, func(g object) bool { return g["groupName"] == name })
+	if len(groups) == 0 {
+		return nil, missingError(fmt.Sprintf("Group %s does not exist", name))
+	}
+
+	id := groups[0].(object)["id"].(string)
+	s.remove(kindGroup, id)
+	for _, u := range s.list(kindUser, nil) {
+		removeUserGroup(u.(object), id)
+	}
+	for _, i := range s.list(kindInvitation, func(i object) bool { return i["groupId"] == id }) {
+		s.remove(kindInvitation, i.(object)["id"].(string))
+	}
+	return success(), nil
+}
+
+func getGroup(s *Server, args object) (interface{}, *gqlerror.Error) {
+	group, ok := s.get(kindGroup, str(args, "group"))
+	if !ok {
+		return nil, missingError(fmt.Sprintf("Group %s does not exist", str(args, "group")))
+	}
+	return group, nil
+}
+
+func listGroups(s *Server, args object) (interface{}, *gqlerror.Error) {
+	filter := str(args, "groupNameFilter")
+	items := s.list(kindGroup, func(g object) bool {
+		name, _ := g["groupName"].(string)
+		return strings.Contains(name, filter)
+	})
+	return object{"items": items, "pageInfo": object{"hasNextPage": false}}, nil
+}
+
+func invite(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "input")
+	if _, ok := s.get(kindGroup, str(input, "groupId")); !ok {
+		return nil, missingError(fmt.Sprintf("Group %s does not exist", str(input, "groupId")))
+	}
+
+	id := s.insert(kindInvitation, object{
+		"email":   input["email"],
+		"groupId": input["groupId"],
+		"status":  "PENDING",
+	})
+	return s.objects[kindInvitation][id], nil
+}
+
+func listUsers(s *Server, args object) (interface{}, *gqlerror.Error) {
+	email := str(args, "emailFilter")
+	users := s.list(kindUser, func(u object) bool { return email == "" || u["email"] == email })
+	return object{
+		"items":    users,
+		"pageInfo": object{"endCursor": "", "hasNextPage": false},
+	}, nil
+}
+
+func removeGroupUsers(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "input")
+	users, _ := input["users"].([]interface{})
+	for _, u := range s.list(kindUser, nil) {
+		user := u.(object)
+		for _, id := range users {
+			// The API accepts either the id or the email of the user.
+			if user["id"] == id || user["email"] == id {
+				removeUserGroup(user, str(input, "group"))
+			}
+		}
+	}
+	return success(), nil
+}
+
+func removeUserGroup(user object, groupID string) {
+	groups := obj(user, "userGroups")
+	items, _ := groups["items"].([]interface{})
+	kept := []interface{}{}
+	for _, item := range items {
+		if item.(object)["id"] != groupID {
+			kept = append(kept, item)
+		}
+	}
+	groups["items"] = kept
+	user["userGroups"] = groups
+}
+
+func getAccount(s *Server, args object) (interface{}, *gqlerror.Error) {
+	// Only open invitations are listed.
+	invitations := s.list(kindInvitation, func(i object) bool { return i["status"] == "PENDING" })
+	return object{
+		"id":                 AccountID,
+		"accountInvitations": object{"items": invitations},
+	}, nil
+}
+
+func updateInvitation(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "input")
+	invitation, ok := s.get(kindInvitation, str(input, "invitationId"))
+	if !ok {
+		return nil, missingError(fmt.Sprintf("Invitation %s not found", str(input, "invitationId")))
+	}
+	invitation["status"] = input["status"]
+	return invitation, nil
+}
+
+// resource groups
+
+func listResourceGroups(s *Server, args object) (interface{}, *gqlerror.Error) {
+	return s.list(kindResourceGroup, nil), nil
+}
+
+func getResourceGroup(s *Server, args object) (interface{}, *gqlerror.Error) {
+	group, ok := s.get(kindResourceGroup, str(args, "id"))
+	if !ok {
+		return nil, missingError("Item not found")
+	}
+	return group, nil
+}
+
+func createResourceGroup(s *Server, args object) (interface{}, *gqlerror.Error) {
+	id := s.insert(kindResourceGroup, obj(args, "input"))
+	return s.objects[kindResourceGroup][id], nil
+}
+
+func updateResourceGroup(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "input")
+	group, ok := s.get(kindResourceGroup, str(input, "id"))
+	if !ok {
+		return nil, missingError("Item not found")
+	}
+	merge(group, input)
+	return group, nil
+}
+
+func deleteResourceGroup(s *Server, args object) (interface{}, *gqlerror.Error) {
+	id := str(obj(args, "input"), "id")
+	if _, ok := s.get(kindResourceGroup, id); !ok {
+		return nil, missingError("Item not found")
+	}
+	s.remove(kindResourceGroup, id)
+	return success(), nil
+}
+
+// compliance
+
+func getFramework(s *Server, args object) (interface{}, *gqlerror.Error) {
+	id := str(obj(args, "input"), "id")
+	framework, ok := s.get(kindFramework, id)
+	if !ok {
+		return nil, missingError(fmt.Sprintf("Could not find compliance framework with id %s", id))
+	}
+
+	framework = normalize(framework).(object)
+	framework["groups"] = s.list(kindComplianceGrp, func(g object) bool { return g["frameworkId"] == id })
+	return framework, nil
+}
+
+func createFramework(s *Server, args object) (interface{}, *gqlerror.Error) {
+	id := s.insert(kindFramework, obj(args, "input"))
+	return s.objects[kindFramework][id], nil
+}
+
+func updateFramework(s *Server, args object) (interface{}, *gqlerror.Error) {
+	return updateWithFields(s, kindFramework, "compliance framework", obj(args, "input"))
+}
+
+func deleteFramework(s *Server, args object) (interface{}, *gqlerror.Error) {
+	return deleteByInputID(s, kindFramework, "compliance framework", obj(args, "input"))
+}
+
+func createComplianceGroup(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "input")
+	if _, ok := s.get(kindFramework, str(input, "frameworkId")); !ok {
+		return nil, missingError(fmt.Sprintf("Could not find compliance framework with id %s", str(input, "frameworkId")))
+	}
+	id := s.insert(kindComplianceGrp, input)
+	return s.objects[kindComplianceGrp][id], nil
+}
+
+func updateComplianceGroup(s *Server, args object) (interface{}, *gqlerror.Error) {
+	return updateWithFields(s, kindComplianceGrp, "compliance group", obj(args, "input"))
+}
+
+func deleteComplianceGroup(s *Server, args object) (interface{}, *gqlerror.Error) {
+	return deleteByInputID(s, kindComplianceGrp, "compliance group", obj(args, "input"))
+}
+
+func getFrameworkItem(s *Server, args object) (interface{}, *gqlerror.Error) {
+	return getByInputID(s, kindFrameworkItem, "compliance framework item", obj(args, "input"))
+}
+
+func createFrameworkItem(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "input")
+	if _, ok := s.get(kindFramework, str(input, "frameworkId")); !ok {
+		return nil, missingError(fmt.Sprintf("Could not find compliance framework with id %s", str(input, "frameworkId")))
+	}
+	id := s.insert(kindFrameworkItem, input)
+	return s.objects[kindFrameworkItem][id], nil
+}
+
+func updateFrameworkItem(s *Server, args object) (interface{}, *gqlerror.Error) {
+	return updateWithFields(s, kindFrameworkItem, "compliance framework item", obj(args, "input"))
+}
+
+func deleteFrameworkItem(s *Server, args object) (interface{}, *gqlerror.Error) {
+	return deleteByInputID(s, kindFrameworkItem, "compliance framework item", obj(args, "input"))
+}
+
+func getLibraryItem(s *Server, args object) (interface{}, *gqlerror.Error) {
+	return getByInputID(s, kindLibraryItem, "compliance library item", obj(args, "input"))
+}
+
+func createLibraryItem(s *Server, args object) (interface{}, *gqlerror.Error) {
+	id := s.insert(kindLibraryItem, obj(args, "input"))
+	return s.objects[kindLibraryItem][id], nil
+}
+
+func updateLibraryItem(s *Server, args object) (interface{}, *gqlerror.Error) {
+	return updateWithFields(s, kindLibraryItem, "compliance library item", obj(args, "input"))
+}
+
+func deleteLibraryItem(s *Server, args object) (interface{}, *gqlerror.Error) {
+	return deleteByInputID(s, kindLibraryItem, "compliance library item", obj(args, "input"))
+}
+
+// getByInputID, updateWithFields and deleteByInputID implement the compliance
+// operations, which take an input with the id and, for updates, the changed
+// fields.
+func getByInputID(s *Server, kind, name string, input object) (interface{}, *gqlerror.Error) {
+	o, ok := s.get(kind, str(input, "id"))
+	if !ok {
+		return nil, missingError(fmt.Sprintf("Could not find %s with id %s", name, str(input, "id")))
+	}
+	return o, nil
+}
+
+func updateWithFields(s *Server, kind, name string, input object) (interface{}, *gqlerror.Error) {
+	o, ok := s.get(kind, str(input, "id"))
+	if !ok {
+		return nil, missingError(fmt.Sprintf("Could not find %s with id %s", name, str(input, "id")))
+	}
+	merge(o, obj(input, "updates"))
+	return o, nil
+}
+
+func deleteByInputID(s *Server, kind, name string, input object) (interface{}, *gqlerror.Error) {
+	id := str(input, "id")
+	if _, ok := s.get(kind, id); !ok {
+		return nil, missingError(fmt.Sprintf("Could not find %s with id %s", name, id))
+	}
+	s.remove(kind, id)
+	return id, nil
+}
+
+// controls
+
+func getControl(s *Server, args object) (interface{}, *gqlerror.Error) {
+	control, ok := s.get(kindControl, str(args, "id"))
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("Could not find control with id %s", str(args, "id")))
+	}
+	return control, nil
+}
+
+func createControl(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "input")
+	if input["state"] == nil {
+		input["state"] = "DRAFT"
+	}
+	input["frameworkIds"] = []interface{}{}
+	id := s.insert(kindControl, input)
+	return s.objects[kindControl][id], nil
+}
+
+func updateControl(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "input")
+	control, ok := s.get(kindControl, str(input, "id"))
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("Could not find control with id %s", str(input, "id")))
+	}
+
+	requirementIDs := updateList(control["requirementIds"], obj(input, "requirementIds"))
+	delete(input, "requirementIds")
+	merge(control, input)
+	control["requirementIds"] = requirementIDs
+	return control, nil
+}
+
+// updateList applies a ListUpdateInput to a list of ids.
+func updateList(current interface{}, update object) []interface{} {
+	list, _ := current.([]interface{})
+	if set, ok := update["set"].([]interface{}); ok {
+		list = set
+	}
+	if add, ok := update["add"].([]interface{}); ok {
+		list = append(list, add...)
+	}
+	if remove, ok := update["remove"].([]interface{}); ok {
+		kept := []interface{}{}
+		for _, v := range list {
+			removed := false
+			for _, r := range remove {
+				removed = removed || v == r
+			}
+			if !removed {
+				kept = append(kept, v)
+			}
+		}
+		list = kept
+	}
+	return list
+}
+
+func deleteControl(s *Server, args object) (interface{}, *gqlerror.Error) {
+	id := str(obj(args, "input"), "id")
+	if _, ok := s.get(kindControl, id); !ok {
+		return nil, notFoundError(fmt.Sprintf("Could not find control with id %s", id))
+	}
+	s.remove(kindControl, id)
+	return success(), nil
+}
+
+func transitionControlState(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "input")
+	control, ok := s.get(kindControl, str(input, "controlId"))
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("Could not find control with id %s", str(input, "controlId")))
+	}
+	control["state"] = input["targetState"]
+	return control, nil
+}
+
+// drop rules
+
+func getDropRules(s *Server, args object) (interface{}, *gqlerror.Error) {
+	config, ok := s.get(kindDropRules, dropRulesID)
+	if !ok {
+		return object{"enabled": false, "version": 0, "ruleCount": 0, "rules": []interface{}{}}, nil
+	}
+	return config, nil
+}
+
+func saveDropRules(s *Server, args object) (interface{}, *gqlerror.Error) {
+	input := obj(args, "input")
+
+	config, created := s.get(kindDropRules, dropRulesID)
+	created = !created
+	var version float64
+	if !created {
+		version, _ = config["version"].(float64)
+	}
+
+	if ifVersion, ok := input["ifVersion"].(float64); ok && ifVersion != version {
+		return nil, conflictError(fmt.Sprintf("Drop rules config version %v does not match the latest version %v", ifVersion, version))
+	}
+
+	rules, _ := input["rules"].([]interface{})
+	for _, r := range rules {
+		rule := r.(object)
+		if str(rule, "id") == "" {
+			s.nextID++
+			rule["id"] = fmt.Sprintf("%08d-fake-drop-rule", s.nextID)
+		}
+	}
+
+	config = object{
+		"id":        dropRulesID,
+		"enabled":   input["enabled"],
+		"version":   version + 1,
+		"ruleCount": len(rules),
+		"rules":     rules,
+	}
+	s.store(kindDropRules, normalize(config).(object))
+
+	return object{"created": created, "config": config}, nil
+}
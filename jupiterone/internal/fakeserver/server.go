@@ -0,0 +1,464 @@
+// Package fakeserver is an in-memory fake of the JupiterOne GraphQL API for
+// tests. It implements the operations of the client package for questions,
+// rules, dashboards, widgets, user groups, users, resource groups, compliance
+// frameworks, controls and drop rules, so resources can go through their
+// whole lifecycle without the network or recorded cassettes. Queries return
+// the totals set with SetQueryTotal and no data.
+//
+//	server := fakeserver.NewServer()
+//	defer server.Close()
+//
+//	qlient, err := server.Qlient(ctx)
+//
+// Responses are built from the selection set of the request like a real
+// GraphQL server, so the generated client decodes them as usual. Errors use
+// the messages and extension codes of the API: a read of a missing rule,
+// dashboard or control returns a NOT_FOUND error, while the services that do
+// not classify the error only return their message.
+package fakeserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// AccountID is the account of the fake server.
+const AccountID = "fake-account"
+
+type object = map[string]interface{}
+
+// resolver returns the value of a root field from its arguments.
+type resolver func(s *Server, args object) (interface{}, *gqlerror.Error)
+
+// Server is a fake JupiterOne GraphQL API backed by in-memory state.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	nextID   int
+	objects  map[string]map[string]object
+	failures map[string][]*gqlerror.Error
+	requests map[string]int
+	totals   map[string]int64
+	pageSize int
+}
+
+// NewServer starts a fake server, call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		objects:  map[string]map[string]object{},
+		failures: map[string][]*gqlerror.Error{},
+		requests: map[string]int{},
+		totals:   map[string]int64{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Qlient returns a client of the fake server, configured like the provider
+// configures its client.
+func (s *Server) Qlient(ctx context.Context) (graphql.Client, error) {
+	config := &client.JupiterOneClientConfig{
+		APIKey:      "fake-api-key",
+		AccountID:   AccountID,
+		Endpoint:    s.URL,
+		MaxAttempts: 1,
+	}
+	return config.Qlient(ctx)
+}
+
+// FailNext makes the next request for the operation fail with the error
+// instead of running it. It can be called several times to fail several
+// requests in a row.
+func (s *Server) FailNext(operationName string, err *gqlerror.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures[operationName] = append(s.failures[operationName], err)
+}
+
+// SetPageSize limits the pages of lists with a limit argument, such as the
+// users of the account or the groups of a user, to size items, so clients
+// have to follow their cursors. With 0, the default, pages hold as many items
+// as the limit.
+func (s *Server) SetPageSize(size int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pageSize = size
+}
+
+// Requests returns how many requests were received for the operation.
+func (s *Server) Requests(operationName string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[operationName]
+}
+
+// SetQueryTotal sets the total count of results that the query returns.
+func (s *Server) SetQueryTotal(query string, total int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.totals[query] = total
+}
+
+// EvaluateRule records an evaluation of the rule that ended at endOn, in
+// milliseconds since the epoch, as the rule evaluator would.
+func (s *Server) EvaluateRule(id string, endOn int64, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.get(kindRule, id)
+	if !ok {
+		return
+	}
+
+	rule["lastEvaluationEndOn"] = endOn
+	if failed {
+		rule["lastFailedEvaluationEndOn"] = endOn
+		rule["evaluationStep"] = "FAILED"
+	} else {
+		rule["lastSuccessfulEvaluationEndOn"] = endOn
+		rule["evaluationStep"] = "DONE"
+	}
+}
+
+// AddUser adds a user to the account that is a member of the groups, as if
+// the user had accepted invitations to them.
+func (s *Server) AddUser(email string, groupIDs ...string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groups := make([]interface{}, 0, len(groupIDs))
+	for _, id := range groupIDs {
+		groups = append(groups, object{"id": id})
+	}
+
+	return s.insert(kindUser, object{
+		"email":      email,
+		"nickName":   email,
+		"userGroups": object{"items": groups},
+	})
+}
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type response struct {
+	Data   interface{}   `json:"data"`
+	Errors gqlerror.List `json:"errors,omitempty"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Header.Get("Authorization") == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, errs := s.execute(req)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response{Data: data, Errors: errs})
+}
+
+// execute runs the operation of the request. Like the API, operations with
+// several root fields are not supported.
+func (s *Server) execute(req request) (interface{}, gqlerror.List) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: req.Query})
+	if err != nil {
+		return nil, gqlerror.List{validationError(err.Error())}
+	}
+
+	op := doc.Operations.ForName(req.OperationName)
+	if op == nil || len(op.SelectionSet) != 1 {
+		return nil, gqlerror.List{validationError(fmt.Sprintf("unsupported operation %q", req.OperationName))}
+	}
+
+	field, ok := op.SelectionSet[0].(*ast.Field)
+	if !ok {
+		return nil, gqlerror.List{validationError("the root selection must be a field")}
+	}
+
+	vars := req.Variables
+	if vars == nil {
+		vars = map[string]interface{}{}
+	}
+	args, err := arguments(field, vars)
+	if err != nil {
+		return nil, gqlerror.List{validationError(err.Error())}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[req.OperationName]++
+
+	if failures := s.failures[req.OperationName]; len(failures) > 0 {
+		s.failures[req.OperationName] = failures[1:]
+		return nil, gqlerror.List{failures[0]}
+	}
+
+	resolve, ok := resolvers[field.Name]
+	if !ok {
+		return nil, gqlerror.List{validationError(fmt.Sprintf("the fake server does not implement %s", field.Name))}
+	}
+
+	// Round trip the arguments so handlers only see JSON values.
+	value, gqlErr := resolve(s, normalize(args).(object))
+	if gqlErr != nil {
+		gqlErr.Path = ast.Path{ast.PathName(field.Alias)}
+		return nil, gqlerror.List{gqlErr}
+	}
+
+	p := projection{fragments: doc.Fragments, vars: vars, pageSize: s.pageSize}
+	return object{field.Alias: p.project(p.page(normalize(value), field), field.SelectionSet)}, nil
+}
+
+// projection builds the response of a request from the values of the server.
+type projection struct {
+	fragments ast.FragmentDefinitionList
+	vars      map[string]interface{}
+	pageSize  int
+}
+
+// project keeps the fields of value selected by the selection set.
+func (p projection) project(value interface{}, selections ast.SelectionSet) interface{} {
+	if len(selections) == 0 {
+		return value
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		projected := make([]interface{}, len(v))
+		for i, item := range v {
+			projected[i] = p.project(item, selections)
+		}
+		return projected
+	case object:
+		projected := object{}
+		p.projectInto(projected, v, selections)
+		return projected
+	default:
+		return value
+	}
+}
+
+func (p projection) projectInto(projected, value object, selections ast.SelectionSet) {
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			projected[sel.Alias] = p.project(p.page(value[sel.Name], sel), sel.SelectionSet)
+		case *ast.InlineFragment:
+			p.projectInto(projected, value, sel.SelectionSet)
+		case *ast.FragmentSpread:
+			if fragment := p.fragments.ForName(sel.Name); fragment != nil {
+				p.projectInto(projected, value, fragment.SelectionSet)
+			}
+		}
+	}
+}
+
+// page returns the page of a list of items, such as the groups of a user,
+// selected by the limit and cursor arguments of the field. Pages hold at most
+// pageSize items when it is set, and their cursors are the offset of the next
+// item.
+func (p projection) page(value interface{}, field *ast.Field) interface{} {
+	list, ok := value.(object)
+	if !ok || field.Arguments.ForName("limit") == nil {
+		return value
+	}
+	items, ok := list["items"].([]interface{})
+	if !ok {
+		return value
+	}
+
+	args, err := arguments(field, p.vars)
+	if err != nil {
+		return value
+	}
+	size := len(items)
+	if limit, ok := intArgument(args["limit"]); ok && limit > 0 {
+		size = limit
+	}
+	if p.pageSize > 0 && p.pageSize < size {
+		size = p.pageSize
+	}
+
+	start := 0
+	if cursor, _ := args["cursor"].(string); cursor != "" {
+		start, _ = strconv.Atoi(cursor)
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+
+	paged := object{}
+	for k, v := range list {
+		paged[k] = v
+	}
+	paged["items"] = items[start:end]
+	paged["pageInfo"] = object{"endCursor": strconv.Itoa(end), "hasNextPage": end < len(items)}
+	return paged
+}
+
+// arguments returns the values of the arguments of the field.
+func arguments(field *ast.Field, vars map[string]interface{}) (object, error) {
+	args := object{}
+	for _, arg := range field.Arguments {
+		value, err := arg.Value.Value(vars)
+		if err != nil {
+			return nil, err
+		}
+		args[arg.Name] = value
+	}
+	return args, nil
+}
+
+func intArgument(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+// normalize converts value to the types encoding/json decodes to, so stored
+// objects never share maps or slices with the caller.
+func normalize(value interface{}) interface{} {
+	b, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		panic(err)
+	}
+	return normalized
+}
+
+// insert stores a copy of the object under a new id and returns the id.
+func (s *Server) insert(kind string, obj object) string {
+	s.nextID++
+	id := fmt.Sprintf("%08d-fake-%s", s.nextID, kind)
+
+	obj = normalize(obj).(object)
+	obj["id"] = id
+	s.store(kind, obj)
+
+	return id
+}
+
+func (s *Server) store(kind string, obj object) {
+	if s.objects[kind] == nil {
+		s.objects[kind] = map[string]object{}
+	}
+	s.objects[kind][obj["id"].(string)] = obj
+}
+
+func (s *Server) get(kind, id string) (object, bool) {
+	obj, ok := s.objects[kind][id]
+	return obj, ok
+}
+
+func (s *Server) remove(kind, id string) {
+	delete(s.objects[kind], id)
+}
+
+// list returns the objects of the kind in the order they were created.
+func (s *Server) list(kind string, match func(object) bool) []interface{} {
+	ids := make([]string, 0, len(s.objects[kind]))
+	for id := range s.objects[kind] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	items := []interface{}{}
+	for _, id := range ids {
+		if obj := s.objects[kind][id]; match == nil || match(obj) {
+			items = append(items, obj)
+		}
+	}
+	return items
+}
+
+// merge sets the fields of the update on the object, skipping null values
+// which the API treats as unchanged.
+func merge(obj, update object) {
+	for k, v := range update {
+		if v != nil {
+			obj[k] = v
+		}
+	}
+}
+
+func notFoundError(message string) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message:    message,
+		Extensions: map[string]interface{}{"code": "NOT_FOUND"},
+	}
+}
+
+// missingError is the error of the services that report a missing object
+// without a NOT_FOUND code, which clients only recognize by its message.
+func missingError(message string) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message:    message,
+		Extensions: map[string]interface{}{"code": "INTERNAL_SERVER_ERROR"},
+	}
+}
+
+func validationError(message string) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message:    message,
+		Extensions: map[string]interface{}{"code": "GRAPHQL_VALIDATION_FAILED"},
+	}
+}
+
+// inputError reports an invalid input argument. Like the API, it does not
+// name the offending field outside of the message.
+func inputError(message string) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message:    message,
+		Extensions: map[string]interface{}{"code": "BAD_USER_INPUT"},
+	}
+}
+
+func conflictError(message string) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message:    message,
+		Extensions: map[string]interface{}{"code": "CONFLICT"},
+	}
+}
@@ -0,0 +1,217 @@
+package fakeserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func newTestQlient(t *testing.T) (*Server, graphql.Client) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	qlient, err := server.Qlient(context.TODO())
+	require.NoError(t, err)
+	return server, qlient
+}
+
+func TestQuestionLifecycle(t *testing.T) {
+	ctx := context.TODO()
+	_, qlient := newTestQlient(t)
+
+	created, err := client.CreateQuestion(ctx, qlient, client.CreateQuestionInput{
+		Title: "question",
+		Tags:  []string{"a"},
+		Queries: []client.QuestionQueryInput{
+			{Name: "query0", Query: "FIND Host", Version: "v1", ResultsAre: client.QueryResultsAreBad},
+		},
+	})
+	require.NoError(t, err)
+	id := created.CreateQuestion.Id
+
+	question, err := client.GetQuestionById(ctx, qlient, id)
+	require.NoError(t, err)
+	assert.Equal(t, "question", question.Question.Title)
+	assert.Equal(t, "FIND Host", question.Question.Queries[0].Query)
+
+	_, err = client.UpdateQuestion(ctx, qlient, id, client.QuestionUpdate{
+		Title:   "renamed",
+		Queries: []client.QuestionQueryInput{{Name: "query0", Query: "FIND User"}},
+	})
+	require.NoError(t, err)
+
+	question, err = client.GetQuestionById(ctx, qlient, id)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", question.Question.Title)
+	assert.Equal(t, "FIND User", question.Question.Queries[0].Query)
+
+	_, err = client.DeleteQuestion(ctx, qlient, id)
+	require.NoError(t, err)
+
+	_, err = client.GetQuestionById(ctx, qlient, id)
+	require.Error(t, err)
+	assert.False(t, client.IsNotFound(err), "the questions service does not classify the error")
+	assert.Contains(t, err.Error(), "does not exist")
+}
+
+func TestQuestionInputErrors(t *testing.T) {
+	_, qlient := newTestQlient(t)
+
+	_, err := client.CreateQuestion(context.TODO(), qlient, client.CreateQuestionInput{
+		Title:   "question",
+		Queries: []client.QuestionQueryInput{{Name: "query0", Query: "FIND Host"}, {Name: "query1"}},
+	})
+	assert.ErrorIs(t, err, client.ErrValidation)
+
+	assert.Contains(t, err.Error(), "Query must not be empty")
+}
+
+func TestRuleVersions(t *testing.T) {
+	ctx := context.TODO()
+	_, qlient := newTestQlient(t)
+
+	created, err := client.CreateInlineQuestionRuleInstance(ctx, qlient, client.CreateInlineQuestionRuleInstanceInput{
+		Name:     "rule",
+		Question: client.RuleQuestionDetailsInput{Queries: []client.J1QueryInput{{Name: "query0", Query: "FIND Host"}}},
+		Operations: []client.RuleOperationInput{
+			{Actions: []client.JSON{client.JSON(`{"type":"SET_PROPERTY"}`)}},
+		},
+	})
+	require.NoError(t, err)
+	rule := created.CreateQuestionRuleInstance
+	assert.Equal(t, 1, rule.Version)
+	assert.Equal(t, "FIND Host", rule.Question.Queries[0].Query)
+
+	update := client.UpdateInlineQuestionRuleInstanceInput{Id: rule.Id, Version: rule.Version, Name: "renamed"}
+	updated, err := client.UpdateInlineQuestionRuleInstance(ctx, qlient, update)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.UpdateInlineQuestionRuleInstance.Version)
+
+	_, err = client.UpdateInlineQuestionRuleInstance(ctx, qlient, update)
+	assert.ErrorIs(t, err, client.ErrConflict, "stale versions are rejected")
+
+	_, err = client.DeleteRuleInstance(ctx, qlient, rule.Id)
+	require.NoError(t, err)
+	_, err = client.GetQuestionRuleInstance(ctx, qlient, rule.Id)
+	assert.True(t, client.IsNotFound(err))
+}
+
+func TestUserGroupMemberships(t *testing.T) {
+	ctx := context.TODO()
+	server, qlient := newTestQlient(t)
+
+	group, err := client.CreateUserGroup(ctx, qlient, "admins", "", nil, []string{"readonly"})
+	require.NoError(t, err)
+	groupID := group.CreateIamGroup.Id
+
+	_, err = client.CreateUserGroup(ctx, qlient, "admins", "", nil, nil)
+	assert.ErrorIs(t, err, client.ErrConflict)
+
+	invitation, err := client.InviteUser(ctx, qlient, "new@example.com", groupID)
+	require.NoError(t, err)
+
+	invitations, err := client.GetInvitations(ctx, qlient, "")
+	require.NoError(t, err)
+	require.Len(t, invitations.IamGetAccount.AccountInvitations.Items, 1)
+	assert.Equal(t, groupID, invitations.IamGetAccount.AccountInvitations.Items[0].GroupId)
+
+	_, err = client.RevokeInvitation(ctx, qlient, invitation.Invite.Id)
+	require.NoError(t, err)
+	invitations, err = client.GetInvitations(ctx, qlient, "")
+	require.NoError(t, err)
+	assert.Empty(t, invitations.IamGetAccount.AccountInvitations.Items, "revoked invitations are not open")
+
+	server.AddUser("member@example.com", groupID)
+	users, err := client.GetUsersByEmail(ctx, qlient, "member@example.com")
+	require.NoError(t, err)
+	require.Len(t, users.IamGetUserList.Items, 1)
+	assert.Equal(t, groupID, users.IamGetUserList.Items[0].UserGroups.Items[0].Id)
+
+	_, err = client.RemoveUserFromGroup(ctx, qlient, "member@example.com", groupID)
+	require.NoError(t, err)
+	users, err = client.GetUsersByEmail(ctx, qlient, "member@example.com")
+	require.NoError(t, err)
+	assert.Empty(t, users.IamGetUserList.Items[0].UserGroups.Items)
+
+	groups, err := client.GetGroupsByName(ctx, qlient, "admins")
+	require.NoError(t, err)
+	require.Len(t, groups.IamGetGroupList.Items, 1)
+	assert.Equal(t, []string{"readonly"}, groups.IamGetGroupList.Items[0].GroupAbacPermission.Statement)
+}
+
+func TestComplianceGroupsAreListedOnTheFramework(t *testing.T) {
+	ctx := context.TODO()
+	_, qlient := newTestQlient(t)
+
+	framework, err := client.CreateComplianceFramework(ctx, qlient, client.CreateComplianceFrameworkInput{Name: "framework"})
+	require.NoError(t, err)
+	frameworkID := framework.CreateComplianceFramework.Id
+
+	_, err = client.CreateComplianceGroup(ctx, qlient, client.CreateComplianceGroupInput{Name: "group", FrameworkId: frameworkID})
+	require.NoError(t, err)
+
+	groups, err := client.GetComplianceGroups(ctx, qlient, frameworkID)
+	require.NoError(t, err)
+	require.Len(t, groups.ComplianceFramework.Groups, 1)
+	assert.Equal(t, "group", groups.ComplianceFramework.Groups[0].Name)
+
+	_, err = client.DeleteComplianceFramework(ctx, qlient, client.DeleteComplianceFrameworkInput{Id: frameworkID})
+	require.NoError(t, err)
+	_, err = client.GetComplianceFrameworkById(ctx, qlient, frameworkID)
+	require.Error(t, err)
+	assert.False(t, client.IsNotFound(err), "the compliance service does not classify the error")
+	assert.Contains(t, err.Error(), "Could not find compliance framework with id")
+}
+
+func TestDropRulesVersions(t *testing.T) {
+	ctx := context.TODO()
+	_, qlient := newTestQlient(t)
+
+	saved, err := client.SaveDropRulesConfig(ctx, qlient, client.DropRulesConfigInputBeta{
+		Enabled: true,
+		Rules:   []client.DropRuleInputBeta{{Enabled: true}},
+	})
+	require.NoError(t, err)
+	assert.True(t, saved.SaveDropRulesConfigBeta.Created)
+	assert.NotEmpty(t, saved.SaveDropRulesConfigBeta.Config.Rules[0].Id)
+
+	stale := int64(0)
+	_, err = client.SaveDropRulesConfig(ctx, qlient, client.DropRulesConfigInputBeta{IfVersion: &stale})
+	assert.ErrorIs(t, err, client.ErrConflict)
+
+	config, err := client.GetDropRulesConfig(ctx, qlient)
+	require.NoError(t, err)
+	assert.Equal(t, 1, config.DropRulesConfigBeta.RuleCount)
+}
+
+func TestFailNext(t *testing.T) {
+	ctx := context.TODO()
+	server, qlient := newTestQlient(t)
+
+	server.FailNext("GetResourceGroups", &gqlerror.Error{
+		Message:    "Forbidden",
+		Extensions: map[string]interface{}{"code": "FORBIDDEN"},
+	})
+
+	_, err := client.GetResourceGroups(ctx, qlient)
+	assert.True(t, client.IsForbidden(err))
+
+	_, err = client.GetResourceGroups(ctx, qlient)
+	assert.NoError(t, err, "only the next request fails")
+	assert.Equal(t, 2, server.Requests("GetResourceGroups"))
+}
+
+func TestUnimplementedOperations(t *testing.T) {
+	_, qlient := newTestQlient(t)
+
+	_, err := client.GetCollector(context.TODO(), qlient, "1")
+	var clientErr *client.Error
+	require.True(t, errors.As(err, &clientErr))
+	assert.Contains(t, err.Error(), "does not implement")
+}
@@ -0,0 +1,95 @@
+package j1ql
+
+import (
+	"errors"
+	"strings"
+)
+
+// Quote returns s as a string literal, escaping backslashes and quotes.
+func Quote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// InList returns a comparison of the property to any of the values, such as
+// `_type = ('aws_instance' or 'azure_vm')`. The values are quoted as strings.
+func InList(property string, values []string) (string, error) {
+	property = strings.TrimSpace(property)
+	if property == "" {
+		return "", errors.New("the property is empty")
+	}
+	if len(values) == 0 {
+		return "", errors.New("the list of values is empty")
+	}
+
+	if !isProperty(property) {
+		return "", errors.New("the property " + property + " is not a valid J1QL property")
+	}
+
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = Quote(v)
+	}
+	if len(quoted) == 1 {
+		return property + " = " + quoted[0], nil
+	}
+	return property + " = (" + strings.Join(quoted, " or ") + ")", nil
+}
+
+// isProperty reports whether s is a single property, such as `active` or
+// `[tag.CIS2.0]`.
+func isProperty(s string) bool {
+	tokens, err := Lex(s)
+	if err != nil {
+		return false
+	}
+	p := &parser{tokens: tokens}
+	_, err = p.property()
+	return err == nil && p.is(EOF)
+}
+
+// Normalize formats the query on one line, with keywords and the identifiers
+// folded by Equivalent in upper case and single spaces between tokens, so that
+// equivalent queries are equal. Only the tokens of the query are checked, not
+// its grammar.
+func Normalize(query string) (string, error) {
+	tokens, err := Lex(query)
+	if err != nil {
+		return "", err
+	}
+	tokens = fold(tokens)
+
+	var b strings.Builder
+	for i, t := range tokens {
+		if t.Kind == EOF {
+			break
+		}
+		if i > 0 && spaced(tokens[i-1], t) {
+			b.WriteByte(' ')
+		}
+		b.WriteString(t.Text)
+	}
+	return b.String(), nil
+}
+
+// spaced reports whether the normalized query has a space between the
+// tokens.
+func spaced(prev, t Token) bool {
+	switch {
+	case prev.Kind == Punctuation && strings.Contains("([|!", prev.Text):
+		return false
+	case t.Kind == Punctuation && strings.Contains(")],|?", t.Text):
+		return false
+	case strings.HasSuffix(prev.Text, "."):
+		// Properties of aliases, such as `Host.*` or `Host.[tag.Name]`.
+		return false
+	case prev.Kind == Identifier && t.Kind == Punctuation && t.Text == "(":
+		// Function calls such as `count(Host)`, but not `Host (THAT HAS Device)?`.
+		return !adjacent(prev, t)
+	}
+	return true
+}
+
+// adjacent reports whether there is no whitespace between the tokens.
+func adjacent(prev, t Token) bool {
+	return prev.Pos.Line == t.Pos.Line && prev.Pos.Column+len([]rune(prev.Text)) == t.Pos.Column
+}
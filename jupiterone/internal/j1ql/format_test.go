@@ -0,0 +1,61 @@
+package j1ql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, `'web'`, Quote("web"))
+	assert.Equal(t, `'it\'s'`, Quote("it's"))
+	assert.Equal(t, `'C:\\temp'`, Quote(`C:\temp`))
+
+	q, err := Parse("FIND Host WITH name = " + Quote(`it's C:\`))
+	require.NoError(t, err)
+	assert.Equal(t, "name", q.Find.With[0].Property)
+}
+
+func TestInList(t *testing.T) {
+	filter, err := InList("_type", []string{"aws_instance", "azure_vm"})
+	require.NoError(t, err)
+	assert.Equal(t, "_type = ('aws_instance' or 'azure_vm')", filter)
+
+	filter, err = InList("[tag.CIS2.0]", []string{"o'brien"})
+	require.NoError(t, err)
+	assert.Equal(t, `[tag.CIS2.0] = 'o\'brien'`, filter)
+
+	_, err = InList("_type", nil)
+	assert.EqualError(t, err, "the list of values is empty")
+
+	_, err = InList("name = 'x' OR _type", []string{"a"})
+	assert.EqualError(t, err, "the property name = 'x' OR _type is not a valid J1QL property")
+}
+
+func TestNormalize(t *testing.T) {
+	queries := map[string]string{
+		"find Host\r\n  with active=true\n":                                                           "FIND Host WITH active = TRUE",
+		"FIND (aws_instance | azure_vm) AS h THAT !PROTECTS HostAgent RETURN h.*, count( h )":         "FIND (aws_instance|azure_vm) AS h THAT !PROTECTS HostAgent RETURN h.*, COUNT(h)",
+		"Find jupiterone_rule as r (that REPORTED jupiterone_rule_alert as a)? return r.[tag.CIS2.0]": "FIND jupiterone_rule AS r (THAT REPORTED jupiterone_rule_alert AS a)? RETURN r.[tag.CIS2.0]",
+		"FIND Host WITH createdOn > date.now - 7 days LIMIT {{limit}}":                                "FIND Host WITH createdOn > date.now - 7 days LIMIT {{limit}}",
+		"FIND Host THAT has Device":                                                                   "FIND Host THAT HAS Device",
+	}
+
+	for query, expected := range queries {
+		normalized, err := Normalize(query)
+		require.NoError(t, err, query)
+		assert.Equal(t, expected, normalized, query)
+		assert.True(t, Equivalent(query, normalized), query)
+	}
+
+	// Queries that Equivalent treats as equal normalize to the same query.
+	a, err := Normalize("FIND Host THAT has Device WITH active = true RETURN count(Device)")
+	require.NoError(t, err)
+	b, err := Normalize("find Host that HAS Device with active = TRUE return COUNT(Device)")
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	_, err = Normalize("FIND Host WITH name = 'web")
+	assert.EqualError(t, err, "line 1, column 23: string is not closed")
+}
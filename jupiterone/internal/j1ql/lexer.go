@@ -0,0 +1,358 @@
+// Package j1ql parses JupiterOne query language (J1QL) queries, so the
+// provider can report syntax errors and compare queries without the API.
+//
+//	query, err := j1ql.Parse("FIND Host WITH active = true THAT HAS Device RETURN Host.name")
+//
+// The grammar is lenient where the language is open ended, such as the verbs
+// of relationships and the functions of RETURN, so that queries accepted by
+// the API are not rejected here.
+package j1ql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kind is the kind of a token.
+type Kind int
+
+const (
+	EOF Kind = iota
+	Keyword
+	Identifier
+	String
+	Number
+	Template
+	Operator
+	Punctuation
+)
+
+func (k Kind) String() string {
+	switch k {
+	case EOF:
+		return "end of query"
+	case Keyword:
+		return "keyword"
+	case Identifier:
+		return "identifier"
+	case String:
+		return "string"
+	case Number:
+		return "number"
+	case Template:
+		return "template"
+	case Operator:
+		return "operator"
+	default:
+		return "punctuation"
+	}
+}
+
+// keywords are the reserved words of J1QL. They are case insensitive and
+// upper cased in tokens.
+var keywords = map[string]bool{
+	"FIND":   true,
+	"WITH":   true,
+	"AS":     true,
+	"THAT":   true,
+	"WHERE":  true,
+	"RETURN": true,
+	"ORDER":  true,
+	"BY":     true,
+	"SKIP":   true,
+	"LIMIT":  true,
+	"AND":    true,
+	"OR":     true,
+	"NOT":    true,
+	"TREE":   true,
+	"ASC":    true,
+	"DESC":   true,
+}
+
+// operators are the comparison operators, longest first.
+var operators = []string{"!~=", "!^=", "!$=", "<=", ">=", "!=", "~=", "^=", "$=", "<<", ">>", "=", "<", ">"}
+
+// Pos is a position in a query. Lines and columns start at 1, columns count
+// characters.
+type Pos struct {
+	Line   int
+	Column int
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("line %d, column %d", p.Line, p.Column)
+}
+
+// Token is a lexical token of a query. Text is the source of the token,
+// except for keywords which are upper cased.
+type Token struct {
+	Kind Kind
+	Text string
+	Pos  Pos
+}
+
+func (t Token) String() string {
+	if t.Kind == EOF {
+		return t.Kind.String()
+	}
+	return fmt.Sprintf("%s %q", t.Kind, t.Text)
+}
+
+// SyntaxError is an error in the syntax of a query.
+type SyntaxError struct {
+	Pos     Pos
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Message)
+}
+
+// Lex splits the query into tokens, ending with an EOF token.
+func Lex(query string) ([]Token, error) {
+	l := &lexer{src: query, pos: Pos{Line: 1, Column: 1}}
+	var tokens []Token
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+		if t.Kind == EOF {
+			return tokens, nil
+		}
+	}
+}
+
+type lexer struct {
+	src    string
+	offset int
+	pos    Pos
+}
+
+func (l *lexer) peek() rune {
+	r, _ := utf8.DecodeRuneInString(l.src[l.offset:])
+	return r
+}
+
+func (l *lexer) advance() rune {
+	r, size := utf8.DecodeRuneInString(l.src[l.offset:])
+	l.offset += size
+	if r == '\n' {
+		l.pos.Line++
+		l.pos.Column = 1
+	} else {
+		l.pos.Column++
+	}
+	return r
+}
+
+func (l *lexer) done() bool {
+	return l.offset >= len(l.src)
+}
+
+func (l *lexer) next() (Token, error) {
+	for !l.done() && unicode.IsSpace(l.peek()) {
+		l.advance()
+	}
+
+	start, pos := l.offset, l.pos
+	token := func(kind Kind) Token {
+		return Token{Kind: kind, Text: l.src[start:l.offset], Pos: pos}
+	}
+
+	if l.done() {
+		return Token{Kind: EOF, Pos: pos}, nil
+	}
+
+	rest := l.src[l.offset:]
+	r := l.peek()
+	switch {
+	case strings.HasPrefix(rest, "{{"):
+		end := strings.Index(rest, "}}")
+		if end < 0 {
+			return Token{}, &SyntaxError{Pos: pos, Message: "template is not closed with }}"}
+		}
+		for l.offset < start+end+2 {
+			l.advance()
+		}
+		return token(Template), nil
+	case r == '\'' || r == '"':
+		l.advance()
+		for {
+			if l.done() {
+				return Token{}, &SyntaxError{Pos: pos, Message: "string is not closed"}
+			}
+			c := l.advance()
+			if c == '\\' && !l.done() {
+				l.advance()
+			} else if c == r {
+				return token(String), nil
+			}
+		}
+	case unicode.IsDigit(r):
+		for !l.done() && unicode.IsDigit(l.peek()) {
+			l.advance()
+		}
+		if strings.HasPrefix(l.src[l.offset:], ".") {
+			if next, _ := utf8.DecodeRuneInString(l.src[l.offset+1:]); unicode.IsDigit(next) {
+				l.advance()
+				for !l.done() && unicode.IsDigit(l.peek()) {
+					l.advance()
+				}
+			}
+		}
+		return token(Number), nil
+	case isIdentifierStart(r) && !isOperatorStart(rest):
+		for !l.done() && isIdentifierPart(l.peek()) {
+			l.advance()
+		}
+		t := token(Identifier)
+		if upper := strings.ToUpper(t.Text); keywords[upper] {
+			t.Kind = Keyword
+			t.Text = upper
+		}
+		return t, nil
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(rest, op) {
+			for l.offset < start+len(op) {
+				l.advance()
+			}
+			return token(Operator), nil
+		}
+	}
+
+	if strings.ContainsRune("()[],|*?!+-/%", r) {
+		l.advance()
+		return token(Punctuation), nil
+	}
+
+	return Token{}, &SyntaxError{Pos: pos, Message: fmt.Sprintf("unexpected character %q", r)}
+}
+
+func isIdentifierStart(r rune) bool {
+	return r == '_' || r == '$' || r == '@' || unicode.IsLetter(r)
+}
+
+func isIdentifierPart(r rune) bool {
+	return isIdentifierStart(r) || unicode.IsDigit(r) || r == '.' || r == ':'
+}
+
+// isOperatorStart reports whether the identifier start at the beginning of
+// s is the start of an operator instead, such as `$=`.
+func isOperatorStart(s string) bool {
+	return strings.HasPrefix(s, "$=")
+}
+
+// literals are the values of J1QL that are written as identifiers. They are
+// case insensitive.
+var literals = map[string]bool{
+	"TRUE":      true,
+	"FALSE":     true,
+	"NULL":      true,
+	"UNDEFINED": true,
+}
+
+// fold returns the tokens with the identifiers that are case insensitive
+// upper cased, as keywords are: the verbs of relationships, the names of
+// functions and literals.
+func fold(tokens []Token) []Token {
+	folded := make([]Token, len(tokens))
+	copy(folded, tokens)
+
+	// verbs is set in the parentheses of `THAT (HAS|USES)`.
+	verbs := false
+	for i := range folded {
+		t := &folded[i]
+		var prev, next Token
+		if i > 0 {
+			prev = folded[i-1]
+		}
+		if i+1 < len(folded) {
+			next = folded[i+1]
+		}
+		afterThat := prev.Kind == Keyword && prev.Text == "THAT" ||
+			i > 1 && (prev.Kind == Keyword && prev.Text == "NOT" || prev.Kind == Punctuation && prev.Text == "!") &&
+				folded[i-2].Kind == Keyword && folded[i-2].Text == "THAT"
+
+		switch t.Kind {
+		case Punctuation:
+			if t.Text == "(" && afterThat {
+				verbs = true
+			} else if t.Text == ")" {
+				verbs = false
+			}
+		case Identifier:
+			upper := strings.ToUpper(t.Text)
+			switch {
+			case afterThat, verbs,
+				upper == "TO" && prev.Kind == Identifier && prev.Text == "RELATES",
+				isFunctionName(prev, *t, next),
+				literals[upper] && isValueStart(prev):
+				t.Text = upper
+			}
+		}
+	}
+	return folded
+}
+
+// isFunctionName reports whether the identifier is the name of a function
+// call, such as `count(h)`, and not a class or alias followed by a
+// parenthesized relationship, such as `Host AS h (THAT HAS Device)?`.
+func isFunctionName(prev, t, next Token) bool {
+	if next.Kind != Punctuation || next.Text != "(" || !adjacent(t, next) {
+		return false
+	}
+	switch prev.Kind {
+	case Identifier:
+		return false
+	case Keyword:
+		return prev.Text != "FIND" && prev.Text != "AS" && prev.Text != "THAT"
+	}
+	return true
+}
+
+// isValueStart reports whether a value can follow the token in a comparison,
+// as after `=` or in a list such as `(true or false)`.
+func isValueStart(t Token) bool {
+	switch t.Kind {
+	case Operator:
+		return t.Text != "<<" && t.Text != ">>"
+	case Keyword:
+		return t.Text == "OR" || t.Text == "AND"
+	case Punctuation:
+		return t.Text == "(" || t.Text == "[" || t.Text == ","
+	}
+	return false
+}
+
+// Equivalent reports whether two queries have the same tokens, so that they
+// differ only in whitespace and in the case of keywords, relationship verbs,
+// function names and literals. Queries that cannot be lexed are only
+// equivalent when they are equal.
+func Equivalent(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	aTokens, err := Lex(a)
+	if err != nil {
+		return false
+	}
+	bTokens, err := Lex(b)
+	if err != nil || len(aTokens) != len(bTokens) {
+		return false
+	}
+
+	aTokens, bTokens = fold(aTokens), fold(bTokens)
+	for i := range aTokens {
+		if aTokens[i].Kind != bTokens[i].Kind || aTokens[i].Text != bTokens[i].Text {
+			return false
+		}
+	}
+	return true
+}
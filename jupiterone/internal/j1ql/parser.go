@@ -0,0 +1,576 @@
+package j1ql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Query is a parsed J1QL query.
+type Query struct {
+	// Unique is set by `FIND UNIQUE`.
+	Unique bool
+	// Find selects the entities the query starts from, the FROM entities of
+	// a shortest path query.
+	Find *Selector
+	// ShortestPath is the TO entities of a `FIND SHORTEST PATH FROM ... TO
+	// ...` query.
+	ShortestPath *Selector
+	Traversals   []*Traversal
+	Where        []*Comparison
+	Return       []*ReturnItem
+	ReturnTree   bool
+	OrderBy      []string
+	Skip         *int
+	Limit        *int
+}
+
+// Selector selects the entities of a FIND or of a traversal.
+type Selector struct {
+	Pos Pos
+	// Targets are the classes or types of the entities, "*" for any.
+	Targets []string
+	With    []*Comparison
+	Alias   string
+}
+
+// Traversal follows relationships from the previous entities, such as
+// `THAT HAS Device`.
+type Traversal struct {
+	Pos      Pos
+	Negated  bool
+	Optional bool
+	Verbs    []string
+	// Alias is the alias of the relationship.
+	Alias    string
+	Selector *Selector
+}
+
+// Comparison is a comparison of a WITH or WHERE clause.
+type Comparison struct {
+	Pos      Pos
+	Property string
+	Operator string
+}
+
+// ReturnItem is a selector of a RETURN clause, such as `Host.name` or
+// `count(Host)`.
+type ReturnItem struct {
+	Pos        Pos
+	Expression string
+	Alias      string
+}
+
+// AllProperties reports whether the item returns every property of the
+// entities, as `*` or `Host.*` does.
+func (r *ReturnItem) AllProperties() bool {
+	return r.Expression == "*" || strings.HasSuffix(r.Expression, ".*")
+}
+
+// Parse parses a query. Errors are *SyntaxError with the position of the
+// offending token.
+func Parse(query string) (*Query, error) {
+	tokens, err := Lex(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	q, err := p.query()
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+type parser struct {
+	tokens []Token
+	i      int
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) peekAt(offset int) Token {
+	if p.i+offset < len(p.tokens) {
+		return p.tokens[p.i+offset]
+	}
+	return p.tokens[len(p.tokens)-1]
+}
+
+func (p *parser) next() Token {
+	t := p.tokens[p.i]
+	if t.Kind != EOF {
+		p.i++
+	}
+	return t
+}
+
+// is reports whether the next token has the kind and one of the texts.
+func (p *parser) is(kind Kind, texts ...string) bool {
+	t := p.peek()
+	if t.Kind != kind {
+		return false
+	}
+	for _, text := range texts {
+		if t.Text == text {
+			return true
+		}
+	}
+	return len(texts) == 0
+}
+
+// isWord reports whether the next token is an identifier with the text,
+// ignoring case. Words such as UNIQUE and SHORTEST only have a meaning at
+// some places of a query and are not keywords, so that they can still be
+// used as names elsewhere.
+func (p *parser) isWord(word string) bool {
+	return p.peek().Kind == Identifier && strings.EqualFold(p.peek().Text, word)
+}
+
+func (p *parser) acceptWord(word string) bool {
+	if p.isWord(word) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) accept(kind Kind, texts ...string) bool {
+	if p.is(kind, texts...) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind Kind, text string) error {
+	if !p.accept(kind, text) {
+		return p.errorf("expected %s, found %s", text, p.peek())
+	}
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Pos: p.peek().Pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) query() (*Query, error) {
+	if err := p.expect(Keyword, "FIND"); err != nil {
+		return nil, err
+	}
+
+	q := &Query{}
+	var err error
+	switch {
+	case p.isWord("UNIQUE") && startsSelector(p.peekAt(1)):
+		p.next()
+		q.Unique = true
+	case p.isWord("SHORTEST") && p.peekAt(1).Kind == Identifier && strings.EqualFold(p.peekAt(1).Text, "PATH"):
+		return p.shortestPath(q)
+	}
+
+	if q.Find, err = p.selector(); err != nil {
+		return nil, err
+	}
+
+	for p.is(Keyword, "THAT") || (p.is(Punctuation, "(") && p.peekAt(1).Kind == Keyword && p.peekAt(1).Text == "THAT") {
+		t, err := p.traversal()
+		if err != nil {
+			return nil, err
+		}
+		q.Traversals = append(q.Traversals, t)
+	}
+
+	return p.clauses(q)
+}
+
+// startsSelector reports whether the token can start the targets of a
+// selector, so that `FIND UNIQUE Host` is told apart from `FIND Unique`.
+func startsSelector(t Token) bool {
+	return t.Kind == Identifier || t.Kind == Template || (t.Kind == Punctuation && (t.Text == "*" || t.Text == "("))
+}
+
+// shortestPath parses `SHORTEST PATH FROM selector TO selector` and the
+// clauses that follow it.
+func (p *parser) shortestPath(q *Query) (*Query, error) {
+	p.next()
+	p.next()
+
+	if !p.acceptWord("FROM") {
+		return nil, p.errorf("expected FROM, found %s", p.peek())
+	}
+	var err error
+	if q.Find, err = p.selector(); err != nil {
+		return nil, err
+	}
+
+	if !p.acceptWord("TO") {
+		return nil, p.errorf("expected TO, found %s", p.peek())
+	}
+	if q.ShortestPath, err = p.selector(); err != nil {
+		return nil, err
+	}
+
+	return p.clauses(q)
+}
+
+// clauses parses the WHERE, RETURN, ORDER BY, SKIP and LIMIT clauses that
+// end a query.
+func (p *parser) clauses(q *Query) (*Query, error) {
+	var err error
+	if p.accept(Keyword, "WHERE") {
+		if q.Where, err = p.conditions(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.accept(Keyword, "RETURN") {
+		if p.accept(Keyword, "TREE") {
+			q.ReturnTree = true
+		} else if q.Return, err = p.returnItems(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.accept(Keyword, "ORDER") {
+		if err := p.expect(Keyword, "BY"); err != nil {
+			return nil, err
+		}
+		for {
+			property, err := p.property()
+			if err != nil {
+				return nil, err
+			}
+			q.OrderBy = append(q.OrderBy, property)
+			p.accept(Keyword, "ASC", "DESC")
+			if !p.accept(Punctuation, ",") {
+				break
+			}
+		}
+	}
+
+	if p.accept(Keyword, "SKIP") {
+		if q.Skip, err = p.count("SKIP"); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept(Keyword, "LIMIT") {
+		if q.Limit, err = p.count("LIMIT"); err != nil {
+			return nil, err
+		}
+	}
+
+	if !p.is(EOF) {
+		return nil, p.errorf("unexpected %s", p.peek())
+	}
+	return q, nil
+}
+
+func (p *parser) count(clause string) (*int, error) {
+	if !p.is(Number) && !p.is(Template) {
+		return nil, p.errorf("expected a number after %s, found %s", clause, p.peek())
+	}
+	n, err := strconv.Atoi(p.next().Text)
+	if err != nil {
+		// Templates are only known to the API.
+		n = -1
+	}
+	return &n, nil
+}
+
+// selector parses `[quantity] target [WITH conditions] [AS alias]`.
+func (p *parser) selector() (*Selector, error) {
+	s := &Selector{Pos: p.peek().Pos}
+
+	// Quantities such as `THAT HAS >= 2 Device`.
+	if p.is(Operator, "=", "!=", "<", "<=", ">", ">=") && p.peekAt(1).Kind == Number {
+		p.next()
+	}
+	if p.is(Number) && p.peekAt(1).Kind != EOF {
+		p.next()
+	}
+
+	switch {
+	case p.accept(Punctuation, "*"):
+		s.Targets = []string{"*"}
+	case p.is(Identifier), p.is(Template):
+		s.Targets = []string{p.next().Text}
+	case p.accept(Punctuation, "("):
+		for {
+			if !p.is(Identifier) && !p.is(Template) {
+				return nil, p.errorf("expected a class or type, found %s", p.peek())
+			}
+			s.Targets = append(s.Targets, p.next().Text)
+			if !p.accept(Punctuation, "|") {
+				break
+			}
+		}
+		if err := p.expect(Punctuation, ")"); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, p.errorf("expected a class or type, found %s", p.peek())
+	}
+
+	if p.accept(Keyword, "WITH") {
+		var err error
+		if s.With, err = p.conditions(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.accept(Keyword, "AS") {
+		if !p.is(Identifier) {
+			return nil, p.errorf("expected an alias, found %s", p.peek())
+		}
+		s.Alias = p.next().Text
+	}
+
+	return s, nil
+}
+
+// traversal parses `THAT [!|NOT] verb [<<|>>] [AS alias] selector`, or an
+// optional traversal in parentheses followed by `?`.
+func (p *parser) traversal() (*Traversal, error) {
+	t := &Traversal{Pos: p.peek().Pos}
+
+	grouped := p.accept(Punctuation, "(")
+	if err := p.expect(Keyword, "THAT"); err != nil {
+		return nil, err
+	}
+
+	t.Negated = p.accept(Punctuation, "!") || p.accept(Keyword, "NOT")
+
+	switch {
+	case p.accept(Punctuation, "("):
+		for {
+			verb, err := p.verb()
+			if err != nil {
+				return nil, err
+			}
+			t.Verbs = append(t.Verbs, verb)
+			if !p.accept(Punctuation, "|") {
+				break
+			}
+		}
+		if err := p.expect(Punctuation, ")"); err != nil {
+			return nil, err
+		}
+	default:
+		verb, err := p.verb()
+		if err != nil {
+			return nil, err
+		}
+		t.Verbs = []string{verb}
+	}
+
+	p.accept(Operator, "<<", ">>")
+
+	if p.accept(Keyword, "AS") {
+		if !p.is(Identifier) {
+			return nil, p.errorf("expected an alias, found %s", p.peek())
+		}
+		t.Alias = p.next().Text
+	}
+
+	var err error
+	if t.Selector, err = p.selector(); err != nil {
+		return nil, err
+	}
+
+	if grouped {
+		if err := p.expect(Punctuation, ")"); err != nil {
+			return nil, err
+		}
+		t.Optional = p.accept(Punctuation, "?")
+	}
+
+	return t, nil
+}
+
+func (p *parser) verb() (string, error) {
+	if !p.is(Identifier) {
+		return "", p.errorf("expected a relationship verb, found %s", p.peek())
+	}
+	verb := strings.ToUpper(p.next().Text)
+	if verb == "RELATES" && strings.EqualFold(p.peek().Text, "TO") && p.peek().Kind == Identifier {
+		p.next()
+		verb = "RELATES TO"
+	}
+	return verb, nil
+}
+
+// conditions parses comparisons combined with AND, OR, NOT and parentheses.
+func (p *parser) conditions() ([]*Comparison, error) {
+	var comparisons []*Comparison
+	for {
+		for p.accept(Keyword, "NOT") || p.accept(Punctuation, "!") {
+		}
+
+		if p.accept(Punctuation, "(") {
+			nested, err := p.conditions()
+			if err != nil {
+				return nil, err
+			}
+			comparisons = append(comparisons, nested...)
+			if err := p.expect(Punctuation, ")"); err != nil {
+				return nil, err
+			}
+		} else {
+			c, err := p.comparison()
+			if err != nil {
+				return nil, err
+			}
+			comparisons = append(comparisons, c)
+		}
+
+		if !p.accept(Keyword, "AND", "OR") {
+			return comparisons, nil
+		}
+	}
+}
+
+// comparison parses `property operator value`.
+func (p *parser) comparison() (*Comparison, error) {
+	pos := p.peek().Pos
+	property, err := p.property()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.is(Operator) || p.is(Operator, "<<", ">>") {
+		return nil, p.errorf("expected a comparison operator after %s, found %s", property, p.peek())
+	}
+	operator := p.next()
+
+	if err := p.value(); err != nil {
+		return nil, err
+	}
+
+	return &Comparison{Pos: pos, Property: property, Operator: operator.Text}, nil
+}
+
+// property parses a property such as `active`, `Host.active` or a property
+// in brackets such as `[tag.CIS2.0]` or `Host.[tag.CIS2.0]`.
+func (p *parser) property() (string, error) {
+	var property string
+	switch {
+	case p.is(Template):
+		return p.next().Text, nil
+	case p.is(Identifier):
+		property = p.next().Text
+		if !strings.HasSuffix(property, ".") {
+			return property, nil
+		}
+		if !p.is(Punctuation, "[") {
+			return "", p.errorf("expected a property after %s, found %s", property, p.peek())
+		}
+	case !p.is(Punctuation, "["):
+		return "", p.errorf("expected a property, found %s", p.peek())
+	}
+
+	property += p.next().Text
+	for !p.is(Punctuation, "]") {
+		if p.is(EOF) {
+			return "", p.errorf("expected ], found %s", p.peek())
+		}
+		property += p.next().Text
+	}
+	return property + p.next().Text, nil
+}
+
+// value parses a literal, a property, a list of values such as
+// `('a' or 'b')`, or date math such as `date.now - 7 days`.
+func (p *parser) value() error {
+	p.accept(Punctuation, "-")
+
+	switch {
+	case p.is(String), p.is(Number), p.is(Template), p.is(Identifier):
+		p.next()
+	case p.accept(Punctuation, "(", "["):
+		closing := ")"
+		if p.tokens[p.i-1].Text == "[" {
+			closing = "]"
+		}
+		for {
+			if err := p.value(); err != nil {
+				return err
+			}
+			if !p.accept(Keyword, "OR", "AND") && !p.accept(Punctuation, ",") {
+				break
+			}
+		}
+		if err := p.expect(Punctuation, closing); err != nil {
+			return err
+		}
+	default:
+		return p.errorf("expected a value, found %s", p.peek())
+	}
+
+	for p.is(Punctuation, "+", "-") {
+		p.next()
+		if !p.accept(Number) && !p.accept(Template) {
+			return p.errorf("expected a number, found %s", p.peek())
+		}
+		// The unit of date math, such as `days`.
+		if p.is(Identifier) && !p.isComparisonAhead() {
+			p.next()
+		}
+	}
+	return nil
+}
+
+// isComparisonAhead reports whether the next identifier starts a comparison
+// instead of being a unit.
+func (p *parser) isComparisonAhead() bool {
+	next := p.peekAt(1)
+	return next.Kind == Operator && next.Text != "<<" && next.Text != ">>"
+}
+
+// returnItems parses `item [AS alias] [, item [AS alias]]...`. Items are
+// properties such as `Host.name`, `Host.*` or `*`, or expressions of
+// properties and functions such as `count(Host) * 100`.
+func (p *parser) returnItems() ([]*ReturnItem, error) {
+	var items []*ReturnItem
+	for {
+		item := &ReturnItem{Pos: p.peek().Pos}
+
+		var expression []string
+		depth := 0
+	loop:
+		for {
+			t := p.peek()
+			switch {
+			case t.Kind == EOF && depth > 0:
+				return nil, p.errorf("expected ), found %s", t)
+			case depth == 0 && (t.Kind == EOF || t.Kind == Keyword || (t.Kind == Punctuation && (t.Text == "," || t.Text == ")"))):
+				break loop
+			case t.Kind == Punctuation && (t.Text == "(" || t.Text == "["):
+				depth++
+			case t.Kind == Punctuation && (t.Text == ")" || t.Text == "]"):
+				depth--
+			case t.Kind == Keyword && t.Text != "AS":
+				return nil, p.errorf("unexpected %s", t)
+			}
+			expression = append(expression, p.next().Text)
+		}
+		if len(expression) == 0 {
+			return nil, p.errorf("expected a property to return, found %s", p.peek())
+		}
+		item.Expression = strings.Join(expression, "")
+
+		if p.accept(Keyword, "AS") {
+			if !p.is(Identifier) && !p.is(String) {
+				return nil, p.errorf("expected an alias, found %s", p.peek())
+			}
+			item.Alias = p.next().Text
+		}
+
+		items = append(items, item)
+		if !p.accept(Punctuation, ",") {
+			return items, nil
+		}
+	}
+}
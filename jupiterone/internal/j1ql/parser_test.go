@@ -0,0 +1,145 @@
+package j1ql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAcceptsQueries(t *testing.T) {
+	queries := []string{
+		"FIND *",
+		"Find User",
+		"find user with active=true",
+		"FIND User THAT HAS Role",
+		"Find DataStore with classification=('critical' or 'sensitive' or 'confidential' or 'restricted') and encrypted!=true",
+		"FIND * WITH _source='integration-managed' AS ent THAT RELATES TO jupiterone_rule_alert WITH [tag.CIS2.0]=true AS Alert RETURN ent.displayName, ent._type, Alert.[tag.CIS2.0], Alert.[tag.1.1]",
+		"FIND jupiterone_rule WITH [tag.CIS2.0]=true AS Control (THAT REPORTED jupiterone_rule_alert AS Alert)? RETURN Control.displayName AS x, Coalesce(Alert.totalNumberOfAffectedEntities,0) as y",
+		"FIND jupiterone_rule WITH [tag.CIS2.0]=true AS rules (THAT REPORTED jupiterone_rule_alert AS alerts)? RETURN (1-count(alerts)/count(rules))*100 AS value",
+		"FIND jupiterone_rule WITH displayName~= {{controlname}} AS ENT RETURN count(ENT) AS value",
+		"FIND (aws_instance|azure_vm) AS h THAT !PROTECTS HostAgent RETURN h.*",
+		"FIND Host WITH createdOn > date.now - 7 days AND (active = true OR active = undefined) RETURN TREE",
+		"FIND User AS u THAT (HAS|ASSIGNED) AS r Role WHERE u.active = true AND r.admin != false ORDER BY u.name DESC SKIP 10 LIMIT 5",
+		"FIND Finding WITH severity = ['high', 'critical'] THAT RELATES TO << CodeRepo",
+		"FIND User\n  THAT IS Person\n  RETURN User.username, Person.email",
+		"FIND UNIQUE Host WITH active=true",
+		"find unique (User|Person) THAT HAS Device RETURN User.name",
+		"FIND Unique",
+		"FIND SHORTEST PATH FROM Host TO Device",
+		"find shortest path from User WITH username = 'admin' AS u TO DataStore WITH classification = 'critical' RETURN PATH",
+	}
+
+	for _, query := range queries {
+		_, err := Parse(query)
+		assert.NoError(t, err, query)
+	}
+}
+
+func TestParseReportsErrorPosition(t *testing.T) {
+	cases := []struct {
+		query   string
+		pos     Pos
+		message string
+	}{
+		{"", Pos{1, 1}, "expected FIND, found end of query"},
+		{"FIND", Pos{1, 5}, "expected a class or type, found end of query"},
+		{"FIND Host WITH", Pos{1, 15}, "expected a property, found end of query"},
+		{"FIND Host WITH active true", Pos{1, 23}, `expected a comparison operator after active, found identifier "true"`},
+		{"FIND Host\nTHAT HAS", Pos{2, 9}, "expected a class or type, found end of query"},
+		{"FIND Host WITH name = 'web", Pos{1, 23}, "string is not closed"},
+		{"FIND Host RETURN Host.name LIMIT x", Pos{1, 34}, `expected a number after LIMIT, found identifier "x"`},
+		{"FIND Host WITH a = 1 FIND", Pos{1, 22}, `unexpected keyword "FIND"`},
+		{"FIND Host WITH a = {{b", Pos{1, 20}, "template is not closed with }}"},
+		{"FIND Host WITH a = #", Pos{1, 20}, `unexpected character '#'`},
+		{"FIND SHORTEST PATH Host TO Device", Pos{1, 20}, `expected FROM, found identifier "Host"`},
+		{"FIND SHORTEST PATH FROM Host THAT HAS Device", Pos{1, 30}, `expected TO, found keyword "THAT"`},
+	}
+
+	for _, tc := range cases {
+		_, err := Parse(tc.query)
+		var syntaxErr *SyntaxError
+		if assert.True(t, errors.As(err, &syntaxErr), "%q: %v", tc.query, err) {
+			assert.Equal(t, tc.pos, syntaxErr.Pos, tc.query)
+			assert.Equal(t, tc.message, syntaxErr.Message, tc.query)
+		}
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	q, err := Parse("FIND Host WITH active = true AS h THAT HAS Device RETURN h.*, Device.name LIMIT 10")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Host"}, q.Find.Targets)
+	assert.Equal(t, "h", q.Find.Alias)
+	require.Len(t, q.Find.With, 1)
+	assert.Equal(t, "active", q.Find.With[0].Property)
+	assert.Equal(t, "=", q.Find.With[0].Operator)
+
+	require.Len(t, q.Traversals, 1)
+	assert.Equal(t, []string{"HAS"}, q.Traversals[0].Verbs)
+	assert.Equal(t, []string{"Device"}, q.Traversals[0].Selector.Targets)
+
+	require.Len(t, q.Return, 2)
+	assert.True(t, q.Return[0].AllProperties())
+	assert.False(t, q.Return[1].AllProperties())
+	assert.Equal(t, "Device.name", q.Return[1].Expression)
+
+	require.NotNil(t, q.Limit)
+	assert.Equal(t, 10, *q.Limit)
+}
+
+func TestParseUniqueAndShortestPath(t *testing.T) {
+	q, err := Parse("FIND UNIQUE Host WITH active=true")
+	require.NoError(t, err)
+	assert.True(t, q.Unique)
+	assert.Equal(t, []string{"Host"}, q.Find.Targets)
+	assert.Nil(t, q.ShortestPath)
+
+	q, err = Parse("FIND Unique WITH active=true")
+	require.NoError(t, err)
+	assert.False(t, q.Unique, "UNIQUE is only a modifier before a class or type")
+	assert.Equal(t, []string{"Unique"}, q.Find.Targets)
+
+	q, err = Parse("FIND SHORTEST PATH FROM Host WITH active = true TO Device LIMIT 1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Host"}, q.Find.Targets)
+	assert.Len(t, q.Find.With, 1)
+	require.NotNil(t, q.ShortestPath)
+	assert.Equal(t, []string{"Device"}, q.ShortestPath.Targets)
+	require.NotNil(t, q.Limit)
+	assert.Equal(t, 1, *q.Limit)
+}
+
+func TestLex(t *testing.T) {
+	tokens, err := Lex("find Host with x!~='a'")
+	require.NoError(t, err)
+
+	assert.Equal(t, []Token{
+		{Kind: Keyword, Text: "FIND", Pos: Pos{1, 1}},
+		{Kind: Identifier, Text: "Host", Pos: Pos{1, 6}},
+		{Kind: Keyword, Text: "WITH", Pos: Pos{1, 11}},
+		{Kind: Identifier, Text: "x", Pos: Pos{1, 16}},
+		{Kind: Operator, Text: "!~=", Pos: Pos{1, 17}},
+		{Kind: String, Text: "'a'", Pos: Pos{1, 20}},
+		{Kind: EOF, Pos: Pos{1, 23}},
+	}, tokens)
+}
+
+func TestEquivalent(t *testing.T) {
+	assert.True(t, Equivalent("FIND Host WITH active = true", "find Host\r\n  with active=true\n"))
+	assert.True(t, Equivalent("FIND Host RETURN Host.name", "Find   Host return Host.name"))
+	assert.True(t, Equivalent("FIND Host THAT HAS Device", "find Host that has Device"), "verbs are case insensitive")
+	assert.True(t, Equivalent("FIND Host THAT !(HAS|relates to) Device", "FIND Host THAT !(has|RELATES TO) Device"))
+	assert.True(t, Equivalent("FIND Host RETURN count(Host)", "FIND Host RETURN COUNT(Host)"), "functions are case insensitive")
+	assert.True(t, Equivalent("FIND Host WITH active = true", "FIND Host WITH active = TRUE"))
+	assert.True(t, Equivalent("FIND Host WITH active = true AND owner != (null or Undefined)", "FIND Host WITH active = TRUE AND owner != (NULL or undefined)"), "literals are case insensitive")
+	assert.False(t, Equivalent("FIND Host", "FIND host"), "classes are case sensitive")
+	assert.False(t, Equivalent("FIND Host THAT HAS Device", "FIND Host THAT HAS device"))
+	assert.False(t, Equivalent("FIND Host AS h (THAT HAS Device)? RETURN h.name", "FIND Host AS H (THAT HAS Device)? RETURN h.name"), "aliases are case sensitive")
+	assert.False(t, Equivalent("FIND Host (THAT HAS Device)?", "FIND HOST (THAT HAS Device)?"))
+	assert.False(t, Equivalent("FIND Host WITH true = 1", "FIND Host WITH TRUE = 1"), "properties are case sensitive")
+	assert.False(t, Equivalent("FIND Host WITH name = 'a b'", "FIND Host WITH name = 'a  b'"), "strings are compared as is")
+	assert.False(t, Equivalent("FIND Host WITH name = 'a", "find Host WITH name = 'a"))
+}
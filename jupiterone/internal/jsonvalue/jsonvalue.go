@@ -0,0 +1,68 @@
+// Package jsonvalue holds the Go type of the JSON scalar of the JupiterOne
+// API. It is a package of its own so that genqlient can bind the scalar to
+// it from the generated client code.
+package jsonvalue
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// JSON is a value of the JSON scalar of the API, kept in its raw encoding so
+// that it is sent back exactly as it was read or configured. The zero value
+// is JSON null.
+type JSON json.RawMessage
+
+// New encodes v as JSON.
+func New(v interface{}) (JSON, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return JSON(b), nil
+}
+
+// Parse checks that s is valid JSON and returns it as a JSON value.
+func Parse(s string) (JSON, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, err
+	}
+	return JSON(s), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (j *JSON) UnmarshalJSON(b []byte) error {
+	*j = append((*j)[:0], b...)
+	return nil
+}
+
+// IsNull reports whether the value is JSON null.
+func (j JSON) IsNull() bool {
+	trimmed := bytes.TrimSpace(j)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
+
+// Decode decodes the value into v.
+func (j JSON) Decode(v interface{}) error {
+	if len(j) == 0 {
+		return json.Unmarshal([]byte("null"), v)
+	}
+	return json.Unmarshal(j, v)
+}
+
+// String returns the encoding of the value.
+func (j JSON) String() string {
+	if len(j) == 0 {
+		return "null"
+	}
+	return string(j)
+}
@@ -0,0 +1,139 @@
+package jupiterone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/j1ql"
+)
+
+// j1qlSettings are the provider settings for the offline checks of J1QL
+// queries.
+type j1qlSettings struct {
+	// Validate enables the syntax validation of queries at plan time.
+	Validate bool
+	// Strict reports syntax errors as errors instead of warnings.
+	Strict bool
+	Lint   j1qlLintSettings
+}
+
+var (
+	_ resource.ConfigValidator   = j1qlValidator{}
+	_ datasource.ConfigValidator = j1qlValidator{}
+)
+
+// j1qlValidator reports J1QL syntax errors of the query attributes matched by
+// queries, with the line and column of the error, and warns about the
+// queries that fail the lint checks enabled in the provider. Syntax errors
+// are warnings unless strict_j1ql is enabled, as the parser may not know
+// every form of query the API accepts.
+//
+// Attribute validators cannot see the provider configuration, so this is a
+// config validator that reads the settings the resource received in
+// Configure. Queries are not checked when the provider is not configured,
+// such as during `terraform validate`, as the settings are not known then.
+type j1qlValidator struct {
+	settings *j1qlSettings
+	queries  path.Expression
+	// pollingInterval is the attribute of the polling interval of the
+	// queries, for queries that are evaluated on a schedule. Their
+	// include_deleted attribute is read next to the query.
+	pollingInterval path.Path
+	// widget enables the lint checks of dashboard widgets.
+	widget bool
+	// alertRule enables the lint checks of alert rules.
+	alertRule bool
+}
+
+// Description implements resource.ConfigValidator
+func (j1qlValidator) Description(context.Context) string {
+	return "Queries must be valid J1QL"
+}
+
+// MarkdownDescription implements resource.ConfigValidator
+func (v j1qlValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource implements resource.ConfigValidator
+func (v j1qlValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config)...)
+}
+
+// ValidateDataSource implements datasource.ConfigValidator
+func (v j1qlValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config)...)
+}
+
+func (v j1qlValidator) validate(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if v.settings == nil {
+		return diags
+	}
+
+	var pollingInterval types.String
+	if len(v.pollingInterval.Steps()) > 0 {
+		diags.Append(config.GetAttribute(ctx, v.pollingInterval, &pollingInterval)...)
+	}
+
+	paths, d := config.PathMatches(ctx, v.queries)
+	diags.Append(d...)
+
+	for _, p := range paths {
+		var query J1QLQueryValue
+		diags.Append(config.GetAttribute(ctx, p, &query)...)
+		if query.IsNull() || query.IsUnknown() {
+			continue
+		}
+
+		parsed, err := j1ql.Parse(query.ValueString())
+		if err != nil {
+			switch {
+			case v.settings.Validate && v.settings.Strict:
+				diags.AddAttributeError(p, "Invalid J1QL Query", j1qlErrorDetail(query.ValueString(), err))
+			case v.settings.Validate:
+				diags.AddAttributeWarning(p, "Invalid J1QL Query", j1qlErrorDetail(query.ValueString(), err))
+			}
+			continue
+		}
+
+		lint := j1qlLintRequest{
+			path:            p,
+			query:           parsed,
+			widget:          v.widget,
+			alertRule:       v.alertRule,
+			pollingInterval: pollingInterval,
+			includeDeleted:  types.BoolNull(),
+		}
+		if len(v.pollingInterval.Steps()) > 0 {
+			diags.Append(config.GetAttribute(ctx, p.ParentPath().AtName("include_deleted"), &lint.includeDeleted)...)
+		}
+		diags.Append(v.settings.Lint.lint(lint)...)
+	}
+	return diags
+}
+
+// j1qlErrorDetail describes a syntax error of a query, showing the line of
+// the error with a marker under the column.
+func j1qlErrorDetail(query string, err error) string {
+	var syntaxErr *j1ql.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return err.Error()
+	}
+
+	lines := strings.Split(query, "\n")
+	line := strings.TrimRight(lines[syntaxErr.Pos.Line-1], "\r")
+	marker := strings.Repeat(" ", syntaxErr.Pos.Column-1) + "^"
+
+	return fmt.Sprintf("The query has a syntax error at %s: %s.\n\n    %s\n    %s\n\n"+
+		"If the query is valid for the JupiterOne API, set validate_j1ql = false in the provider configuration.",
+		syntaxErr.Pos, syntaxErr.Message, line, marker)
+}
@@ -0,0 +1,148 @@
+package jupiterone
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/j1ql"
+)
+
+// frequentPollingIntervals are the polling intervals that evaluate queries
+// every hour or more often.
+var frequentPollingIntervals = []string{
+	string(client.SchedulerPollingIntervalFifteenMinutes),
+	string(client.SchedulerPollingIntervalThirtyMinutes),
+	string(client.SchedulerPollingIntervalOneHour),
+}
+
+// J1QLLintModel is the `j1ql_lint` block of the provider.
+type J1QLLintModel struct {
+	UnboundedFind         types.Bool `tfsdk:"unbounded_find"`
+	WidgetLimit           types.Bool `tfsdk:"widget_limit"`
+	IncludeDeletedPolling types.Bool `tfsdk:"include_deleted_polling"`
+	ReturnAllProperties   types.Bool `tfsdk:"return_all_properties"`
+}
+
+// j1qlLintSettings are the enabled lint checks.
+type j1qlLintSettings struct {
+	UnboundedFind         bool
+	WidgetLimit           bool
+	IncludeDeletedPolling bool
+	ReturnAllProperties   bool
+}
+
+func j1qlLintBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Lint checks of J1QL queries that are reported as warnings when planning. All checks are enabled by default.",
+		Attributes: map[string]schema.Attribute{
+			"unbounded_find": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Warn about `FIND *` queries without WITH filters or relationships, which match every entity of the account. Defaults to true.",
+			},
+			"widget_limit": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Warn about widget queries without a LIMIT, unless they only return aggregations such as `count(...)`. Defaults to true.",
+			},
+			"include_deleted_polling": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Warn about question and rule queries with `include_deleted` that are evaluated every hour or more often. Defaults to true.",
+			},
+			"return_all_properties": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Warn about rule queries that return all properties with `RETURN *` or `RETURN alias.*`. Defaults to true.",
+			},
+		},
+	}
+}
+
+// settings returns the enabled checks, all checks are enabled unless
+// disabled in the block.
+func (m *J1QLLintModel) settings() j1qlLintSettings {
+	enabled := func(v types.Bool) bool {
+		return v.IsNull() || v.IsUnknown() || v.ValueBool()
+	}
+	if m == nil {
+		m = &J1QLLintModel{}
+	}
+	return j1qlLintSettings{
+		UnboundedFind:         enabled(m.UnboundedFind),
+		WidgetLimit:           enabled(m.WidgetLimit),
+		IncludeDeletedPolling: enabled(m.IncludeDeletedPolling),
+		ReturnAllProperties:   enabled(m.ReturnAllProperties),
+	}
+}
+
+type j1qlLintRequest struct {
+	path            path.Path
+	query           *j1ql.Query
+	widget          bool
+	alertRule       bool
+	pollingInterval types.String
+	includeDeleted  types.Bool
+}
+
+// lint returns a warning for each enabled check the query fails.
+func (s j1qlLintSettings) lint(req j1qlLintRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+	q := req.query
+
+	warn := func(summary, detail, setting string) {
+		diags.AddAttributeWarning(req.path, summary,
+			fmt.Sprintf("%s\n\nThis check can be disabled with %s = false in the j1ql_lint block of the provider configuration.", detail, setting))
+	}
+
+	if s.UnboundedFind && containsString(q.Find.Targets, "*") && len(q.Find.With) == 0 && len(q.Traversals) == 0 {
+		warn("Unbounded J1QL Query",
+			"`FIND *` without WITH filters or relationships matches every entity of the account, "+
+				"which is slow and may time out. Find the entities of a class or type, or filter them with WITH.",
+			"unbounded_find")
+	}
+
+	if s.WidgetLimit && req.widget && q.Limit == nil && !returnsOnlyAggregations(q) {
+		warn("J1QL Query Without LIMIT",
+			"The widget query has no LIMIT, so every matching entity is returned each time the dashboard is loaded. "+
+				"Add a LIMIT, or return aggregations such as `count(...)`.",
+			"widget_limit")
+	}
+
+	if s.IncludeDeletedPolling && req.includeDeleted.ValueBool() && containsString(frequentPollingIntervals, req.pollingInterval.ValueString()) {
+		warn("Deleted Entities Queried Frequently",
+			fmt.Sprintf("The query includes deleted entities and is evaluated with a polling_interval of %s. "+
+				"Deleted entities are kept for a long time, so these queries grow and slow down with every evaluation. "+
+				"Disable include_deleted, or evaluate the query less often.", req.pollingInterval.ValueString()),
+			"include_deleted_polling")
+	}
+
+	if s.ReturnAllProperties && req.alertRule {
+		for _, item := range q.Return {
+			if item.AllProperties() {
+				warn("J1QL Query Returns All Properties",
+					fmt.Sprintf("`RETURN %s` returns all properties of the entities, which are stored with every alert of the rule. "+
+						"Return the properties the rule uses instead.", item.Expression),
+					"return_all_properties")
+				break
+			}
+		}
+	}
+
+	return diags
+}
+
+// returnsOnlyAggregations reports whether every item of the RETURN clause of
+// the query is a function, such as `count(Host)`.
+func returnsOnlyAggregations(q *j1ql.Query) bool {
+	if len(q.Return) == 0 {
+		return false
+	}
+	for _, item := range q.Return {
+		if !strings.Contains(item.Expression, "(") {
+			return false
+		}
+	}
+	return true
+}
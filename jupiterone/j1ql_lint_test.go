@@ -0,0 +1,57 @@
+package jupiterone
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/j1ql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lintSummaries(t *testing.T, settings j1qlLintSettings, req j1qlLintRequest, query string) []string {
+	parsed, err := j1ql.Parse(query)
+	require.NoError(t, err)
+
+	req.path = path.Root("query")
+	req.query = parsed
+
+	var summaries []string
+	for _, d := range settings.lint(req) {
+		summaries = append(summaries, d.Summary())
+	}
+	return summaries
+}
+
+func TestJ1QLLint(t *testing.T) {
+	all := (*J1QLLintModel)(nil).settings()
+	widget := j1qlLintRequest{widget: true}
+	rule := j1qlLintRequest{
+		alertRule:       true,
+		pollingInterval: types.StringValue("THIRTY_MINUTES"),
+		includeDeleted:  types.BoolValue(true),
+	}
+
+	assert.Equal(t, []string{"Unbounded J1QL Query"}, lintSummaries(t, all, j1qlLintRequest{}, "FIND *"))
+	assert.Empty(t, lintSummaries(t, all, j1qlLintRequest{}, "FIND * WITH _class = 'Host'"))
+	assert.Empty(t, lintSummaries(t, all, j1qlLintRequest{}, "FIND * THAT HAS Device"))
+
+	assert.Equal(t, []string{"J1QL Query Without LIMIT"}, lintSummaries(t, all, widget, "FIND Host RETURN Host.name"))
+	assert.Empty(t, lintSummaries(t, all, widget, "FIND Host RETURN Host.name LIMIT 10"))
+	assert.Empty(t, lintSummaries(t, all, widget, "FIND Host AS h RETURN count(h) AS value"))
+	assert.Empty(t, lintSummaries(t, all, j1qlLintRequest{}, "FIND Host RETURN Host.name"), "only widgets need a LIMIT")
+
+	assert.Equal(t, []string{"Deleted Entities Queried Frequently", "J1QL Query Returns All Properties"},
+		lintSummaries(t, all, rule, "FIND Host AS h RETURN h.*"))
+
+	rule.pollingInterval = types.StringValue("ONE_DAY")
+	assert.Equal(t, []string{"J1QL Query Returns All Properties"}, lintSummaries(t, all, rule, "FIND Host RETURN *"))
+
+	disabled := (&J1QLLintModel{
+		UnboundedFind:       types.BoolValue(false),
+		ReturnAllProperties: types.BoolValue(false),
+	}).settings()
+	assert.Empty(t, lintSummaries(t, disabled, rule, "FIND * RETURN *"))
+	assert.True(t, disabled.WidgetLimit, "checks are enabled unless disabled")
+}
@@ -0,0 +1,143 @@
+package jupiterone
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/j1ql"
+)
+
+var (
+	_ basetypes.StringTypable                    = J1QLQueryType{}
+	_ basetypes.StringValuableWithSemanticEquals = J1QLQueryValue{}
+)
+
+// J1QLQueryType is the type of query attributes. Its values are semantically
+// equal when the queries differ only in whitespace, line endings and the case
+// of keywords, verbs, functions and literals, so a query returned by the API in
+// another format than the configuration keeps the configured value in state.
+// Resource attributes also use useStateForEquivalentQuery, as semantic
+// equality is not checked when planning, which makes them computed.
+type J1QLQueryType struct {
+	basetypes.StringType
+}
+
+// Equal implements attr.Type
+func (t J1QLQueryType) Equal(o attr.Type) bool {
+	other, ok := o.(J1QLQueryType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// String implements attr.Type
+func (J1QLQueryType) String() string {
+	return "J1QLQueryType"
+}
+
+// ValueFromString implements basetypes.StringTypable
+func (J1QLQueryType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return J1QLQueryValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform implements attr.Type
+func (t J1QLQueryType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := value.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+
+	valuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return valuable, nil
+}
+
+// ValueType implements attr.Type
+func (J1QLQueryType) ValueType(context.Context) attr.Value {
+	return J1QLQueryValue{}
+}
+
+// J1QLQueryValue is a value of J1QLQueryType.
+type J1QLQueryValue struct {
+	basetypes.StringValue
+}
+
+func NewJ1QLQueryValue(query string) J1QLQueryValue {
+	return J1QLQueryValue{StringValue: basetypes.NewStringValue(query)}
+}
+
+func NewJ1QLQueryNull() J1QLQueryValue {
+	return J1QLQueryValue{StringValue: basetypes.NewStringNull()}
+}
+
+// Equal implements attr.Value
+func (v J1QLQueryValue) Equal(o attr.Value) bool {
+	other, ok := o.(J1QLQueryValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// Type implements attr.Value
+func (J1QLQueryValue) Type(context.Context) attr.Type {
+	return J1QLQueryType{}
+}
+
+// StringSemanticEquals implements basetypes.StringValuableWithSemanticEquals
+func (v J1QLQueryValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(J1QLQueryValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return j1ql.Equivalent(v.ValueString(), newValue.ValueString()), diags
+}
+
+var _ validator.String = configuredQueryValidator{}
+
+// configuredQueryValidator requires a query attribute to be configured. The
+// query attributes of resources are computed, so that useStateForEquivalentQuery
+// may plan the state value, and cannot be marked as required.
+type configuredQueryValidator struct{}
+
+func configuredQuery() validator.String {
+	return configuredQueryValidator{}
+}
+
+// Description implements validator.Describer
+func (configuredQueryValidator) Description(context.Context) string {
+	return "value must be configured"
+}
+
+// MarkdownDescription implements validator.Describer
+func (v configuredQueryValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements validator.String
+func (configuredQueryValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() {
+		resp.Diagnostics.AddAttributeError(req.Path, "Missing Query",
+			"The query must be configured.")
+	}
+}
@@ -0,0 +1,178 @@
+package jupiterone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validateSmartClassQueryConfig configures a smart class query resource with
+// the provider and runs its config validators on the query.
+func validateSmartClassQueryConfig(t *testing.T, p *JupiterOneProvider, query string) diag.Diagnostics {
+	ctx := context.TODO()
+
+	r := NewSmartClassQueryResource().(*SmartClassQueryResource)
+	if p != nil {
+		r.Configure(ctx, resource.ConfigureRequest{ProviderData: p}, &resource.ConfigureResponse{})
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	require.False(t, state.Set(ctx, &SmartClassQuery{
+		Query:        NewJ1QLQueryValue(query),
+		SmartClassId: types.StringValue("smart-class"),
+		Description:  types.StringValue("description"),
+	}).HasError())
+
+	req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}
+	resp := &resource.ValidateConfigResponse{}
+	for _, v := range r.ConfigValidators(ctx) {
+		v.ValidateResource(ctx, req, resp)
+	}
+	return resp.Diagnostics
+}
+
+func TestJ1QLSyntaxValidator(t *testing.T) {
+	p := &JupiterOneProvider{j1ql: j1qlSettings{Validate: true, Strict: true}}
+
+	diags := validateSmartClassQueryConfig(t, p, "FIND Host WITH active = true")
+	assert.False(t, diags.HasError(), "%v", diags)
+
+	diags = validateSmartClassQueryConfig(t, p, "FIND Host\nTHAT HAS WITH active = true")
+	require.Len(t, diags, 1)
+	assert.Equal(t, []path.Path{path.Root("query")}, diagnosticPaths(diags))
+	assert.Equal(t, "Invalid J1QL Query", diags[0].Summary())
+	assert.Contains(t, diags[0].Detail(), `line 2, column 10: expected a class or type, found keyword "WITH"`)
+	assert.Contains(t, diags[0].Detail(), "    THAT HAS WITH active = true\n             ^\n")
+
+	p.j1ql.Strict = false
+	diags = validateSmartClassQueryConfig(t, p, "FIND Host THAT HAS WITH active = true")
+	assert.False(t, diags.HasError(), "syntax errors are warnings unless strict_j1ql is enabled")
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Invalid J1QL Query", diags[0].Summary())
+
+	p.j1ql.Validate = false
+	diags = validateSmartClassQueryConfig(t, p, "FIND Host THAT HAS WITH active = true")
+	assert.False(t, diags.HasError(), "validation is disabled by the provider")
+
+	diags = validateSmartClassQueryConfig(t, nil, "FIND Host THAT HAS WITH active = true")
+	assert.False(t, diags.HasError(), "the provider is not configured")
+}
+
+func TestJ1QLQueryValueSemanticEquals(t *testing.T) {
+	ctx := context.TODO()
+	prior := NewJ1QLQueryValue("find Host\r\n  with active=true")
+
+	equal, diags := prior.StringSemanticEquals(ctx, NewJ1QLQueryValue("FIND Host WITH active = true"))
+	require.False(t, diags.HasError())
+	assert.True(t, equal)
+
+	equal, diags = prior.StringSemanticEquals(ctx, NewJ1QLQueryValue("FIND Host WITH active = false"))
+	require.False(t, diags.HasError())
+	assert.False(t, equal)
+}
+
+func TestUseStateForEquivalentQuery(t *testing.T) {
+	ctx := context.TODO()
+	state := types.StringValue("find Host\r\n  with active=true")
+
+	for _, tc := range []struct {
+		name     string
+		state    types.String
+		config   types.String
+		expected types.String
+	}{
+		{"reformatted", state, types.StringValue("FIND Host WITH active = TRUE"), state},
+		{"changed", state, types.StringValue("FIND Host WITH active = false"), types.StringValue("FIND Host WITH active = false")},
+		{"created", types.StringNull(), types.StringValue("FIND Host"), types.StringValue("FIND Host")},
+	} {
+		req := planmodifier.StringRequest{StateValue: tc.state, ConfigValue: tc.config, PlanValue: tc.config}
+		resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+		useStateForEquivalentQuery().PlanModifyString(ctx, req, resp)
+		assert.Equal(t, tc.expected, resp.PlanValue, tc.name)
+	}
+}
+
+func TestEquivalentQueryPlan(t *testing.T) {
+	ctx := context.TODO()
+
+	schemaResp := &resource.SchemaResponse{}
+	NewSmartClassQueryResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	// The config leaves the computed id and account_id null.
+	value := func(query J1QLQueryValue, id types.String) *tfprotov6.DynamicValue {
+		accountId := types.StringValue("account")
+		if id.IsNull() {
+			accountId = types.StringNull()
+		}
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+		require.False(t, state.Set(ctx, &SmartClassQuery{
+			Id:           id,
+			AccountId:    accountId,
+			Query:        query,
+			SmartClassId: types.StringValue("smart-class"),
+			Description:  types.StringValue("description"),
+		}).HasError())
+		dv, err := tfprotov6.NewDynamicValue(objectType, state.Raw)
+		require.NoError(t, err)
+		return &dv
+	}
+	prior := value(NewJ1QLQueryValue("FIND Host WITH active = true"), types.StringValue("1"))
+
+	server, err := providerserver.NewProtocol6WithError(NewTestProvider(nil)())()
+	require.NoError(t, err)
+	for _, tt := range []struct {
+		name    string
+		query   string
+		planned *tfprotov6.DynamicValue
+	}{
+		{"reformatted", "find Host\n  with active=TRUE", prior},
+		{"changed", "FIND Host WITH active = false", value(NewJ1QLQueryValue("FIND Host WITH active = false"), types.StringValue("1"))},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "jupiterone_smart_class_query",
+				PriorState:       prior,
+				Config:           value(NewJ1QLQueryValue(tt.query), types.StringNull()),
+				ProposedNewState: value(NewJ1QLQueryValue(tt.query), types.StringValue("1")),
+			})
+			require.NoError(t, err)
+			require.Empty(t, resp.Diagnostics)
+
+			planned, err := resp.PlannedState.Unmarshal(objectType)
+			require.NoError(t, err)
+			expected, err := tt.planned.Unmarshal(objectType)
+			require.NoError(t, err)
+			assert.True(t, expected.Equal(planned), "planned %s", planned)
+		})
+	}
+
+	// The query is computed so that the state value may be planned, it
+	// still has to be configured.
+	resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: "jupiterone_smart_class_query",
+		Config:   value(NewJ1QLQueryNull(), types.StringNull()),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Missing Query", resp.Diagnostics[0].Summary)
+}
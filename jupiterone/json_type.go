@@ -0,0 +1,386 @@
+package jupiterone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+)
+
+var (
+	_ basetypes.StringTypable                    = JSONType{}
+	_ xattr.TypeWithValidate                     = JSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = JSONValue{}
+	_ basetypes.ListTypable                      = JSONListType{}
+	_ basetypes.ListValuableWithSemanticEquals   = JSONListValue{}
+)
+
+// JSONType is the type of attributes that hold a JSON document as a string.
+// Its values are semantically equal when the documents decode to the same
+// value, so a document returned by the API with other whitespace or key order
+// than the configuration keeps the configured value in state.
+type JSONType struct {
+	basetypes.StringType
+}
+
+// Equal implements attr.Type
+func (t JSONType) Equal(o attr.Type) bool {
+	other, ok := o.(JSONType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// String implements attr.Type
+func (JSONType) String() string {
+	return "JSONType"
+}
+
+// ValueFromString implements basetypes.StringTypable
+func (JSONType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return JSONValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform implements attr.Type
+func (t JSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := value.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+
+	valuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return valuable, nil
+}
+
+// ValueType implements attr.Type
+func (JSONType) ValueType(context.Context) attr.Value {
+	return JSONValue{}
+}
+
+// Validate implements xattr.TypeWithValidate
+func (JSONType) Validate(_ context.Context, in tftypes.Value, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if in.IsNull() || !in.IsKnown() {
+		return diags
+	}
+
+	var s string
+	if err := in.As(&s); err != nil {
+		diags.AddAttributeError(p, "Invalid JSON", "Expected a string value: "+err.Error())
+		return diags
+	}
+	if _, err := client.ParseJSON(s); err != nil {
+		diags.AddAttributeError(p, "Invalid JSON", fmt.Sprintf("The value is not a valid JSON document: %s", err))
+	}
+	return diags
+}
+
+// JSONValue is a value of JSONType.
+type JSONValue struct {
+	basetypes.StringValue
+}
+
+func NewJSONValue(document string) JSONValue {
+	return JSONValue{StringValue: basetypes.NewStringValue(document)}
+}
+
+func NewJSONNull() JSONValue {
+	return JSONValue{StringValue: basetypes.NewStringNull()}
+}
+
+// NewJSONValueFrom returns the document of a JSON value of the API, or a null
+// value when it is JSON null.
+func NewJSONValueFrom(j client.JSON) JSONValue {
+	if j.IsNull() {
+		return NewJSONNull()
+	}
+	return NewJSONValue(j.String())
+}
+
+// Equal implements attr.Value
+func (v JSONValue) Equal(o attr.Value) bool {
+	other, ok := o.(JSONValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// Type implements attr.Value
+func (JSONValue) Type(context.Context) attr.Type {
+	return JSONType{}
+}
+
+// JSON returns the document as a JSON value of the API. A null value is JSON
+// null.
+func (v JSONValue) JSON() (client.JSON, error) {
+	if v.IsNull() || v.IsUnknown() {
+		return nil, nil
+	}
+	return client.ParseJSON(v.ValueString())
+}
+
+// StringSemanticEquals implements basetypes.StringValuableWithSemanticEquals
+func (v JSONValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(JSONValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	prior, ok := decodeJSON(v.ValueString())
+	if !ok {
+		return false, diags
+	}
+	proposed, ok := decodeJSON(newValue.ValueString())
+	if !ok {
+		return false, diags
+	}
+	return reflect.DeepEqual(prior, proposed), diags
+}
+
+func decodeJSON(s string) (interface{}, bool) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// JSONListType is the type of lists of JSON objects, such as the actions of a
+// rule, whose elements are given an `id` by the API. Its values are
+// semantically equal when the elements can be matched up regardless of their
+// order: elements that both have an `id` match when they are equal, other
+// elements match when they are equal apart from the `id`.
+type JSONListType struct {
+	basetypes.ListType
+}
+
+func NewJSONListType() JSONListType {
+	return JSONListType{ListType: basetypes.ListType{ElemType: JSONType{}}}
+}
+
+// Equal implements attr.Type
+func (t JSONListType) Equal(o attr.Type) bool {
+	other, ok := o.(JSONListType)
+	if !ok {
+		return false
+	}
+	return t.ListType.Equal(other.ListType)
+}
+
+// String implements attr.Type
+func (JSONListType) String() string {
+	return "JSONListType"
+}
+
+// ValueFromList implements basetypes.ListTypable
+func (JSONListType) ValueFromList(_ context.Context, in basetypes.ListValue) (basetypes.ListValuable, diag.Diagnostics) {
+	return JSONListValue{ListValue: in}, nil
+}
+
+// ValueFromTerraform implements attr.Type
+func (t JSONListType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.ListType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	listValue, ok := value.(basetypes.ListValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+
+	valuable, diags := t.ValueFromList(ctx, listValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting ListValue to ListValuable: %v", diags)
+	}
+	return valuable, nil
+}
+
+// ValueType implements attr.Type
+func (JSONListType) ValueType(context.Context) attr.Value {
+	return JSONListValue{}
+}
+
+// JSONListValue is a value of JSONListType.
+type JSONListValue struct {
+	basetypes.ListValue
+}
+
+func NewJSONListNull() JSONListValue {
+	return JSONListValue{ListValue: basetypes.NewListNull(JSONType{})}
+}
+
+// NewJSONListValueFrom returns the documents of JSON values of the API.
+func NewJSONListValueFrom(values []client.JSON) JSONListValue {
+	elements := make([]attr.Value, len(values))
+	for i, v := range values {
+		elements[i] = NewJSONValueFrom(v)
+	}
+	return JSONListValue{ListValue: basetypes.NewListValueMust(JSONType{}, elements)}
+}
+
+// Equal implements attr.Value
+func (v JSONListValue) Equal(o attr.Value) bool {
+	other, ok := o.(JSONListValue)
+	if !ok {
+		return false
+	}
+	return v.ListValue.Equal(other.ListValue)
+}
+
+// Type implements attr.Value
+func (JSONListValue) Type(context.Context) attr.Type {
+	return NewJSONListType()
+}
+
+// JSON returns the documents as JSON values of the API.
+func (v JSONListValue) JSON() ([]client.JSON, error) {
+	var values []client.JSON
+	for _, e := range v.Elements() {
+		element, ok := e.(JSONValue)
+		if !ok {
+			return nil, fmt.Errorf("unexpected element type of %T", e)
+		}
+		j, err := element.JSON()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, j)
+	}
+	return values, nil
+}
+
+// ListSemanticEquals implements basetypes.ListValuableWithSemanticEquals
+func (v JSONListValue) ListSemanticEquals(_ context.Context, newValuable basetypes.ListValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(JSONListValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	if v.IsNull() || v.IsUnknown() || newValue.IsNull() || newValue.IsUnknown() {
+		return false, diags
+	}
+
+	prior, ok := decodeJSONElements(v.Elements())
+	if !ok {
+		return false, diags
+	}
+	proposed, ok := decodeJSONElements(newValue.Elements())
+	if !ok {
+		return false, diags
+	}
+	return jsonElementsMatch(prior, proposed), diags
+}
+
+func decodeJSONElements(elements []attr.Value) ([]interface{}, bool) {
+	decoded := make([]interface{}, len(elements))
+	for i, e := range elements {
+		s, ok := e.(JSONValue)
+		if !ok || s.IsNull() || s.IsUnknown() {
+			return nil, false
+		}
+		if decoded[i], ok = decodeJSON(s.ValueString()); !ok {
+			return nil, false
+		}
+	}
+	return decoded, true
+}
+
+// jsonElementsMatch reports whether every element of a can be paired with an
+// element of b. Elements that both have an `id` are paired by it first, the
+// remaining elements are paired when they are equal apart from the `id`.
+func jsonElementsMatch(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	matched := make([]bool, len(b))
+	var unmatched []interface{}
+	for _, x := range a {
+		i := -1
+		if id, ok := jsonObjectID(x); ok {
+			for j, y := range b {
+				if other, ok := jsonObjectID(y); ok && !matched[j] && reflect.DeepEqual(id, other) {
+					i = j
+					break
+				}
+			}
+		}
+		if i < 0 {
+			unmatched = append(unmatched, x)
+			continue
+		}
+		if !reflect.DeepEqual(x, b[i]) {
+			return false
+		}
+		matched[i] = true
+	}
+
+	for _, x := range unmatched {
+		i := -1
+		for j, y := range b {
+			if !matched[j] && reflect.DeepEqual(withoutJSONID(x), withoutJSONID(y)) {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			return false
+		}
+		matched[i] = true
+	}
+	return true
+}
+
+func jsonObjectID(v interface{}) (interface{}, bool) {
+	object, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	id, ok := object["id"]
+	return id, ok
+}
+
+func withoutJSONID(v interface{}) interface{} {
+	object, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	without := make(map[string]interface{}, len(object))
+	for k, e := range object {
+		if k != "id" {
+			without[k] = e
+		}
+	}
+	return without
+}
@@ -0,0 +1,113 @@
+package jupiterone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONValueSemanticEquals(t *testing.T) {
+	ctx := context.TODO()
+	prior := NewJSONValue("{\n  \"b\": [1, 2],\n  \"a\": true\n}")
+
+	equal, diags := prior.StringSemanticEquals(ctx, NewJSONValue(`{"a":true,"b":[1,2]}`))
+	require.False(t, diags.HasError())
+	assert.True(t, equal, "whitespace and key order are ignored")
+
+	equal, diags = prior.StringSemanticEquals(ctx, NewJSONValue(`{"a":true,"b":[2,1]}`))
+	require.False(t, diags.HasError())
+	assert.False(t, equal, "the order of arrays is kept")
+
+	equal, diags = NewJSONValue(`{`).StringSemanticEquals(ctx, NewJSONValue(`{`))
+	require.False(t, diags.HasError())
+	assert.False(t, equal, "invalid JSON is never equal")
+}
+
+func TestJSONTypeValidate(t *testing.T) {
+	ctx := context.TODO()
+	p := path.Root("config")
+
+	assert.False(t, JSONType{}.Validate(ctx, tftypes.NewValue(tftypes.String, `{"a":1}`), p).HasError())
+	assert.False(t, JSONType{}.Validate(ctx, tftypes.NewValue(tftypes.String, nil), p).HasError())
+	assert.False(t, JSONType{}.Validate(ctx, tftypes.NewValue(tftypes.String, tftypes.UnknownValue), p).HasError())
+
+	diags := JSONType{}.Validate(ctx, tftypes.NewValue(tftypes.String, `{"a":`), p)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Invalid JSON", diags[0].Summary())
+}
+
+func jsonList(documents ...string) JSONListValue {
+	elements := make([]attr.Value, len(documents))
+	for i, d := range documents {
+		elements[i] = NewJSONValue(d)
+	}
+	return JSONListValue{ListValue: basetypes.NewListValueMust(JSONType{}, elements)}
+}
+
+func TestJSONListValueSemanticEquals(t *testing.T) {
+	ctx := context.TODO()
+
+	testCases := []struct {
+		name     string
+		prior    JSONListValue
+		proposed JSONListValue
+		equal    bool
+	}{
+		{
+			name:     "reordered_by_id",
+			prior:    jsonList(`{"id":"1","type":"SET_PROPERTY"}`, `{"id":"2","type":"CREATE_ALERT"}`),
+			proposed: jsonList(`{"type":"CREATE_ALERT","id":"2"}`, `{"id":"1","type":"SET_PROPERTY"}`),
+			equal:    true,
+		},
+		{
+			name:     "same_id_other_content",
+			prior:    jsonList(`{"id":"1","type":"SET_PROPERTY"}`),
+			proposed: jsonList(`{"id":"1","type":"CREATE_ALERT"}`),
+			equal:    false,
+		},
+		{
+			name:     "ids_added_by_the_api",
+			prior:    jsonList(`{"type":"SET_PROPERTY"}`, `{"type":"CREATE_ALERT"}`),
+			proposed: jsonList(`{"id":"2","type":"CREATE_ALERT"}`, `{"id":"1","type":"SET_PROPERTY"}`),
+			equal:    true,
+		},
+		{
+			name:     "ids_replaced_by_the_api",
+			prior:    jsonList(`{"id":"a","type":"SET_PROPERTY"}`),
+			proposed: jsonList(`{"id":"b","type":"SET_PROPERTY"}`),
+			equal:    true,
+		},
+		{
+			name:     "duplicates",
+			prior:    jsonList(`{"type":"SET_PROPERTY"}`, `{"type":"SET_PROPERTY"}`),
+			proposed: jsonList(`{"type":"SET_PROPERTY"}`, `{"type":"CREATE_ALERT"}`),
+			equal:    false,
+		},
+		{
+			name:     "element_removed",
+			prior:    jsonList(`{"type":"SET_PROPERTY"}`, `{"type":"CREATE_ALERT"}`),
+			proposed: jsonList(`{"type":"SET_PROPERTY"}`),
+			equal:    false,
+		},
+		{
+			name:     "null",
+			prior:    NewJSONListNull(),
+			proposed: jsonList(),
+			equal:    false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, diags := tt.prior.ListSemanticEquals(ctx, tt.proposed)
+			require.False(t, diags.HasError())
+			assert.Equal(t, tt.equal, equal)
+		})
+	}
+}
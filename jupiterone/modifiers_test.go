@@ -5,9 +5,15 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestJsonIgnoreDiffModifierList(t *testing.T) {
@@ -15,6 +21,15 @@ func TestJsonIgnoreDiffModifierList(t *testing.T) {
 	// the the resource_framework_test.go.
 	ctx := context.TODO()
 
+	jsonList := func(elements ...string) types.List {
+		values := make([]attr.Value, len(elements))
+		for i, e := range elements {
+			values[i] = NewJSONValue(e)
+		}
+		return types.ListValueMust(JSONType{}, values)
+	}
+	configured := jsonList(`{"type":"SEND_EMAIL","recipients":["a@example.com"]}`, `{"type": "CREATE_ALERT"}`)
+
 	testCases := []struct {
 		name         string
 		configValue  types.List
@@ -43,6 +58,27 @@ func TestJsonIgnoreDiffModifierList(t *testing.T) {
 			stateValue:   types.ListValueMust(types.StringType, []attr.Value{}),
 			expectedPlan: types.ListValueMust(types.StringType, []attr.Value{}),
 		},
+		{
+			name:         "ids_and_order_in_state",
+			configValue:  configured,
+			planValue:    configured,
+			stateValue:   jsonList(`{"id":"2","type":"CREATE_ALERT"}`, `{"recipients":["a@example.com"],"id":"1","type":"SEND_EMAIL"}`),
+			expectedPlan: jsonList(`{"id":"2","type":"CREATE_ALERT"}`, `{"recipients":["a@example.com"],"id":"1","type":"SEND_EMAIL"}`),
+		},
+		{
+			name:         "changed",
+			configValue:  configured,
+			planValue:    configured,
+			stateValue:   jsonList(`{"id":"1","type":"SEND_EMAIL","recipients":["b@example.com"]}`, `{"id":"2","type":"CREATE_ALERT"}`),
+			expectedPlan: configured,
+		},
+		{
+			name:         "removed",
+			configValue:  configured,
+			planValue:    configured,
+			stateValue:   jsonList(`{"type":"CREATE_ALERT"}`),
+			expectedPlan: configured,
+		},
 	}
 
 	for _, tt := range testCases {
@@ -64,5 +100,72 @@ func TestJsonIgnoreDiffModifierList(t *testing.T) {
 			assert.Equal(t, tt.expectedPlan, resp.PlanValue)
 		})
 	}
+}
+
+func TestJsonIgnoreDiffPlan(t *testing.T) {
+	// A framework imported or written by an older version of the provider
+	// has the ids and formatting of the API in state.
+	ctx := context.TODO()
+
+	schemaResp := &resource.SchemaResponse{}
+	NewFrameworkResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	value := func(model *ComplianceFrameworkModel) *tfprotov6.DynamicValue {
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+		require.False(t, state.Set(ctx, model).HasError())
+		dv, err := tfprotov6.NewDynamicValue(objectType, state.Raw)
+		require.NoError(t, err)
+		return &dv
+	}
+	framework := func(id string, filters ...string) *ComplianceFrameworkModel {
+		elements := make([]attr.Value, len(filters))
+		for i, f := range filters {
+			elements[i] = NewJSONValue(f)
+		}
+		return &ComplianceFrameworkModel{
+			Id:            types.StringValue(id),
+			AccountId:     types.StringValue("account"),
+			Name:          types.StringValue("framework"),
+			Version:       types.StringValue("v1"),
+			FrameworkType: types.StringValue("STANDARD"),
+			WebLink:       types.StringNull(),
+			ScopeFilters:  JSONListValue{ListValue: types.ListValueMust(JSONType{}, elements)},
+		}
+	}
+
+	prior := value(framework("1", `{"id":"a","key":"tag.Production","values":[true]}`))
 
+	server, err := providerserver.NewProtocol6WithError(NewTestProvider(nil)())()
+	require.NoError(t, err)
+	for _, tt := range []struct {
+		name    string
+		filter  string
+		planned *tfprotov6.DynamicValue
+	}{
+		{"formatted", `{ "values": [true], "key": "tag.Production" }`, prior},
+		{"changed", `{"key":"tag.Production","values":[false]}`, value(framework("1", `{"key":"tag.Production","values":[false]}`))},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			config := framework("", tt.filter)
+			config.Id = types.StringNull()
+			config.AccountId = types.StringNull()
+
+			resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "jupiterone_framework",
+				PriorState:       prior,
+				Config:           value(config),
+				ProposedNewState: value(framework("1", tt.filter)),
+			})
+			require.NoError(t, err)
+			require.Empty(t, resp.Diagnostics)
+
+			planned, err := resp.PlannedState.Unmarshal(objectType)
+			require.NoError(t, err)
+			expected, err := tt.planned.Unmarshal(objectType)
+			require.NoError(t, err)
+			assert.True(t, expected.Equal(planned), "planned %s", planned)
+		})
+	}
 }
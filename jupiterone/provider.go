@@ -4,10 +4,16 @@ import (
 	// "errors"
 	"context"
 	"log"
+	"net/url"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,15 +28,34 @@ type JupiterOneProvider struct {
 	// testing.
 	version string
 	Qlient  graphql.Client
+
+	j1ql j1qlSettings
 }
 
 type JupiterOneProviderModel struct {
-	APIKey    basetypes.StringValue `tfsdk:"api_key"`
-	AccountID basetypes.StringValue `tfsdk:"account_id"`
-	Region    basetypes.StringValue `tfsdk:"region"`
+	APIKey           basetypes.StringValue `tfsdk:"api_key"`
+	AccountID        basetypes.StringValue `tfsdk:"account_id"`
+	Region           basetypes.StringValue `tfsdk:"region"`
+	ClientID         basetypes.StringValue `tfsdk:"client_id"`
+	ClientSecret     basetypes.StringValue `tfsdk:"client_secret"`
+	OAuthTokenURL    basetypes.StringValue `tfsdk:"oauth_token_url"`
+	APIKeyCommand    basetypes.StringValue `tfsdk:"api_key_command"`
+	Profile          basetypes.StringValue `tfsdk:"profile"`
+	Endpoint         basetypes.StringValue `tfsdk:"endpoint"`
+	HTTPProxy        basetypes.StringValue `tfsdk:"http_proxy"`
+	CACertFile       basetypes.StringValue `tfsdk:"ca_cert_file"`
+	ClientCertFile   basetypes.StringValue `tfsdk:"client_cert_file"`
+	ClientKeyFile    basetypes.StringValue `tfsdk:"client_key_file"`
+	RetryMaxAttempts basetypes.Int64Value  `tfsdk:"retry_max_attempts"`
+	RetryMinBackoff  basetypes.StringValue `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff  basetypes.StringValue `tfsdk:"retry_max_backoff"`
+	ValidateJ1QL     basetypes.BoolValue   `tfsdk:"validate_j1ql"`
+	StrictJ1QL       basetypes.BoolValue   `tfsdk:"strict_j1ql"`
+	J1QLLint         *J1QLLintModel        `tfsdk:"j1ql_lint"`
 }
 
 var _ provider.Provider = &JupiterOneProvider{}
+var _ provider.ProviderWithFunctions = &JupiterOneProvider{}
 
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
@@ -49,80 +74,417 @@ func (p *JupiterOneProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
+	var diags diag.Diagnostics
+	p.j1ql, diags = data.j1qlSettings()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// NOTE: One important use case here is client already being set at part
 	// of the acceptance tests to use the preconfigured `go-vcr` transport.
 	if p.Qlient == nil {
-		apiKey := data.APIKey.ValueString()
-		accountId := data.AccountID.ValueString()
-		region := data.Region.ValueString()
+		config, diags := data.clientConfig(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		qlient, err := config.Qlient(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to Initialize JupiterOne Client",
+				"While configuring the provider, the HTTP client could not be "+
+					"created: "+err.Error(),
+			)
+			return
+		}
+
+		p.Qlient = client.NewAccountQlients(config.AccountID, qlient, &config)
+		log.Println("[INFO] JupiterOne client successfully initialized")
+	} else {
+		log.Println("[INFO] Using already configured client")
+
+		if _, ok := p.Qlient.(*client.AccountQlients); !ok {
+			accountId := data.AccountID.ValueString()
+			if accountId == "" {
+				accountId = os.Getenv("JUPITERONE_ACCOUNT_ID")
+			}
+			p.Qlient = client.NewAccountQlients(accountId, p.Qlient, nil)
+		}
+	}
+
+	resp.DataSourceData = p
+	resp.ResourceData = p
+}
+
+// clientConfig merges the provider configuration with the environment
+// variables and the shared config file profile. For each setting the provider
+// configuration block takes precedence over the environment, which takes
+// precedence over the profile.
+func (data *JupiterOneProviderModel) clientConfig(ctx context.Context) (client.JupiterOneClientConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	apiKey := data.APIKey.ValueString()
+	accountId := data.AccountID.ValueString()
+	region := data.Region.ValueString()
+	clientId := data.ClientID.ValueString()
+	clientSecret := data.ClientSecret.ValueString()
+	tokenURL := data.OAuthTokenURL.ValueString()
+	apiKeyCommand := data.APIKeyCommand.ValueString()
+	endpoint := data.Endpoint.ValueString()
+	httpProxy := data.HTTPProxy.ValueString()
+	caCertFile := data.CACertFile.ValueString()
+	clientCertFile := data.ClientCertFile.ValueString()
+	clientKeyFile := data.ClientKeyFile.ValueString()
+
+	// Check environment variables. Performing this as part of Configure is
+	// the current de-facto way of "merging" defaults:
+	// https://github.com/hashicorp/terraform-plugin-framework/issues/539#issuecomment-1334470425
+	// An api_key from the configuration takes precedence over a credential
+	// helper from the environment.
+	if apiKeyCommand == "" && apiKey == "" {
+		apiKeyCommand = os.Getenv("JUPITERONE_API_KEY_COMMAND")
+	}
+	if accountId == "" {
+		accountId = os.Getenv("JUPITERONE_ACCOUNT_ID")
+	}
+
+	// The credential helper takes the place of the JUPITERONE_API_KEY
+	// environment variable, but conflicts with an api_key in the same
+	// configuration block.
+	if apiKeyCommand != "" {
+		if apiKey != "" {
+			diags.AddError(
+				"Conflicting API key Configuration",
+				"While configuring the provider, both the api_key and "+
+					"api_key_command attributes were set. Configure only one of them.",
+			)
+			return client.JupiterOneClientConfig{}, diags
+		}
 
-		// Check environment variables. Performing this as part of Configure is
-		// the current de-facto way of "merging" defaults:
-		// https://github.com/hashicorp/terraform-plugin-framework/issues/539#issuecomment-1334470425
-		if apiKey == "" {
-			apiKey = os.Getenv("JUPITERONE_API_KEY")
+		creds, err := client.RunCredentialCommand(ctx, apiKeyCommand)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("api_key_command"),
+				"Failed to Run API key Command",
+				"While configuring the provider, the api_key_command failed: "+err.Error(),
+			)
+			return client.JupiterOneClientConfig{}, diags
 		}
+
+		apiKey = creds.APIKey
 		if accountId == "" {
-			accountId = os.Getenv("JUPITERONE_ACCOUNT_ID")
+			accountId = creds.AccountID
+		}
+	}
+
+	if apiKey == "" {
+		apiKey = os.Getenv("JUPITERONE_API_KEY")
+	}
+	if region == "" {
+		region = os.Getenv("JUPITERONE_REGION")
+	}
+	if clientId == "" {
+		clientId = os.Getenv("JUPITERONE_CLIENT_ID")
+	}
+	if clientSecret == "" {
+		clientSecret = os.Getenv("JUPITERONE_CLIENT_SECRET")
+	}
+	if tokenURL == "" {
+		tokenURL = os.Getenv("JUPITERONE_OAUTH_TOKEN_URL")
+	}
+	if endpoint == "" {
+		endpoint = os.Getenv("JUPITERONE_ENDPOINT")
+	}
+	if httpProxy == "" {
+		httpProxy = os.Getenv("JUPITERONE_HTTP_PROXY")
+	}
+	if caCertFile == "" {
+		caCertFile = os.Getenv("JUPITERONE_CA_CERT_FILE")
+	}
+	if clientCertFile == "" {
+		clientCertFile = os.Getenv("JUPITERONE_CLIENT_CERT_FILE")
+	}
+	if clientKeyFile == "" {
+		clientKeyFile = os.Getenv("JUPITERONE_CLIENT_KEY_FILE")
+	}
+
+	// Anything still unset falls back to the selected profile in the
+	// shared config file, which has the lowest precedence.
+	profile, profileDiags := loadProviderProfile(data.Profile.ValueString())
+	diags.Append(profileDiags...)
+	if diags.HasError() {
+		return client.JupiterOneClientConfig{}, diags
+	}
+	if profile != nil {
+		if apiKey == "" && apiKeyCommand == "" && clientId == "" && clientSecret == "" {
+			apiKey = profile.APIKey
+		}
+		if accountId == "" {
+			accountId = profile.AccountID
 		}
 		if region == "" {
-			region = os.Getenv("JUPITERONE_REGION")
+			region = profile.Region
 		}
+		if endpoint == "" {
+			endpoint = profile.Endpoint
+		}
+	}
 
-		if apiKey == "" {
-			resp.Diagnostics.AddError(
-				"Missing API key Configuration",
-				"While configuring the provider, the API key was not found in "+
-					"the JUPITERONE_API_KEY environment variable or provider "+
-					"configuration block api_key attribute.",
+	usesClientCredentials := clientId != "" || clientSecret != ""
+
+	switch {
+	case usesClientCredentials && apiKey != "":
+		diags.AddError(
+			"Conflicting Authentication Configuration",
+			"While configuring the provider, both an API key and OAuth "+
+				"client credentials were found. Configure either api_key "+
+				"or client_id and client_secret, not both.",
+		)
+	case usesClientCredentials && (clientId == "" || clientSecret == ""):
+		diags.AddError(
+			"Incomplete OAuth Client Credentials Configuration",
+			"While configuring the provider, only one of the client id and "+
+				"client secret was found. Both must be set, either in the "+
+				"JUPITERONE_CLIENT_ID and JUPITERONE_CLIENT_SECRET environment "+
+				"variables or the provider configuration block client_id and "+
+				"client_secret attributes.",
+		)
+	case !usesClientCredentials && apiKey == "":
+		diags.AddError(
+			"Missing API key Configuration",
+			"While configuring the provider, the API key was not found in "+
+				"the JUPITERONE_API_KEY environment variable or provider "+
+				"configuration block api_key attribute, and no OAuth client "+
+				"credentials were configured.",
+		)
+		// Not returning early allows the logic to collect all errors.
+	}
+
+	if accountId == "" {
+		diags.AddError(
+			"Missing Account ID Configuration",
+			"While configuring the provider, the account id was not found in "+
+				"the JUPITERONE_ACCOUNT_ID variable, provider "+
+				"configuration block account_id attribute or selected profile.",
+		)
+		// Not returning early allows the logic to collect all errors.
+	}
+
+	if region == "" && endpoint == "" {
+		diags.AddError(
+			"Missing region Configuration",
+			"While configuring the provider, the region was not found in "+
+				"the JUPITERONE_REGION variable, provider "+
+				"configuration block region attribute or selected profile.",
+		)
+		// Not returning early allows the logic to collect all errors.
+	}
+
+	if endpoint != "" {
+		if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			diags.AddAttributeError(
+				path.Root("endpoint"),
+				"Invalid Endpoint Configuration",
+				"While configuring the provider, the endpoint must be an absolute "+
+					"http or https URL, got: "+endpoint,
 			)
-			// Not returning early allows the logic to collect all errors.
 		}
+	}
 
-		if accountId == "" {
-			resp.Diagnostics.AddError(
-				"Missing Account ID Configuration",
-				"While configuring the provider, the account id was not found in "+
-					"the JUPITERONE_ACCOUNT_ID variable or provider "+
-					"configuration block account_id attribute.",
+	if httpProxy != "" {
+		if u, err := url.Parse(httpProxy); err != nil || u.Scheme == "" || u.Host == "" {
+			diags.AddAttributeError(
+				path.Root("http_proxy"),
+				"Invalid HTTP Proxy Configuration",
+				"While configuring the provider, the http_proxy must be an absolute "+
+					"URL such as http://proxy.example.com:3128, got: "+httpProxy,
 			)
-			// Not returning early allows the logic to collect all errors.
 		}
+	}
 
-		if region == "" {
-			resp.Diagnostics.AddError(
-				"Missing region Configuration",
-				"While configuring the provider, the region was not found in "+
-					"the JUPITERONE_REGION variable or provider "+
-					"configuration block region attribute.",
+	if (clientCertFile == "") != (clientKeyFile == "") {
+		diags.AddError(
+			"Incomplete Client Certificate Configuration",
+			"While configuring the provider, both client_cert_file and "+
+				"client_key_file must be set to use a client certificate.",
+		)
+	}
+
+	retry, retryDiags := data.retryConfig()
+	diags.Append(retryDiags...)
+
+	return client.JupiterOneClientConfig{
+		APIKey:         apiKey,
+		AccountID:      accountId,
+		Region:         region,
+		Endpoint:       endpoint,
+		ClientID:       clientId,
+		ClientSecret:   clientSecret,
+		TokenURL:       tokenURL,
+		APIKeyCommand:  apiKeyCommand,
+		ProxyURL:       httpProxy,
+		CACertFile:     caCertFile,
+		ClientCertFile: clientCertFile,
+		ClientKeyFile:  clientKeyFile,
+		MaxAttempts:    retry.MaxAttempts,
+		MinBackoff:     retry.MinBackoff,
+		MaxBackoff:     retry.MaxBackoff,
+	}, diags
+}
+
+// retryConfig reads the retry settings from the provider configuration or
+// the environment. Unset settings are left zero so the client defaults apply.
+func (data *JupiterOneProviderModel) retryConfig() (client.JupiterOneClientConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var config client.JupiterOneClientConfig
+
+	if !data.RetryMaxAttempts.IsNull() {
+		config.MaxAttempts = int(data.RetryMaxAttempts.ValueInt64())
+		if config.MaxAttempts < 1 {
+			diags.AddAttributeError(
+				path.Root("retry_max_attempts"),
+				"Invalid Retry Configuration",
+				"While configuring the provider, retry_max_attempts must be at least 1.",
 			)
-			// Not returning early allows the logic to collect all errors.
 		}
+	} else if v := os.Getenv("JUPITERONE_RETRY_MAX_ATTEMPTS"); v != "" {
+		attempts, err := strconv.Atoi(v)
+		if err != nil || attempts < 1 {
+			diags.AddError(
+				"Invalid Retry Configuration",
+				"While configuring the provider, JUPITERONE_RETRY_MAX_ATTEMPTS must be a number of at least 1, got: "+v,
+			)
+		}
+		config.MaxAttempts = attempts
+	}
 
-		config := client.JupiterOneClientConfig{
-			APIKey:    apiKey,
-			AccountID: accountId,
-			Region:    region,
+	durations := []struct {
+		name   string
+		envVar string
+		value  basetypes.StringValue
+		target *time.Duration
+	}{
+		{"retry_min_backoff", "JUPITERONE_RETRY_MIN_BACKOFF", data.RetryMinBackoff, &config.MinBackoff},
+		{"retry_max_backoff", "JUPITERONE_RETRY_MAX_BACKOFF", data.RetryMaxBackoff, &config.MaxBackoff},
+	}
+	for _, d := range durations {
+		v := d.value.ValueString()
+		if v == "" {
+			v = os.Getenv(d.envVar)
+		}
+		if v == "" {
+			continue
 		}
 
-		p.Qlient = config.Qlient(ctx)
-		log.Println("[INFO] JupiterOne client successfully initialized")
-	} else {
-		log.Println("[INFO] Using already configured client")
+		duration, err := time.ParseDuration(v)
+		if err != nil || duration <= 0 {
+			diags.AddAttributeError(
+				path.Root(d.name),
+				"Invalid Retry Configuration",
+				"While configuring the provider, "+d.name+" must be a positive duration such as 15s, got: "+v,
+			)
+			continue
+		}
+		*d.target = duration
 	}
 
-	resp.DataSourceData = p
-	resp.ResourceData = p
+	minBackoff, maxBackoff := config.MinBackoff, config.MaxBackoff
+	if minBackoff == 0 {
+		minBackoff = client.MinBackoff
+	}
+	if maxBackoff == 0 {
+		maxBackoff = client.MaxBackoff
+	}
+	if minBackoff > maxBackoff {
+		diags.AddError(
+			"Invalid Retry Configuration",
+			"While configuring the provider, retry_min_backoff ("+minBackoff.String()+
+				") must not be greater than retry_max_backoff ("+maxBackoff.String()+").",
+		)
+	}
+
+	return config, diags
+}
+
+// j1qlSettings reads the J1QL settings from the provider configuration or
+// the environment.
+func (data *JupiterOneProviderModel) j1qlSettings() (j1qlSettings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	settings := j1qlSettings{Lint: data.J1QLLint.settings()}
+	settings.Validate = j1qlBoolSetting(data.ValidateJ1QL, "JUPITERONE_VALIDATE_J1QL", true, &diags)
+	settings.Strict = j1qlBoolSetting(data.StrictJ1QL, "JUPITERONE_STRICT_J1QL", false, &diags)
+
+	return settings, diags
+}
+
+// j1qlBoolSetting returns the value of a boolean J1QL setting, from the
+// provider configuration, the environment variable or the default.
+func j1qlBoolSetting(value basetypes.BoolValue, env string, defaultValue bool, diags *diag.Diagnostics) bool {
+	if !value.IsNull() {
+		return value.ValueBool()
+	}
+	v := os.Getenv(env)
+	if v == "" {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		diags.AddError(
+			"Invalid J1QL Configuration",
+			"While configuring the provider, "+env+" must be true or false, got: "+v,
+		)
+	}
+	return b
+}
+
+// loadProviderProfile loads the profile named by the `profile` attribute or
+// the JUPITERONE_PROFILE environment variable from the shared config file.
+func loadProviderProfile(name string) (*client.Profile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if name == "" {
+		name = os.Getenv("JUPITERONE_PROFILE")
+	}
+
+	configPath, err := client.DefaultConfigFilePath()
+	if err != nil {
+		if name == "" {
+			return nil, diags
+		}
+		diags.AddAttributeError(path.Root("profile"), "Failed to Locate Config File", err.Error())
+		return nil, diags
+	}
+
+	profile, err := client.LoadProfile(configPath, name)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Failed to Load Profile",
+			"While configuring the provider, the profile could not be loaded: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	if profile != nil {
+		log.Printf("[INFO] Using profile %q from %s", profile.Name, configPath)
+	}
+
+	return profile, diags
 }
 
 // DataSources implements provider.Provider
 func (*JupiterOneProvider) DataSources(context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewUserGroupDataSource,
+		NewUserDataSource,
+		NewUsersDataSource,
 		NewResourceGroupDataSource,
 		NewJ1QLResultDataSource,
 		NewIntegrationExternalIdDataSource,
 		NewCustomIntegrationDefinitionDataSource,
+		NewQuestionRuleStatusDataSource,
 	}
 }
 
@@ -143,6 +505,7 @@ func (*JupiterOneProvider) Resources(context.Context) []func() resource.Resource
 		NewLibraryItemResource,
 		NewUserGroupResource,
 		NewUserGroupMembershipResource,
+		NewUserGroupMembersResource,
 		NewDashboardResource,
 		NewWidgetResource,
 		NewDashboardParameterResource,
@@ -163,6 +526,15 @@ func (*JupiterOneProvider) Resources(context.Context) []func() resource.Resource
 	}
 }
 
+// Functions implements provider.ProviderWithFunctions
+func (*JupiterOneProvider) Functions(context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewJ1QLQuoteFunction,
+		NewJ1QLInListFunction,
+		NewJ1QLNormalizeFunction,
+	}
+}
+
 // Schema implements provider.Provider
 func (*JupiterOneProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
@@ -182,6 +554,70 @@ func (*JupiterOneProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:    true,
 				Description: "region used for generating the GraphQL endpoint url. If not provided defaults to 'us'",
 			},
+			"client_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "OAuth client ID used with `client_secret` to request short-lived access tokens instead of using an API key. Can also be set with the JUPITERONE_CLIENT_ID environment variable.",
+			},
+			"client_secret": schema.StringAttribute{
+				Optional:    true,
+				Description: "OAuth client secret used with `client_id`. Can also be set with the JUPITERONE_CLIENT_SECRET environment variable.",
+				Sensitive:   true,
+			},
+			"oauth_token_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the OAuth token endpoint used with client credentials. Defaults to the token endpoint for the configured region. Can also be set with the JUPITERONE_OAUTH_TOKEN_URL environment variable.",
+			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the profile in the shared config file (`~/.jupiterone/config`, or the JUPITERONE_CONFIG_FILE environment variable) to read `api_key`, `account_id`, `region` and `endpoint` from. Defaults to the `default` profile when the file exists. Can also be set with the JUPITERONE_PROFILE environment variable.",
+			},
+			"endpoint": schema.StringAttribute{
+				Optional:    true,
+				Description: "GraphQL endpoint URL, for example of a private or staging deployment. Overrides the endpoint generated from `region`. Can also be set with the JUPITERONE_ENDPOINT environment variable.",
+			},
+			"http_proxy": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the HTTP proxy to send API requests through. Defaults to the proxy from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables. Can also be set with the JUPITERONE_HTTP_PROXY environment variable.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM file of additional certificate authorities to trust, such as the CA of a TLS intercepting proxy. Can also be set with the JUPITERONE_CA_CERT_FILE environment variable.",
+			},
+			"client_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM encoded client certificate presented for mutual TLS. Requires `client_key_file`. Can also be set with the JUPITERONE_CLIENT_CERT_FILE environment variable.",
+			},
+			"client_key_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to the PEM encoded private key of `client_cert_file`. Can also be set with the JUPITERONE_CLIENT_KEY_FILE environment variable.",
+			},
+			"retry_max_attempts": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of times a request is sent before giving up, including the first attempt. Rate limited requests are retried for all operations, server errors and dropped connections only for queries. Defaults to 5. Can also be set with the JUPITERONE_RETRY_MAX_ATTEMPTS environment variable.",
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				Optional:    true,
+				Description: "Base delay between retries as a duration such as `15s`, doubled on each attempt unless the API sends a Retry-After or Ratelimit-Reset header. Defaults to 15s. Can also be set with the JUPITERONE_RETRY_MIN_BACKOFF environment variable.",
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Optional:    true,
+				Description: "Maximum delay between retries as a duration such as `60s`. Defaults to 60s. Can also be set with the JUPITERONE_RETRY_MAX_BACKOFF environment variable.",
+			},
+			"validate_j1ql": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to check the syntax of J1QL queries when planning, so that errors are reported with their line and column before apply. Set to false if the API accepts a query that the provider rejects. Defaults to true. Can also be set with the JUPITERONE_VALIDATE_J1QL environment variable.",
+			},
+			"strict_j1ql": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether J1QL syntax errors found by `validate_j1ql` fail the plan. By default they are reported as warnings, as the API may accept queries the provider cannot parse. Defaults to false. Can also be set with the JUPITERONE_STRICT_J1QL environment variable.",
+			},
+			"api_key_command": schema.StringAttribute{
+				Optional:    true,
+				Description: "Credential helper command, run through the system shell, that prints a JSON object with an `api_key` and optionally an `account_id` to stdout. The command is run again when the API rejects the key so that rotated keys are picked up. An `account_id` from the provider configuration or environment takes precedence over the one printed by the command. Can also be set with the JUPITERONE_API_KEY_COMMAND environment variable.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"j1ql_lint": j1qlLintBlock(),
 		},
 	}
 }
@@ -4,19 +4,20 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"os"
-	"strings"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
-	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/fakeserver"
+	"github.com/stretchr/testify/assert"
 	"gopkg.in/dnaeon/go-vcr.v3/cassette"
-	"gopkg.in/dnaeon/go-vcr.v3/recorder"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -57,24 +58,12 @@ func stripHeadersFromCassetteInteraction(i *cassette.Interaction) error {
 	return nil
 }
 
-func setupCassettes(name string) (*recorder.Recorder, func(t *testing.T)) {
-	rec, err := recorder.NewWithOptions(&recorder.Options{
-		CassetteName:       fmt.Sprintf("cassettes/%s", name),
-		Mode:               recorder.ModeRecordOnce,
-		RealTransport:      cleanhttp.DefaultTransport(),
-		SkipRequestLatency: false,
-	})
+func setupCassettes(name string) (*cassetteRecorder, func(t *testing.T)) {
+	rec, err := newCassetteRecorder(fmt.Sprintf("cassettes/%s", name))
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	rec.SetMatcher(func(req *http.Request, c cassette.Request) bool {
-		// ignore hostname prefixes and URI paths on replays
-		return req.Method == c.Method && strings.HasSuffix(req.Host, "jupiterone.io")
-	})
-
-	rec.AddHook(stripHeadersFromCassetteInteraction, recorder.BeforeSaveHook)
-
 	cleanup := func(t *testing.T) {
 		_ = rec.Stop()
 	}
@@ -86,14 +75,21 @@ func setupCassettes(name string) (*recorder.Recorder, func(t *testing.T)) {
 //     requests that verify the state during recording, but don't need to be
 //     repeated during replays.
 func setupTestClients(ctx context.Context, t *testing.T) (recordingClient graphql.Client, directClient graphql.Client, cleanup func(t *testing.T)) {
-	var recorder *recorder.Recorder
+	var recorder *cassetteRecorder
+	var err error
 
 	recorder, cleanup = setupCassettes(t.Name())
 
-	recordingClient = client.NewQlientFromEnv(ctx, recorder)
+	recordingClient, err = client.NewQlientFromEnv(ctx, recorder)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	if recorder.IsRecording() {
-		directClient = client.NewQlientFromEnv(ctx, recorder)
+		directClient, err = client.NewQlientFromEnv(ctx, recorder)
+		if err != nil {
+			log.Fatal(err)
+		}
 		t.Log("Recording cassettes")
 	}
 
@@ -104,14 +100,21 @@ func setupTestClients(ctx context.Context, t *testing.T) (recordingClient graphq
 //   - directClient: uses the same recorder during recording/replay to ensure
 //     all test interactions are captured in cassettes
 func setupTestClientsWithReplaySupport(ctx context.Context, t *testing.T) (recordingClient graphql.Client, directClient graphql.Client, cleanup func(t *testing.T)) {
-	var recorder *recorder.Recorder
+	var recorder *cassetteRecorder
+	var err error
 
 	recorder, cleanup = setupCassettes(t.Name())
 
-	recordingClient = client.NewQlientFromEnv(ctx, recorder)
+	recordingClient, err = client.NewQlientFromEnv(ctx, recorder)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	if recorder.IsRecording() {
-		directClient = client.NewQlientFromEnv(ctx, recorder)
+		directClient, err = client.NewQlientFromEnv(ctx, recorder)
+		if err != nil {
+			log.Fatal(err)
+		}
 		t.Log("Recording cassettes")
 	} else {
 		directClient = recordingClient
@@ -121,6 +124,21 @@ func setupTestClientsWithReplaySupport(ctx context.Context, t *testing.T) (recor
 	return
 }
 
+// setupFakeServer starts an in-memory fake of the JupiterOne API for tests
+// that should not depend on cassettes or credentials. The server is closed
+// when the test ends.
+func setupFakeServer(ctx context.Context, t *testing.T) (*fakeserver.Server, graphql.Client) {
+	server := fakeserver.NewServer()
+	t.Cleanup(server.Close)
+
+	qlient, err := server.Qlient(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, qlient
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("JUPITERONE_API_KEY"); v == "" {
 		t.Fatal("JUPITERONE_API_KEY must be set for acceptance tests")
@@ -132,3 +150,158 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("JUPITERONE_REGION must be set for acceptance tests")
 	}
 }
+
+// clearProviderEnv unsets the provider environment variables, which are
+// exported by the GNUmakefile for the acceptance tests.
+func clearProviderEnv(t *testing.T) {
+	for _, name := range []string{
+		"JUPITERONE_API_KEY",
+		"JUPITERONE_ACCOUNT_ID",
+		"JUPITERONE_REGION",
+		"JUPITERONE_CLIENT_ID",
+		"JUPITERONE_CLIENT_SECRET",
+		"JUPITERONE_OAUTH_TOKEN_URL",
+		"JUPITERONE_API_KEY_COMMAND",
+		"JUPITERONE_PROFILE",
+		"JUPITERONE_ENDPOINT",
+		"JUPITERONE_HTTP_PROXY",
+		"JUPITERONE_CA_CERT_FILE",
+		"JUPITERONE_CLIENT_CERT_FILE",
+		"JUPITERONE_CLIENT_KEY_FILE",
+		"JUPITERONE_RETRY_MAX_ATTEMPTS",
+		"JUPITERONE_RETRY_MIN_BACKOFF",
+		"JUPITERONE_RETRY_MAX_BACKOFF",
+	} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("JUPITERONE_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+}
+
+func TestProviderClientConfigPrecedence(t *testing.T) {
+	clearProviderEnv(t)
+
+	configFile := os.Getenv("JUPITERONE_CONFIG_FILE")
+	err := os.WriteFile(configFile, []byte(`
+[default]
+api_key    = profile-key
+account_id = profile-account
+region     = profile-region
+
+[staging]
+api_key  = staging-key
+endpoint = https://graphql.staging.example.com/
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("JUPITERONE_ACCOUNT_ID", "env-account")
+
+	data := JupiterOneProviderModel{
+		Region: types.StringValue("hcl-region"),
+	}
+
+	config, diags := data.clientConfig(context.TODO())
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "profile-key", config.APIKey)
+	assert.Equal(t, "env-account", config.AccountID)
+	assert.Equal(t, "hcl-region", config.Region)
+	assert.Equal(t, "", config.Endpoint)
+
+	data.Profile = types.StringValue("staging")
+	config, diags = data.clientConfig(context.TODO())
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "staging-key", config.APIKey)
+	assert.Equal(t, "env-account", config.AccountID)
+	assert.Equal(t, "https://graphql.staging.example.com/", config.Endpoint)
+
+	t.Setenv("JUPITERONE_PROFILE", "missing")
+	data.Profile = types.StringNull()
+	_, diags = data.clientConfig(context.TODO())
+	assert.True(t, diags.HasError())
+}
+
+func TestProviderClientConfigAuthentication(t *testing.T) {
+	clearProviderEnv(t)
+	t.Setenv("JUPITERONE_ACCOUNT_ID", "account")
+	t.Setenv("JUPITERONE_REGION", "us")
+
+	data := JupiterOneProviderModel{}
+	_, diags := data.clientConfig(context.TODO())
+	assert.True(t, diags.HasError(), "an api key or client credentials are required")
+
+	data = JupiterOneProviderModel{
+		APIKey:   types.StringValue("key"),
+		ClientID: types.StringValue("client"),
+	}
+	_, diags = data.clientConfig(context.TODO())
+	assert.True(t, diags.HasError(), "api key and client credentials conflict")
+
+	data = JupiterOneProviderModel{
+		ClientID:     types.StringValue("client"),
+		ClientSecret: types.StringValue("secret"),
+	}
+	config, diags := data.clientConfig(context.TODO())
+	assert.False(t, diags.HasError(), diags)
+	assert.True(t, config.UsesClientCredentials())
+
+	data = JupiterOneProviderModel{
+		APIKeyCommand: types.StringValue(`echo '{"api_key":"helper-key","account_id":"helper-account"}'`),
+	}
+	config, diags = data.clientConfig(context.TODO())
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "helper-key", config.APIKey)
+	assert.Equal(t, "account", config.AccountID, "environment account id takes precedence")
+
+	data = JupiterOneProviderModel{
+		APIKey:        types.StringValue("key"),
+		APIKeyCommand: types.StringValue("echo '{}'"),
+	}
+	_, diags = data.clientConfig(context.TODO())
+	assert.True(t, diags.HasError(), "api key and api key command conflict")
+
+	t.Setenv("JUPITERONE_API_KEY_COMMAND", "exit 1")
+	data = JupiterOneProviderModel{
+		APIKey: types.StringValue("key"),
+	}
+	config, diags = data.clientConfig(context.TODO())
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "key", config.APIKey, "configured api key takes precedence over the environment")
+	assert.Empty(t, config.APIKeyCommand)
+}
+
+func TestProviderClientConfigRetry(t *testing.T) {
+	clearProviderEnv(t)
+	t.Setenv("JUPITERONE_API_KEY", "key")
+	t.Setenv("JUPITERONE_ACCOUNT_ID", "account")
+	t.Setenv("JUPITERONE_REGION", "us")
+	t.Setenv("JUPITERONE_RETRY_MIN_BACKOFF", "1s")
+
+	data := JupiterOneProviderModel{
+		RetryMaxAttempts: types.Int64Value(3),
+		RetryMaxBackoff:  types.StringValue("30s"),
+	}
+	config, diags := data.clientConfig(context.TODO())
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, 3, config.MaxAttempts)
+	assert.Equal(t, time.Second, config.MinBackoff)
+	assert.Equal(t, 30*time.Second, config.MaxBackoff)
+
+	data = JupiterOneProviderModel{
+		RetryMaxAttempts: types.Int64Value(0),
+	}
+	_, diags = data.clientConfig(context.TODO())
+	assert.True(t, diags.HasError(), "at least one attempt is required")
+
+	data = JupiterOneProviderModel{
+		RetryMinBackoff: types.StringValue("2m"),
+	}
+	_, diags = data.clientConfig(context.TODO())
+	assert.True(t, diags.HasError(), "min backoff above the default max backoff")
+
+	data = JupiterOneProviderModel{
+		RetryMaxBackoff: types.StringValue("soon"),
+	}
+	_, diags = data.clientConfig(context.TODO())
+	assert.True(t, diags.HasError(), "invalid duration")
+}
@@ -29,6 +29,7 @@ type AccountParameterResource struct {
 // AccountParameterModel is the terraform HCL representation of an account parameter.
 type AccountParameterModel struct {
 	Id        types.String `json:"id,omitempty" tfsdk:"id"`
+	AccountId types.String `json:"account_id,omitempty" tfsdk:"account_id"`
 	Name      types.String `json:"name,omitempty" tfsdk:"name"`
 	Value     types.String `json:"value,omitempty" tfsdk:"value"`
 	ValueType types.String `json:"valueType,omitempty" tfsdk:"value_type"`
@@ -55,6 +56,7 @@ func (*AccountParameterResource) Schema(ctx context.Context, req resource.Schema
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the account parameter. Must be unique. Must contain no spaces, just alphanumeric characters, and underscores.",
@@ -115,6 +117,8 @@ func (r *AccountParameterResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var parsedValue, parseError = parseValue(data.ValueType.ValueString(), data.Value.ValueString())
 
 	if parseError != nil {
@@ -131,7 +135,7 @@ func (r *AccountParameterResource) Create(ctx context.Context, req resource.Crea
 	)
 
 	if err != nil || !created.SetParameter.Success {
-		resp.Diagnostics.AddError("failed to create account parameter", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create account parameter", err)
 		return
 	}
 
@@ -154,8 +158,10 @@ func (r *AccountParameterResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteAccountParameter(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("failed to delete account parameter", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete account parameter", err)
 	}
 }
 
@@ -170,14 +176,16 @@ func (r *AccountParameterResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	parameterResp, err := client.GetAccountParameter(ctx, r.qlient, data.Name.ValueString())
 	log.Println("Read account parameter:", parameterResp.GetParameter().Name == "")
 
 	if err != nil {
-		if strings.Contains(err.Error(), "does not exist") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "does not exist") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to get account parameter", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to get account parameter", err)
 		}
 		return
 	} else if parameterResp.Parameter.Name == "" {
@@ -196,7 +204,7 @@ func (r *AccountParameterResource) Read(ctx context.Context, req resource.ReadRe
 
 // ImportState implements resource.ResourceWithImportState
 func (*AccountParameterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Update implements resource.Resource
@@ -210,6 +218,8 @@ func (r *AccountParameterResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var parsedValue, parseError = parseValue(data.ValueType.ValueString(), data.Value.ValueString())
 
 	if parseError != nil {
@@ -226,7 +236,7 @@ func (r *AccountParameterResource) Update(ctx context.Context, req resource.Upda
 	)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update account parameter", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update account parameter", err)
 		return
 	}
 
@@ -55,7 +55,7 @@ func (*CollectorResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Required:    true,
 				Description: "The name of the collector.",
 			},
-			"account_id":                 schema.StringAttribute{Computed: true},
+			"account_id":                 resourceAccountIdAttribute(),
 			"created_at":                 schema.Int64Attribute{Computed: true},
 			"updated_at":                 schema.Int64Attribute{Computed: true},
 			"collector_pool_id":          schema.StringAttribute{Computed: true},
@@ -92,9 +92,11 @@ func (r *CollectorResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	created, err := client.CreateCollector(ctx, r.qlient, data.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create collector", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create collector", err)
 		return
 	}
 
@@ -121,8 +123,10 @@ func (r *CollectorResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteCollector(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("failed to delete collector", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete collector", err)
 	}
 }
 
@@ -134,9 +138,15 @@ func (r *CollectorResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	out, err := client.GetCollector(ctx, r.qlient, data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get collector", err.Error())
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "failed to get collector", err)
 		return
 	}
 
@@ -156,7 +166,7 @@ func (r *CollectorResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // ImportState implements resource.ResourceWithImportState
 func (*CollectorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Update implements resource.Resource
@@ -167,9 +177,11 @@ func (r *CollectorResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	updated, err := client.UpdateCollector(ctx, r.qlient, data.Id.ValueString(), data.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update collector", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update collector", err)
 		return
 	}
 
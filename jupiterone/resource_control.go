@@ -22,6 +22,7 @@ import (
 
 type ControlModel struct {
 	Id               types.String `tfsdk:"id"`
+	AccountId        types.String `tfsdk:"account_id"`
 	Name             types.String `tfsdk:"name"`
 	Description      types.String `tfsdk:"description"`
 	ResourceGroupId  types.String `tfsdk:"resource_group_id"`
@@ -84,6 +85,7 @@ func (*ControlResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the control",
@@ -142,7 +144,7 @@ func (*ControlResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 
 // ImportState implements resource.ResourceWithImportState
 func (*ControlResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Create implements resource.Resource
@@ -155,6 +157,8 @@ func (r *ControlResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	desiredState := data.State.ValueString()
 
 	var initialState client.InitialControlState
@@ -187,7 +191,7 @@ func (r *ControlResource) Create(ctx context.Context, req resource.CreateRequest
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create control", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create control", err)
 		return
 	}
 
@@ -210,8 +214,10 @@ func (r *ControlResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteControl(ctx, r.qlient, client.DeleteControlInput{Id: data.Id.ValueString()}); err != nil {
-		resp.Diagnostics.AddError("failed to delete control", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete control", err)
 	}
 }
 
@@ -225,12 +231,14 @@ func (r *ControlResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var c client.GetControlByIdControl
 	if result, err := client.GetControlById(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		if strings.Contains(err.Error(), "Could not find") || strings.Contains(err.Error(), "not found") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "Could not find") || strings.Contains(err.Error(), "not found") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to find control", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to find control", err)
 		}
 		return
 	} else {
@@ -276,6 +284,8 @@ func (r *ControlResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	requirementIds := make([]string, 0)
 	if !data.RequirementIds.IsNull() && !data.RequirementIds.IsUnknown() {
 		resp.Diagnostics.Append(data.RequirementIds.ElementsAs(ctx, &requirementIds, false)...)
@@ -298,7 +308,7 @@ func (r *ControlResource) Update(ctx context.Context, req resource.UpdateRequest
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update control", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update control", err)
 		return
 	}
 
@@ -311,7 +321,7 @@ func (r *ControlResource) Update(ctx context.Context, req resource.UpdateRequest
 			TargetState: client.ControlState(desiredState),
 		})
 		if err != nil {
-			resp.Diagnostics.AddError("failed to transition control state", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to transition control state", err)
 			return
 		}
 		data.State = types.StringValue(string(transitioned.TransitionControlState.State))
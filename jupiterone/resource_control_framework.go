@@ -18,6 +18,7 @@ import (
 
 type ControlFrameworkModel struct {
 	Id              types.String `tfsdk:"id"`
+	AccountId       types.String `tfsdk:"account_id"`
 	Name            types.String `tfsdk:"name"`
 	Description     types.String `tfsdk:"description"`
 	ResourceGroupId types.String `tfsdk:"resource_group_id"`
@@ -74,6 +75,7 @@ func (*ControlFrameworkResource) Schema(_ context.Context, _ resource.SchemaRequ
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the control framework",
@@ -96,7 +98,7 @@ func (*ControlFrameworkResource) Schema(_ context.Context, _ resource.SchemaRequ
 
 // ImportState implements resource.ResourceWithImportState
 func (*ControlFrameworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Create implements resource.Resource
@@ -109,6 +111,8 @@ func (r *ControlFrameworkResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	created, err := client.CreateFramework(ctx, r.qlient, client.CreateFrameworkInput{
 		Title:           data.Name.ValueString(),
 		Description:     data.Description.ValueString(),
@@ -117,7 +121,7 @@ func (r *ControlFrameworkResource) Create(ctx context.Context, req resource.Crea
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create control framework", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create control framework", err)
 		return
 	}
 
@@ -139,8 +143,10 @@ func (r *ControlFrameworkResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteFramework(ctx, r.qlient, client.DeleteFrameworkInput{Id: data.Id.ValueString()}); err != nil {
-		resp.Diagnostics.AddError("failed to delete control framework", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete control framework", err)
 	}
 }
 
@@ -154,12 +160,14 @@ func (r *ControlFrameworkResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var f client.GetFrameworkByIdControlFramework
 	if result, err := client.GetFrameworkById(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		if strings.Contains(err.Error(), "Could not find") || strings.Contains(err.Error(), "not found") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "Could not find") || strings.Contains(err.Error(), "not found") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to find control framework", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to find control framework", err)
 		}
 		return
 	} else {
@@ -190,6 +198,8 @@ func (r *ControlFrameworkResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.UpdateFramework(ctx, r.qlient, client.UpdateFrameworkInput{
 		FrameworkId:     data.Id.ValueString(),
 		Name:            data.Name.ValueString(),
@@ -199,7 +209,7 @@ func (r *ControlFrameworkResource) Update(ctx context.Context, req resource.Upda
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update control framework", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update control framework", err)
 		return
 	}
 
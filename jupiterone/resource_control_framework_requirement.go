@@ -20,6 +20,7 @@ import (
 
 type ControlFrameworkRequirementModel struct {
 	Id          types.String `tfsdk:"id"`
+	AccountId   types.String `tfsdk:"account_id"`
 	Title       types.String `tfsdk:"title"`
 	FrameworkId types.String `tfsdk:"framework_id"`
 	Description types.String `tfsdk:"description"`
@@ -78,6 +79,7 @@ func (*ControlFrameworkRequirementResource) Schema(_ context.Context, _ resource
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"title": schema.StringAttribute{
 				Required:    true,
 				Description: "The title of the requirement",
@@ -114,7 +116,7 @@ func (*ControlFrameworkRequirementResource) Schema(_ context.Context, _ resource
 
 // ImportState implements resource.ResourceWithImportState
 func (*ControlFrameworkRequirementResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Create implements resource.Resource
@@ -127,6 +129,8 @@ func (r *ControlFrameworkRequirementResource) Create(ctx context.Context, req re
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	created, err := client.CreateRequirement(ctx, r.qlient, client.CreateRequirementInput{
 		Title:       data.Title.ValueString(),
 		FrameworkId: data.FrameworkId.ValueString(),
@@ -137,7 +141,7 @@ func (r *ControlFrameworkRequirementResource) Create(ctx context.Context, req re
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create control framework requirement", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create control framework requirement", err)
 		return
 	}
 
@@ -159,8 +163,10 @@ func (r *ControlFrameworkRequirementResource) Delete(ctx context.Context, req re
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteRequirement(ctx, r.qlient, client.DeleteRequirementInput{Id: data.Id.ValueString()}); err != nil {
-		resp.Diagnostics.AddError("failed to delete control framework requirement", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete control framework requirement", err)
 	}
 }
 
@@ -174,12 +180,14 @@ func (r *ControlFrameworkRequirementResource) Read(ctx context.Context, req reso
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var item client.GetRequirementByIdRequirementControlRequirement
 	if result, err := client.GetRequirementById(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		if strings.Contains(err.Error(), "Could not find") || strings.Contains(err.Error(), "not found") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "Could not find") || strings.Contains(err.Error(), "not found") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to find control framework requirement", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to find control framework requirement", err)
 		}
 		return
 	} else {
@@ -216,6 +224,8 @@ func (r *ControlFrameworkRequirementResource) Update(ctx context.Context, req re
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.UpdateRequirement(ctx, r.qlient, client.UpdateRequirementInput{
 		Id:          data.Id.ValueString(),
 		Title:       data.Title.ValueString(),
@@ -226,7 +236,7 @@ func (r *ControlFrameworkRequirementResource) Update(ctx context.Context, req re
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update control framework requirement", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update control framework requirement", err)
 		return
 	}
 
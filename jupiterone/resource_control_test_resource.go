@@ -3,7 +3,6 @@ package jupiterone
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -19,21 +18,24 @@ import (
 )
 
 type ControlTestResourceModel struct {
-	Id          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	ControlId   types.String `tfsdk:"control_id"`
-	Description types.String `tfsdk:"description"`
-	Query       types.String `tfsdk:"query"`
-	ResultsAre  types.String `tfsdk:"results_are"`
+	Id          types.String   `tfsdk:"id"`
+	AccountId   types.String   `tfsdk:"account_id"`
+	Name        types.String   `tfsdk:"name"`
+	ControlId   types.String   `tfsdk:"control_id"`
+	Description types.String   `tfsdk:"description"`
+	Query       J1QLQueryValue `tfsdk:"query"`
+	ResultsAre  types.String   `tfsdk:"results_are"`
 }
 
 var _ resource.Resource = &ControlTestResource{}
 var _ resource.ResourceWithConfigure = &ControlTestResource{}
 var _ resource.ResourceWithImportState = &ControlTestResource{}
+var _ resource.ResourceWithConfigValidators = &ControlTestResource{}
 
 type ControlTestResource struct {
 	version string
 	qlient  graphql.Client
+	j1ql    *j1qlSettings
 }
 
 func NewControlTestResource() resource.Resource {
@@ -64,6 +66,17 @@ func (r *ControlTestResource) Configure(_ context.Context, req resource.Configur
 
 	r.version = p.version
 	r.qlient = p.Qlient
+	r.j1ql = &p.j1ql
+}
+
+// ConfigValidators implements resource.ResourceWithConfigValidators
+func (r *ControlTestResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		j1qlValidator{
+			settings: r.j1ql,
+			queries:  path.MatchRoot("query"),
+		},
+	}
 }
 
 // Schema implements resource.Resource
@@ -77,6 +90,7 @@ func (*ControlTestResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the control test",
@@ -93,8 +107,16 @@ func (*ControlTestResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Description: "Description of the control test",
 			},
 			"query": schema.StringAttribute{
-				Required:    true,
+				CustomType:  J1QLQueryType{},
+				Optional:    true,
+				Computed:    true,
 				Description: "The J1QL query to evaluate",
+				Validators: []validator.String{
+					configuredQuery(),
+				},
+				PlanModifiers: []planmodifier.String{
+					useStateForEquivalentQuery(),
+				},
 			},
 			"results_are": schema.StringAttribute{
 				Required:    true,
@@ -109,7 +131,7 @@ func (*ControlTestResource) Schema(_ context.Context, _ resource.SchemaRequest,
 
 // ImportState implements resource.ResourceWithImportState
 func (*ControlTestResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 func (data *ControlTestResourceModel) toQueryInput() []client.ControlTestQueryInput {
@@ -132,6 +154,8 @@ func (r *ControlTestResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	created, err := client.CreateControlTest(ctx, r.qlient, client.CreateControlTestInput{
 		Name:        data.Name.ValueString(),
 		ControlId:   data.ControlId.ValueString(),
@@ -139,7 +163,7 @@ func (r *ControlTestResource) Create(ctx context.Context, req resource.CreateReq
 		Queries:     data.toQueryInput(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create control test", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create control test", err)
 		return
 	}
 
@@ -160,8 +184,10 @@ func (r *ControlTestResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteControlTest(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("failed to delete control test", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete control test", err)
 	}
 }
 
@@ -174,12 +200,14 @@ func (r *ControlTestResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var ct client.GetControlTestByIdControlTest
 	if result, err := client.GetControlTestById(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		if strings.Contains(err.Error(), "Could not find") || strings.Contains(err.Error(), "not found") {
+		if client.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to find control test", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to find control test", err)
 		}
 		return
 	} else {
@@ -194,7 +222,7 @@ func (r *ControlTestResource) Read(ctx context.Context, req resource.ReadRequest
 
 	if len(ct.Queries) > 0 {
 		q := ct.Queries[0]
-		data.Query = types.StringValue(q.Query)
+		data.Query = NewJ1QLQueryValue(q.Query)
 		data.ResultsAre = types.StringValue(string(q.ResultsAre))
 	}
 
@@ -210,6 +238,8 @@ func (r *ControlTestResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.UpdateControlTest(ctx, r.qlient, client.UpdateControlTestInput{
 		Id:          data.Id.ValueString(),
 		Name:        data.Name.ValueString(),
@@ -217,7 +247,7 @@ func (r *ControlTestResource) Update(ctx context.Context, req resource.UpdateReq
 		Queries:     data.toQueryInput(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update control test", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update control test", err)
 		return
 	}
 
@@ -29,6 +29,7 @@ type CustomIntegrationDefinitionResource struct {
 
 type CustomIntegrationDefinitionModel struct {
 	Id                   types.String `json:"id,omitempty" tfsdk:"id"`
+	AccountId            types.String `json:"account_id,omitempty" tfsdk:"account_id"`
 	Name                 types.String `json:"name" tfsdk:"name"`
 	Description          types.String `json:"description" tfsdk:"description"`
 	IntegrationType      types.String `json:"integrationType" tfsdk:"integration_type"`
@@ -57,6 +58,7 @@ func (*CustomIntegrationDefinitionResource) Schema(ctx context.Context, req reso
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "Name of the custom integration definition",
@@ -134,6 +136,8 @@ func (r *CustomIntegrationDefinitionResource) Create(ctx context.Context, req re
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var categories []string
 	resp.Diagnostics.Append(data.IntegrationCategory.ElementsAs(ctx, &categories, false)...)
 	if resp.Diagnostics.HasError() {
@@ -152,7 +156,7 @@ func (r *CustomIntegrationDefinitionResource) Create(ctx context.Context, req re
 
 	created, err := client.CreateCustomIntegrationDefinition(ctx, r.qlient, input)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create custom integration definition", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create custom integration definition", err)
 		return
 	}
 
@@ -172,12 +176,14 @@ func (r *CustomIntegrationDefinitionResource) Read(ctx context.Context, req reso
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	def, err := client.GetCustomIntegrationDefinition(ctx, r.qlient, data.IntegrationType.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "not found") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to get custom integration definition", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to get custom integration definition", err)
 		}
 		return
 	}
@@ -208,6 +214,8 @@ func (r *CustomIntegrationDefinitionResource) Update(ctx context.Context, req re
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var categories []string
 	resp.Diagnostics.Append(data.IntegrationCategory.ElementsAs(ctx, &categories, false)...)
 	if resp.Diagnostics.HasError() {
@@ -225,7 +233,7 @@ func (r *CustomIntegrationDefinitionResource) Update(ctx context.Context, req re
 
 	_, err := client.UpdateCustomIntegrationDefinition(ctx, r.qlient, data.Id.ValueString(), updateInput)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update custom integration definition", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update custom integration definition", err)
 		return
 	}
 
@@ -240,8 +248,10 @@ func (r *CustomIntegrationDefinitionResource) Delete(ctx context.Context, req re
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.ArchiveCustomIntegrationDefinition(ctx, r.qlient, data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete custom integration definition", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete custom integration definition", err)
 	}
 }
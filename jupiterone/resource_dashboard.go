@@ -36,6 +36,7 @@ func NewDashboard() resource.Resource {
 
 type DashboardModel struct {
 	Id              types.String `json:"id,omitempty" tfsdk:"id"`
+	AccountId       types.String `json:"account_id,omitempty" tfsdk:"account_id"`
 	Name            types.String `json:"name,omitempty" tfsdk:"name"`
 	Type            types.String `json:"type,omitempty" tfsdk:"type"`
 	ResourceGroupId types.String `json:"resource_group_id" tfsdk:"resource_group_id"`
@@ -80,6 +81,8 @@ func (r *DashboardResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	dashboard, err := data.BuildCreateInsightsDashboardInput()
 	if err != nil {
 		resp.Diagnostics.AddError("failed to build dashboard from configuration", err.Error())
@@ -93,7 +96,7 @@ func (r *DashboardResource) Create(ctx context.Context, req resource.CreateReque
 	)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create dashboard entity", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create dashboard entity", err)
 		return
 	}
 
@@ -117,8 +120,10 @@ func (r *DashboardResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteDashboard(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("failed to delete dashboard", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete dashboard", err)
 	}
 }
 
@@ -133,12 +138,14 @@ func (r *DashboardResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	dashboard, err := client.GetDashboard(ctx, r.qlient, data.Id.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "not found") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to get dashboard", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to get dashboard", err)
 		}
 		return
 	}
@@ -152,7 +159,7 @@ func (r *DashboardResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // ImportState implements resource.ResourceWithImportState
 func (*DashboardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Schema implements resource.Resource.
@@ -166,6 +173,7 @@ func (*DashboardResource) Schema(ctx context.Context, req resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the dashboard.",
@@ -196,6 +204,8 @@ func (r *DashboardResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	dashboard, err := data.BuildPatchInsightsDashboardInput()
 	if err != nil {
 		resp.Diagnostics.AddError("failed to build update dashboard from configuration", err.Error())
@@ -209,7 +219,7 @@ func (r *DashboardResource) Update(ctx context.Context, req resource.UpdateReque
 	)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update dashboard", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update dashboard", err)
 		return
 	}
 
@@ -26,6 +26,7 @@ type DashboardParameterResource struct {
 
 type DashboardParameterModel struct {
 	Id                 types.String `json:"id,omitempty" tfsdk:"id"`
+	AccountId          types.String `json:"account_id,omitempty" tfsdk:"account_id"`
 	DashboardId        types.String `json:"dashboard_id" tfsdk:"dashboard_id"`
 	Label              types.String `json:"label" tfsdk:"label"`
 	Name               types.String `json:"name" tfsdk:"name"`
@@ -69,6 +70,8 @@ func (r *DashboardParameterResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	// Validate that the name is alphanumeric
 	if !isAlphanumeric(data.Name.ValueString()) {
 		resp.Diagnostics.AddError(
@@ -99,7 +102,7 @@ func (r *DashboardParameterResource) Create(ctx context.Context, req resource.Cr
 
 	created, err := client.CreateDashboardParameter(ctx, r.qlient, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create dashboard parameter", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to create dashboard parameter", err)
 		return
 	}
 
@@ -116,9 +119,15 @@ func (r *DashboardParameterResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	response, err := client.DashboardParameter(ctx, r.qlient, data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read dashboard parameter", err.Error())
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Failed to read dashboard parameter", err)
 		return
 	}
 
@@ -168,6 +177,8 @@ func (r *DashboardParameterResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var state DashboardParameterModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -195,7 +206,7 @@ func (r *DashboardParameterResource) Update(ctx context.Context, req resource.Up
 
 	_, err := client.PatchDashboardParameter(ctx, r.qlient, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update dashboard parameter", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to update dashboard parameter", err)
 		return
 	}
 
@@ -209,9 +220,11 @@ func (r *DashboardParameterResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.DeleteDashboardParameter(ctx, r.qlient, data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete dashboard parameter", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to delete dashboard parameter", err)
 	}
 }
 
@@ -226,6 +239,7 @@ func (*DashboardParameterResource) Schema(ctx context.Context, req resource.Sche
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"dashboard_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The ID of the dashboard.",
@@ -272,7 +286,7 @@ func (*DashboardParameterResource) Schema(ctx context.Context, req resource.Sche
 
 // ImportState implements resource.ResourceWithImportState
 func (*DashboardParameterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Helper function to check if a string is alphanumeric
@@ -2,7 +2,6 @@ package jupiterone
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/Khan/genqlient/graphql"
@@ -36,7 +35,7 @@ type DropRuleConfigResource struct {
 type dropRuleConditionModel struct {
 	Property types.String `tfsdk:"property"`
 	Op       types.String `tfsdk:"op"`
-	Value    types.String `tfsdk:"value"`
+	Value    JSONValue    `tfsdk:"value"`
 }
 
 type dropRuleModel struct {
@@ -48,10 +47,11 @@ type dropRuleModel struct {
 }
 
 type dropRuleConfigModel struct {
-	Id      types.String    `tfsdk:"id"`
-	Enabled types.Bool      `tfsdk:"enabled"`
-	Version types.Int64     `tfsdk:"version"`
-	Rules   []dropRuleModel `tfsdk:"rules"`
+	Id        types.String    `tfsdk:"id"`
+	AccountId types.String    `tfsdk:"account_id"`
+	Enabled   types.Bool      `tfsdk:"enabled"`
+	Version   types.Int64     `tfsdk:"version"`
+	Rules     []dropRuleModel `tfsdk:"rules"`
 }
 
 func NewDropRuleConfigResource() resource.Resource {
@@ -94,6 +94,7 @@ account owns the outcome of anything it drops.`,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"version": schema.Int64Attribute{
 				Computed:    true,
 				Description: "Monotonic version of the configuration, bumped on every write.",
@@ -142,7 +143,8 @@ account owns the outcome of anything it drops.`,
 										},
 									},
 									"value": schema.StringAttribute{
-										Optional: true,
+										Optional:   true,
+										CustomType: JSONType{},
 										Description: "The comparison value, JSON-encoded (e.g. `jsonencode(false)`, " +
 											"`jsonencode(\"prod\")`, `jsonencode([\"a\",\"b\"])`). Omit for `exists`.",
 									},
@@ -157,7 +159,7 @@ account owns the outcome of anything it drops.`,
 }
 
 func (*DropRuleConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // buildInput converts the terraform model into the GraphQL save input.
@@ -166,7 +168,7 @@ func buildDropRulesInput(data *dropRuleConfigModel) (client.DropRulesConfigInput
 	for _, rule := range data.Rules {
 		conditions := make([]client.DropRuleConditionInputBeta, 0, len(rule.Conditions))
 		for _, c := range rule.Conditions {
-			value, err := decodeConditionValue(c.Value)
+			value, err := c.Value.JSON()
 			if err != nil {
 				return client.DropRulesConfigInputBeta{}, fmt.Errorf("rule %q condition %q: value must be valid JSON: %w", rule.Id.ValueString(), c.Property.ValueString(), err)
 			}
@@ -205,7 +207,7 @@ func flattenDropRulesConfig(cfg client.DropRulesConfig, data *dropRuleConfigMode
 			conditions = append(conditions, dropRuleConditionModel{
 				Property: types.StringValue(c.Property),
 				Op:       types.StringValue(string(c.Op)),
-				Value:    encodeConditionValue(c.Value),
+				Value:    NewJSONValueFrom(c.Value),
 			})
 		}
 		rules = append(rules, dropRuleModel{
@@ -228,6 +230,8 @@ func (r *DropRuleConfigResource) Create(ctx context.Context, req resource.Create
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
 	r.save(ctx, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -242,6 +246,8 @@ func (r *DropRuleConfigResource) Update(ctx context.Context, req resource.Update
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
 	r.save(ctx, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -258,7 +264,7 @@ func (r *DropRuleConfigResource) save(ctx context.Context, data *dropRuleConfigM
 	}
 	saved, err := client.SaveDropRulesConfig(ctx, r.qlient, input)
 	if err != nil {
-		diags.AddError("Failed to save drop rule config", err.Error())
+		addAPIError(diags, "Failed to save drop rule config", err)
 		return
 	}
 	flattenDropRulesConfig(saved.SaveDropRulesConfigBeta.Config.DropRulesConfig, data)
@@ -271,9 +277,15 @@ func (r *DropRuleConfigResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	current, err := client.GetDropRulesConfig(ctx, r.qlient)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read drop rule config", err.Error())
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Failed to read drop rule config", err)
 		return
 	}
 
@@ -292,12 +304,20 @@ func (r *DropRuleConfigResource) Read(ctx context.Context, req resource.ReadRequ
 // Delete neutralizes the config (there is no delete-config GraphQL mutation):
 // disable it and clear the rules so nothing is dropped.
 func (r *DropRuleConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data dropRuleConfigModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, _ = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.SaveDropRulesConfig(ctx, r.qlient, client.DropRulesConfigInputBeta{
 		Enabled: false,
 		Rules:   []client.DropRuleInputBeta{},
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete (disable) drop rule config", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to delete (disable) drop rule config", err)
 	}
 }
 
@@ -320,25 +340,3 @@ func stringOrNull(s string) types.String {
 	}
 	return types.StringValue(s)
 }
-
-func decodeConditionValue(s types.String) (interface{}, error) {
-	if s.IsNull() || s.IsUnknown() || s.ValueString() == "" {
-		return nil, nil
-	}
-	var out interface{}
-	if err := json.Unmarshal([]byte(s.ValueString()), &out); err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func encodeConditionValue(v interface{}) types.String {
-	if v == nil {
-		return types.StringNull()
-	}
-	b, err := json.Marshal(v)
-	if err != nil {
-		return types.StringNull()
-	}
-	return types.StringValue(string(b))
-}
@@ -2,7 +2,6 @@ package jupiterone
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -29,28 +28,22 @@ var FrameworkTypes = []string{
 }
 
 type ComplianceFrameworkModel struct {
-	Id            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	Version       types.String `tfsdk:"version"`
-	FrameworkType types.String `tfsdk:"framework_type"`
-	WebLink       types.String `tfsdk:"web_link"`
-	ScopeFilters  types.List   `tfsdk:"scope_filters"`
+	Id            types.String  `tfsdk:"id"`
+	AccountId     types.String  `tfsdk:"account_id"`
+	Name          types.String  `tfsdk:"name"`
+	Version       types.String  `tfsdk:"version"`
+	FrameworkType types.String  `tfsdk:"framework_type"`
+	WebLink       types.String  `tfsdk:"web_link"`
+	ScopeFilters  JSONListValue `tfsdk:"scope_filters"`
 }
 
 // BuildScopeFilters builds the data model that is accepted by the J1 API
 // for its `JSON` types
-func (c *ComplianceFrameworkModel) BuildScopeFilters(ctx context.Context) ([]interface{}, diag.Diagnostics) {
-	var elements []types.String
-	diag := c.ScopeFilters.ElementsAs(ctx, &elements, false)
-	if diag.HasError() {
-		return nil, diag
-	}
-	scopeFilters := make([]interface{}, len(c.ScopeFilters.Elements()))
-	for i, f := range elements {
-		err := json.Unmarshal([]byte(f.ValueString()), &scopeFilters[i])
-		if err != nil {
-			diag.AddError(fmt.Sprintf("Could not marshal scope filter at index %d", i), err.Error())
-		}
+func (c *ComplianceFrameworkModel) BuildScopeFilters(ctx context.Context) ([]client.JSON, diag.Diagnostics) {
+	var diag diag.Diagnostics
+	scopeFilters, err := c.ScopeFilters.JSON()
+	if err != nil {
+		diag.AddError("Could not encode scope filters", err.Error())
 	}
 	return scopeFilters, diag
 }
@@ -106,6 +99,7 @@ func (*ComplianceFrameworkResource) Schema(_ context.Context, _ resource.SchemaR
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The framework's name",
@@ -138,9 +132,10 @@ func (*ComplianceFrameworkResource) Schema(_ context.Context, _ resource.SchemaR
 				Description: "JSON encoded filters for scoping the framework.",
 				Optional:    true,
 				Computed:    true,
-				ElementType: types.StringType,
+				ElementType: JSONType{},
+				CustomType:  NewJSONListType(),
 				Validators:  []validator.List{},
-				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				Default:     listdefault.StaticValue(types.ListValueMust(JSONType{}, []attr.Value{})),
 				PlanModifiers: []planmodifier.List{
 					jsonIgnoreDiffPlanModifierList(),
 				},
@@ -151,7 +146,7 @@ func (*ComplianceFrameworkResource) Schema(_ context.Context, _ resource.SchemaR
 
 // ImportState implements resource.ResourceWithImportState
 func (*ComplianceFrameworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Create implements resource.Resource
@@ -165,6 +160,8 @@ func (r *ComplianceFrameworkResource) Create(ctx context.Context, req resource.C
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	scopeFilters, diag := data.BuildScopeFilters(ctx)
 	resp.Diagnostics.Append(diag...)
 	if resp.Diagnostics.HasError() {
@@ -180,7 +177,7 @@ func (r *ComplianceFrameworkResource) Create(ctx context.Context, req resource.C
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create framework", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create framework", err)
 		return
 	}
 
@@ -203,8 +200,10 @@ func (r *ComplianceFrameworkResource) Delete(ctx context.Context, req resource.D
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteComplianceFramework(ctx, r.qlient, client.DeleteComplianceFrameworkInput{Id: data.Id.ValueString()}); err != nil {
-		resp.Diagnostics.AddError("failed to delete framework", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete framework", err)
 	}
 }
 
@@ -219,12 +218,14 @@ func (r *ComplianceFrameworkResource) Read(ctx context.Context, req resource.Rea
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var f client.GetComplianceFrameworkByIdComplianceFramework
 	if r, err := client.GetComplianceFrameworkById(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		if strings.Contains(err.Error(), "Could not find") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "Could not find") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to find framework", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to find framework", err)
 		}
 		return
 	} else {
@@ -237,26 +238,7 @@ func (r *ComplianceFrameworkResource) Read(ctx context.Context, req resource.Rea
 		data.WebLink = types.StringValue(f.WebLink)
 	}
 
-	newScopeFilters := []string{}
-
-	for _, f := range f.ScopeFilters {
-		b, err := json.Marshal(f)
-		if err != nil {
-			resp.Diagnostics.AddError("failed to json encode scope filter", err.Error())
-		}
-		newScopeFilters = append(newScopeFilters, string(b))
-	}
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	var diag diag.Diagnostics
-	data.ScopeFilters, diag = types.ListValueFrom(ctx, types.StringType, newScopeFilters)
-	resp.Diagnostics.Append(diag...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	data.ScopeFilters = NewJSONListValueFrom(f.ScopeFilters)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -273,6 +255,8 @@ func (r *ComplianceFrameworkResource) Update(ctx context.Context, req resource.U
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	scopeFilters, diag := data.BuildScopeFilters(ctx)
 	resp.Diagnostics.Append(diag...)
 	if resp.Diagnostics.HasError() {
@@ -289,7 +273,7 @@ func (r *ComplianceFrameworkResource) Update(ctx context.Context, req resource.U
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update framework", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update framework", err)
 		return
 	}
 
@@ -18,6 +18,7 @@ import (
 
 type ComplianceFrameworkItemModel struct {
 	Id              types.String `tfsdk:"id"`
+	AccountId       types.String `tfsdk:"account_id"`
 	FrameworkId     types.String `tfsdk:"framework_id"`
 	GroupId         types.String `tfsdk:"group_id"`
 	Name            types.String `tfsdk:"name"`
@@ -78,6 +79,7 @@ func (*ComplianceFrameworkItemResource) Schema(_ context.Context, _ resource.Sch
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"framework_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The internal ID of the framework this item belongs to",
@@ -117,7 +119,7 @@ func (*ComplianceFrameworkItemResource) Schema(_ context.Context, _ resource.Sch
 
 // ImportState implements resource.ResourceWithImportState
 func (*ComplianceFrameworkItemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Create implements resource.Resource
@@ -131,6 +133,8 @@ func (r *ComplianceFrameworkItemResource) Create(ctx context.Context, req resour
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	created, err := client.CreateComplianceFrameworkItem(ctx, r.qlient, client.CreateComplianceFrameworkItemInput{
 		Name:            data.Name.ValueString(),
 		Description:     data.Description.ValueString(),
@@ -142,7 +146,7 @@ func (r *ComplianceFrameworkItemResource) Create(ctx context.Context, req resour
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create framework item", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create framework item", err)
 		return
 	}
 
@@ -165,8 +169,10 @@ func (r *ComplianceFrameworkItemResource) Delete(ctx context.Context, req resour
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteComplianceFrameworkItem(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("failed to delete framework item", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete framework item", err)
 	}
 }
 
@@ -181,12 +187,14 @@ func (r *ComplianceFrameworkItemResource) Read(ctx context.Context, req resource
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var i client.GetComplianceFrameworkItemByIdComplianceFrameworkItem
 	if r, err := client.GetComplianceFrameworkItemById(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		if strings.Contains(err.Error(), "Could not find") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "Could not find") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to find framework item", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to find framework item", err)
 		}
 		return
 	} else {
@@ -224,6 +232,8 @@ func (r *ComplianceFrameworkItemResource) Update(ctx context.Context, req resour
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.UpdateComplianceFrameworkItem(ctx, r.qlient, client.UpdateComplianceFrameworkItemInput{
 		Id: data.Id.ValueString(),
 		Updates: client.UpdateComplianceFrameworkItemFields{
@@ -237,7 +247,7 @@ func (r *ComplianceFrameworkItemResource) Update(ctx context.Context, req resour
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update framework item", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update framework item", err)
 		return
 	}
 
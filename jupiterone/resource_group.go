@@ -19,6 +19,7 @@ import (
 
 type ComplianceGroupModel struct {
 	Id              types.String `tfsdk:"id"`
+	AccountId       types.String `tfsdk:"account_id"`
 	FrameworkId     types.String `tfsdk:"framework_id"`
 	Name            types.String `tfsdk:"name"`
 	Description     types.String `tfsdk:"description"`
@@ -79,6 +80,7 @@ Refer to the resource_framework docs for example usage`,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"framework_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The internal ID of the framework this group is a part of",
@@ -110,7 +112,7 @@ Refer to the resource_framework docs for example usage`,
 
 // ImportState implements resource.ResourceWithImportState
 func (*ComplianceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Create implements resource.Resource
@@ -124,6 +126,8 @@ func (r *ComplianceGroupResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	created, err := client.CreateComplianceGroup(ctx, r.qlient, client.CreateComplianceGroupInput{
 		FrameworkId:     data.FrameworkId.ValueString(),
 		Name:            data.Name.ValueString(),
@@ -133,7 +137,7 @@ func (r *ComplianceGroupResource) Create(ctx context.Context, req resource.Creat
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create group", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create group", err)
 		return
 	}
 
@@ -156,8 +160,10 @@ func (r *ComplianceGroupResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteComplianceGroup(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("failed to delete group", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete group", err)
 	}
 }
 
@@ -172,13 +178,15 @@ func (r *ComplianceGroupResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	group, err := getGroup(ctx, r.qlient, data.FrameworkId.ValueString(), data.Id.ValueString())
 
 	if err != nil {
-		if strings.Contains(err.Error(), "Could not find") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "Could not find") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to find group", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to find group", err)
 		}
 		return
 	}
@@ -232,6 +240,8 @@ func (r *ComplianceGroupResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.UpdateComplianceGroup(ctx, r.qlient, client.UpdateComplianceGroupInput{
 		Id: data.Id.ValueString(),
 		Updates: client.UpdateComplianceGroupFields{
@@ -244,7 +254,7 @@ func (r *ComplianceGroupResource) Update(ctx context.Context, req resource.Updat
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update group", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update group", err)
 		return
 	}
 
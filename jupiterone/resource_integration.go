@@ -34,14 +34,15 @@ type IngestionSourceOverride struct {
 
 type IntegrationModel struct {
 	Id                            types.String               `tfsdk:"id"`
+	AccountId                     types.String               `tfsdk:"account_id"`
 	Name                          types.String               `tfsdk:"name"`
 	PollingInterval               types.String               `tfsdk:"polling_interval"`
 	IntegrationDefinitionId       types.String               `tfsdk:"integration_definition_id"`
 	Description                   types.String               `tfsdk:"description"`
-	Config                        types.String               `tfsdk:"config"`
+	Config                        JSONValue                  `tfsdk:"config"`
 	SourceIntegrationInstanceId   types.String               `tfsdk:"source_integration_instance_id"`
 	CollectorPoolId               types.String               `tfsdk:"collector_pool_id"`
-	PollingIntervalCronExpression types.String               `tfsdk:"polling_interval_cron_expression"`
+	PollingIntervalCronExpression JSONValue                  `tfsdk:"polling_interval_cron_expression"`
 	IngestionSourcesOverrides     *[]IngestionSourceOverride `tfsdk:"ingestion_sources_overrides"`
 	ResourceGroupId               types.String               `tfsdk:"resource_group_id"`
 }
@@ -78,8 +79,10 @@ func (r *IntegrationResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	var config map[string]interface{}
-	if err := json.Unmarshal([]byte(data.Config.ValueString()), &config); err != nil {
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
+	config, err := data.Config.JSON()
+	if err != nil {
 		resp.Diagnostics.AddError("Failed to unmarshal config", err.Error())
 		return
 	}
@@ -126,7 +129,7 @@ func (r *IntegrationResource) Create(ctx context.Context, req resource.CreateReq
 
 	created, err := client.CreateIntegrationInstance(ctx, r.qlient, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create integration instance", err.Error())
+		addInputError(ctx, &resp.Diagnostics, req.Plan.Schema, "Failed to create integration instance", err, nil)
 		return
 	}
 
@@ -144,13 +147,15 @@ func (r *IntegrationResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	response, err := client.GetIntegrationInstance(ctx, r.qlient, data.Id.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "Integration instance not found") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "Integration instance not found") {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Failed to read integration instance", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to read integration instance", err)
 		return
 	}
 
@@ -160,16 +165,10 @@ func (r *IntegrationResource) Read(ctx context.Context, req resource.ReadRequest
 	data.Description = types.StringValue(response.IntegrationInstance.Description)
 	data.ResourceGroupId = types.StringValue(response.IntegrationInstance.ResourceGroupId)
 
-	configJSON, err := json.Marshal(response.IntegrationInstance.Config)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to marshal config", err.Error())
-		return
-	}
-
 	// Only update config if it doesn't contain masked values
 	// If it does, preserve the existing state value to avoid false diffs
-	if !strings.Contains(string(configJSON), "***masked***") {
-		data.Config = types.StringValue(string(configJSON))
+	if config := response.IntegrationInstance.Config; !strings.Contains(config.String(), "***masked***") {
+		data.Config = NewJSONValueFrom(config)
 	} else {
 		resp.Diagnostics.AddWarning("Config contains masked values", "Config contains masked values, so it will not be updated in terraform state to avoid false diffs.")
 	}
@@ -189,7 +188,7 @@ func (r *IntegrationResource) Read(ctx context.Context, req resource.ReadRequest
 			resp.Diagnostics.AddError("Failed to marshal polling interval cron expression", err.Error())
 			return
 		}
-		data.PollingIntervalCronExpression = types.StringValue(string(cronExpressionJSON))
+		data.PollingIntervalCronExpression = NewJSONValue(string(cronExpressionJSON))
 	}
 
 	if len(response.IntegrationInstance.IngestionSourcesOverrides) > 0 {
@@ -215,6 +214,8 @@ func (r *IntegrationResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var config map[string]interface{}
 	if err := json.Unmarshal([]byte(data.Config.ValueString()), &config); err != nil {
 		resp.Diagnostics.AddError("Failed to unmarshal config", err.Error())
@@ -223,12 +224,17 @@ func (r *IntegrationResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Cannot pass externalId to the API, so remove it from the config
 	delete(config, "externalId")
+	configJSON, err := client.NewJSON(config)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to marshal config", err.Error())
+		return
+	}
 
 	input := client.UpdateIntegrationInstanceInput{
 		Name:            data.Name.ValueString(),
 		PollingInterval: client.IntegrationPollingInterval(data.PollingInterval.ValueString()),
 		Description:     data.Description.ValueString(),
-		Config:          config,
+		Config:          configJSON,
 	}
 
 	if !data.SourceIntegrationInstanceId.IsNull() {
@@ -263,9 +269,9 @@ func (r *IntegrationResource) Update(ctx context.Context, req resource.UpdateReq
 		input.ResourceGroupId = data.ResourceGroupId.ValueString()
 	}
 
-	_, err := client.UpdateIntegrationInstance(ctx, r.qlient, data.Id.ValueString(), input)
+	_, err = client.UpdateIntegrationInstance(ctx, r.qlient, data.Id.ValueString(), input)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update integration instance", err.Error())
+		addInputError(ctx, &resp.Diagnostics, req.Plan.Schema, "Failed to update integration instance", err, nil)
 		return
 	}
 
@@ -281,9 +287,11 @@ func (r *IntegrationResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.DeleteIntegrationInstance(ctx, r.qlient, data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete integration instance", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to delete integration instance", err)
 		return
 	}
 
@@ -291,7 +299,7 @@ func (r *IntegrationResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *IntegrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 func (r *IntegrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -305,6 +313,7 @@ func (r *IntegrationResource) Schema(ctx context.Context, req resource.SchemaReq
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the integration instance.",
@@ -326,6 +335,7 @@ func (r *IntegrationResource) Schema(ctx context.Context, req resource.SchemaReq
 			},
 			"config": schema.StringAttribute{
 				Required:    true,
+				CustomType:  JSONType{},
 				Description: "The configuration for the integration instance as a JSON string.",
 			},
 			"source_integration_instance_id": schema.StringAttribute{
@@ -338,6 +348,7 @@ func (r *IntegrationResource) Schema(ctx context.Context, req resource.SchemaReq
 			},
 			"polling_interval_cron_expression": schema.StringAttribute{
 				Optional:    true,
+				CustomType:  JSONType{},
 				Description: "The cron expression for the polling interval as a JSON string.",
 			},
 			"ingestion_sources_overrides": schema.ListAttribute{
@@ -18,6 +18,7 @@ import (
 
 type ComplianceLibraryItemModel struct {
 	Id              types.String `tfsdk:"id"`
+	AccountId       types.String `tfsdk:"account_id"`
 	PolicyItemId    types.String `tfsdk:"policy_item_id"`
 	Name            types.String `tfsdk:"name"`
 	Description     types.String `tfsdk:"description"`
@@ -79,6 +80,7 @@ Refer to the resource_framework docs for example usage`,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The Library Item's display name",
@@ -111,7 +113,7 @@ Refer to the resource_framework docs for example usage`,
 
 // ImportState implements resource.ResourceWithImportState
 func (*ComplianceLibraryItemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Create implements resource.Resource
@@ -125,6 +127,8 @@ func (r *ComplianceLibraryItemResource) Create(ctx context.Context, req resource
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	created, err := client.CreateComplianceLibraryItem(ctx, r.qlient, client.CreateComplianceLibraryItemInput{
 		Name:            data.Name.ValueString(),
 		Description:     data.Description.ValueString(),
@@ -134,7 +138,7 @@ func (r *ComplianceLibraryItemResource) Create(ctx context.Context, req resource
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create library item", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create library item", err)
 		return
 	}
 
@@ -157,8 +161,10 @@ func (r *ComplianceLibraryItemResource) Delete(ctx context.Context, req resource
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteComplianceLibraryItem(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("failed to delete library item", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete library item", err)
 	}
 }
 
@@ -173,12 +179,14 @@ func (r *ComplianceLibraryItemResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var i client.GetComplianceLibraryItemByIdComplianceLibraryItem
 	if r, err := client.GetComplianceLibraryItemById(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		if strings.Contains(err.Error(), "Could not find") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "Could not find") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to find library item", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to find library item", err)
 		}
 		return
 	} else {
@@ -217,6 +225,8 @@ func (r *ComplianceLibraryItemResource) Update(ctx context.Context, req resource
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.UpdateComplianceLibraryItem(ctx, r.qlient, client.UpdateComplianceLibraryItemInput{
 		Id: data.Id.ValueString(),
 		Updates: client.UpdateComplianceLibraryItemFields{
@@ -230,7 +240,7 @@ func (r *ComplianceLibraryItemResource) Update(ctx context.Context, req resource
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update library item", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update library item", err)
 		return
 	}
 
@@ -32,10 +32,13 @@ var QueryResultsAre = []string{
 var _ resource.Resource = &QuestionResource{}
 var _ resource.ResourceWithConfigure = &QuestionResource{}
 var _ resource.ResourceWithImportState = &QuestionResource{}
+var _ resource.ResourceWithConfigValidators = &QuestionResource{}
+var _ resource.ResourceWithModifyPlan = &QuestionResource{}
 
 type QuestionResource struct {
 	version string
 	qlient  graphql.Client
+	j1ql    *j1qlSettings
 }
 
 type QuestionComplianceModel struct {
@@ -65,6 +68,7 @@ type QuestionQueryModel struct {
 // TODO: Unify the client types and the state model if possible
 type QuestionModel struct {
 	Id              types.String               `json:"id,omitempty" tfsdk:"id"`
+	AccountId       types.String               `json:"account_id,omitempty" tfsdk:"account_id"`
 	Title           types.String               `json:"title,omitempty" tfsdk:"title"`
 	Description     types.String               `json:"description,omitempty" tfsdk:"description"`
 	ShowTrend       types.Bool                 `json:"show_trend,omitempty" tfsdk:"show_trend"`
@@ -72,6 +76,7 @@ type QuestionModel struct {
 	Tags            []string                   `json:"tags,omitempty" tfsdk:"tags"`
 	Query           []*QuestionQueryModel      `json:"query,omitempty" tfsdk:"query"`
 	Compliance      []*QuestionComplianceModel `json:"compliance,omitempty" tfsdk:"compliance"`
+	Preview         types.Bool                 `json:"preview,omitempty" tfsdk:"preview"`
 }
 
 func NewQuestionResource() resource.Resource {
@@ -94,6 +99,7 @@ func (*QuestionResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"title": schema.StringAttribute{
 				Required:    true,
 				Description: "The title of the question",
@@ -120,6 +126,10 @@ func (*QuestionResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"preview": schema.BoolAttribute{
+				Optional:    true,
+				Description: previewDescription,
+			},
 		},
 		// TODO: Deprecate the use of blocks following new framework guidance:
 		// https://developer.hashicorp.com/terraform/plugin/framework/handling-data/blocks
@@ -134,10 +144,16 @@ func (*QuestionResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							},
 						},
 						"query": schema.StringAttribute{
-							Required: true,
+							CustomType: J1QLQueryType{},
+							Optional:   true,
+							Computed:   true,
 							Validators: []validator.String{
+								configuredQuery(),
 								stringvalidator.LengthAtLeast(1),
 							},
+							PlanModifiers: []planmodifier.String{
+								useStateForEquivalentQuery(),
+							},
 						},
 						"version": schema.StringAttribute{
 							Required: true,
@@ -206,6 +222,45 @@ func (r *QuestionResource) Configure(ctx context.Context, req resource.Configure
 
 	r.version = p.version
 	r.qlient = p.Qlient
+	r.j1ql = &p.j1ql
+}
+
+// ConfigValidators implements resource.ResourceWithConfigValidators
+func (r *QuestionResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		j1qlValidator{
+			settings:        r.j1ql,
+			queries:         path.MatchRoot("query").AtAnyListIndex().AtName("query"),
+			pollingInterval: path.Root("polling_interval"),
+		},
+	}
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan
+func (r *QuestionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var preview types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("preview"), &preview)...)
+	if !previewEnabled(preview) {
+		return
+	}
+
+	var question QuestionModel
+	// The question cannot be previewed while parts of it are unknown.
+	if diags := req.Plan.Get(ctx, &question); diags.HasError() || question.AccountId.IsUnknown() {
+		return
+	}
+	ctx, _ = withAccountContext(ctx, r.qlient, question.AccountId)
+	resp.Diagnostics.Append(previewQuestion(ctx, r.qlient, &question)...)
+}
+
+// questionInputRenames maps the fields of the question inputs to the
+// attributes they are built from where the names differ.
+var questionInputRenames = map[string]string{
+	"queries": "query",
 }
 
 // Create implements resource.Resource
@@ -218,11 +273,13 @@ func (r *QuestionResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	quest := data.BuildCreateQuestionInput()
 	created, err := client.CreateQuestion(ctx, r.qlient, quest)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create question", err.Error())
+		addInputError(ctx, &resp.Diagnostics, req.Plan.Schema, "failed to create question", err, questionInputRenames)
 		return
 	}
 
@@ -245,8 +302,10 @@ func (r *QuestionResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteQuestion(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("failed to delete question", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete question", err)
 	}
 }
 
@@ -261,14 +320,16 @@ func (r *QuestionResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	q, err := client.GetQuestionById(ctx, r.qlient, data.Id.ValueString())
 	if err != nil {
 		// If the error is a not found error, we should remove the resource so it can be recreated
-		if strings.Contains(err.Error(), "does not exist") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "does not exist") {
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("failed to get question", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to get question", err)
 			return
 		}
 
@@ -306,7 +367,7 @@ func (r *QuestionResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 // ImportState implements resource.ResourceWithImportState
 func (*QuestionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Update implements resource.Resource
@@ -320,11 +381,13 @@ func (r *QuestionResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	u := data.BuildQuestion()
 
 	_, err := client.UpdateQuestion(ctx, r.qlient, data.Id.ValueString(), u)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update question", err.Error())
+		addInputError(ctx, &resp.Diagnostics, req.Plan.Schema, "failed to update question", err, questionInputRenames)
 		return
 	}
 
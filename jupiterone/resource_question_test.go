@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
 func TestQuestion_Basic(t *testing.T) {
@@ -102,6 +103,74 @@ func TestQuestion_BasicImport(t *testing.T) {
 	})
 }
 
+func TestQuestion_FakeServer(t *testing.T) {
+	ctx := context.TODO()
+
+	server, qlient := setupFakeServer(ctx, t)
+
+	resourceName := "jupiterone_question.test"
+	questionTitle := "tf-provider-test-question"
+	var questionID string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(qlient),
+		CheckDestroy:             testAccCheckQuestionDestroy(ctx, qlient),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					server.FailNext("CreateQuestion", &gqlerror.Error{
+						Message:    "Forbidden",
+						Extensions: map[string]interface{}{"code": "FORBIDDEN"},
+					})
+				},
+				Config:      testQuestionBasicConfigWithTags(questionTitle, "tf_acc:1"),
+				ExpectError: regexp.MustCompile(`do not have permission`),
+			},
+			{
+				Config: testQuestionBasicConfigWithTags(questionTitle, "tf_acc:1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckQuestionExists(ctx, qlient),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "title", questionTitle),
+					resource.TestCheckResourceAttr(resourceName, "tags.0", "tf_acc:1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testQuestionBasicConfigWithTags(questionTitle, "tf_acc:2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckQuestionExists(ctx, qlient),
+					resource.TestCheckResourceAttr(resourceName, "tags.0", "tf_acc:2"),
+					func(s *terraform.State) error {
+						questionID = s.RootModule().Resources[resourceName].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// Reformatting the query does not plan an update.
+				Config:   testQuestionConfigWithQuery(questionTitle, "tf_acc:2", "FIND DataStore\n  WITH classification = ('critical' OR 'sensitive' OR 'confidential' OR 'restricted')\n  AND encrypted != TRUE"),
+				PlanOnly: true,
+			},
+			{
+				// The questions service reports a deleted question without a
+				// NOT_FOUND code, so the read recognizes it by its message.
+				PreConfig: func() {
+					if _, err := client.DeleteQuestion(ctx, qlient, questionID); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testQuestionBasicConfigWithTags(questionTitle, "tf_acc:2"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func TestQuestion_Config_Errors(t *testing.T) {
 	ctx := context.TODO()
 
@@ -243,6 +312,10 @@ func testQuestionBasicConfigWithShowTrend(rName string, showTrend string) string
 }
 
 func testQuestionBasicConfigWithTags(rName string, tag string) string {
+	return testQuestionConfigWithQuery(rName, tag, "Find DataStore with classification=('critical' or 'sensitive' or 'confidential' or 'restricted') and encrypted!=true")
+}
+
+func testQuestionConfigWithQuery(rName string, tag string, query string) string {
 	return fmt.Sprintf(`
 		provider "jupiterone" {}
 
@@ -253,9 +326,9 @@ func testQuestionBasicConfigWithTags(rName string, tag string) string {
 
 			query {
 				name = "query0"
-				query = "Find DataStore with classification=('critical' or 'sensitive' or 'confidential' or 'restricted') and encrypted!=true"
+				query = %q
 				version = "v1"
 			}
 		}
-	`, rName, tag)
+	`, rName, tag, query)
 }
@@ -22,8 +22,9 @@ type ResourceGroupResource struct {
 }
 
 type ResourceGroupModel struct {
-	Id   types.String `json:"id,omitempty" tfsdk:"id"`
-	Name types.String `json:"name" tfsdk:"name"`
+	Id        types.String `json:"id,omitempty" tfsdk:"id"`
+	AccountId types.String `json:"account_id,omitempty" tfsdk:"account_id"`
+	Name      types.String `json:"name" tfsdk:"name"`
 }
 
 func NewResourceGroupResource() resource.Resource {
@@ -67,6 +68,7 @@ func (*ResourceGroupResource) Schema(ctx context.Context, req resource.SchemaReq
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the resource group.",
@@ -86,6 +88,8 @@ func (r *ResourceGroupResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	tflog.Debug(ctx, "!!!! SOS", map[string]interface{}{"name": data.Name.ValueString(), "id": data.Id.ValueString()})
 
 	created, err := client.CreateResourceGroup(ctx, r.qlient, client.CreateIamResourceGroupInput{
@@ -94,7 +98,7 @@ func (r *ResourceGroupResource) Create(ctx context.Context, req resource.CreateR
 	tflog.Debug(ctx, "!!!! after created")
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create resource group", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create resource group", err)
 		return
 	}
 
@@ -117,12 +121,14 @@ func (r *ResourceGroupResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	updated, err := client.UpdateResourceGroup(ctx, r.qlient, client.UpdateIamResourceGroupInput{
 		Id:   data.Id.ValueString(),
 		Name: data.Name.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update resource group", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update resource group", err)
 		return
 	}
 
@@ -141,10 +147,12 @@ func (r *ResourceGroupResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.DeleteResourceGroup(ctx, r.qlient, client.DeleteIamResourceGroupInput{Id: data.Id.ValueString()})
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete resource group", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete resource group", err)
 		return
 	}
 }
@@ -158,13 +166,15 @@ func (r *ResourceGroupResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	resourceGroup, err := client.GetResourceGroup(ctx, r.qlient, data.Id.ValueString())
 
 	if err != nil {
-		if strings.Contains(err.Error(), "Item not found") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "Item not found") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to get resource group", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to get resource group", err)
 		}
 		return
 	}
@@ -175,5 +185,5 @@ func (r *ResourceGroupResource) Read(ctx context.Context, req resource.ReadReque
 }
 
 func (r *ResourceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
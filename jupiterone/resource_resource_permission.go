@@ -21,6 +21,7 @@ type ResourcePermissionResource struct {
 
 type ResourcePermissionModel struct {
 	ID           types.String `json:"id,omitempty" tfsdk:"id"`
+	AccountId    types.String `json:"account_id,omitempty" tfsdk:"account_id"`
 	SubjectType  types.String `json:"subjectType" tfsdk:"subject_type"`
 	SubjectId    types.String `json:"subjectId" tfsdk:"subject_id"`
 	ResourceArea types.String `json:"resourceArea" tfsdk:"resource_area"`
@@ -68,13 +69,14 @@ func (*ResourcePermissionResource) Schema(ctx context.Context, req resource.Sche
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"subject_type": schema.StringAttribute{
 				Required:    true,
-				Description: "The type of the subject that the resource permissions will be applied to. Possible values: group, token.",
+				Description: "The type of the subject that the resource permissions will be applied to. Possible values: group, user, token.",
 			},
 			"subject_id": schema.StringAttribute{
 				Required:    true,
-				Description: "The ID of the subject that the resource permissions will be applied to (e.g. group ID).",
+				Description: "The ID of the subject that the resource permissions will be applied to (e.g. group ID or user ID).",
 			},
 			"resource_area": schema.StringAttribute{
 				Required:    true,
@@ -133,6 +135,8 @@ func (r *ResourcePermissionResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	permissionResource, err := data.BuildSetResourcePermissionInput()
 
 	if err != nil {
@@ -143,7 +147,7 @@ func (r *ResourcePermissionResource) Create(ctx context.Context, req resource.Cr
 	created, err := client.SetResourcePermission(ctx, r.qlient, permissionResource)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create resource permission", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create resource permission", err)
 		return
 	}
 
@@ -174,6 +178,8 @@ func (r *ResourcePermissionResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	// A change in any of these fields should trigger a deletion of the old resource and a creation of a new one
 	shouldCreateNewPermissionSetFields := []struct {
 		newVal, oldVal attr.Value
@@ -199,7 +205,7 @@ func (r *ResourcePermissionResource) Update(ctx context.Context, req resource.Up
 				ResourceId:   state.ResourceId.ValueString()})
 
 			if err != nil {
-				resp.Diagnostics.AddError("Failed to delete resource permission", err.Error())
+				addAPIError(&resp.Diagnostics, "Failed to delete resource permission", err)
 				return
 			}
 
@@ -218,7 +224,7 @@ func (r *ResourcePermissionResource) Update(ctx context.Context, req resource.Up
 	updated, err := client.SetResourcePermission(ctx, r.qlient, permissionResource)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update resource permission", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update resource permission", err)
 		return
 	}
 
@@ -245,6 +251,8 @@ func (r *ResourcePermissionResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	var deleteInput client.DeleteResourcePermissionInput
 	deleteInput.SubjectType = data.SubjectType.ValueString()
 	deleteInput.SubjectId = data.SubjectId.ValueString()
@@ -255,7 +263,7 @@ func (r *ResourcePermissionResource) Delete(ctx context.Context, req resource.De
 	_, err := client.DeleteResourcePermission(ctx, r.qlient, deleteInput)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to delete resource permission", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete resource permission", err)
 		return
 	}
 }
@@ -269,6 +277,8 @@ func (r *ResourcePermissionResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	const maxResults = 10
 	// Check if this resource exists
 	resourcePermission, err := client.GetResourcePermissions(ctx, r.qlient, client.GetResourcePermissionsFilter{
@@ -280,7 +290,11 @@ func (r *ResourcePermissionResource) Read(ctx context.Context, req resource.Read
 	}, "", maxResults)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to get resource permission", err.Error())
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "failed to get resource permission", err)
 		return
 	}
 
@@ -292,5 +306,5 @@ func (r *ResourcePermissionResource) Read(ctx context.Context, req resource.Read
 }
 
 func (r *ResourcePermissionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
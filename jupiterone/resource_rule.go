@@ -2,7 +2,6 @@ package jupiterone
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
@@ -17,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
@@ -63,6 +63,7 @@ type J1QueryInputModel struct {
 type QuestionRuleResource struct {
 	version string
 	qlient  graphql.Client
+	j1ql    *j1qlSettings
 }
 
 type RuleQuestion struct {
@@ -70,8 +71,10 @@ type RuleQuestion struct {
 }
 
 type RuleOperation struct {
-	When    types.String `json:"when" tfsdk:"when"`
-	Actions []string     `json:"actions" tfsdk:"actions"`
+	When         JSONValue      `json:"when" tfsdk:"when"`
+	Condition    *RuleCondition `json:"condition" tfsdk:"condition"`
+	Actions      []string       `json:"actions" tfsdk:"actions"`
+	TypedActions []RuleAction   `json:"typed_actions" tfsdk:"typed_actions"`
 }
 
 type RuleLabel struct {
@@ -80,37 +83,85 @@ type RuleLabel struct {
 }
 
 // newOperationsWithoutId removes any "id" fields before saving into state.
-func newOperationsWithoutId(ops []client.RuleOperationOutput) ([]RuleOperation, error) {
+// The condition and actions of each operation are read in the form used by
+// the matching operation of prior, the operations in the current state.
+func newOperationsWithoutId(ops []client.RuleOperationOutput, prior []RuleOperation) ([]RuleOperation, error) {
+	priorOps := matchPriorOperations(ops, prior)
+
 	l := make([]RuleOperation, 0, len(ops))
-	for _, o := range ops {
+	for i, o := range ops {
 
-		op := RuleOperation{
-			Actions: make([]string, 0, len(o.Actions)),
-		}
+		op := RuleOperation{}
+		priorOp := priorOps[i]
 
-		if o.When != nil {
-			w, err := json.Marshal(o.When)
-			if err != nil {
-				return nil, err
+		if !o.When.IsNull() && priorOp != nil && priorOp.Condition != nil {
+			if c, ok := readRuleCondition(o.When, priorOp.Condition); ok {
+				op.Condition = c
 			}
-			op.When = types.StringValue(string(w))
+		}
+		if !o.When.IsNull() && op.Condition == nil {
+			op.When = NewJSONValueFrom(o.When)
+		}
+		if err := op.readActions(o.Actions, priorOp); err != nil {
+			return nil, err
 		}
 
-		for _, action := range o.Actions {
-			if actionMap, ok := action.(map[string]interface{}); ok {
-				delete(actionMap, "id")
-			}
+		l = append(l, op)
+	}
+	return l, nil
+}
 
-			a, err := json.Marshal(action)
-			if err != nil {
-				return nil, err
+// matchPriorOperations returns the prior operation of each operation of the
+// API: the prior operation with the same content, so that operations are
+// matched when their order changes, or else the prior operation at the same
+// index if it did not match another operation.
+func matchPriorOperations(ops []client.RuleOperationOutput, prior []RuleOperation) []*RuleOperation {
+	matched := make([]*RuleOperation, len(ops))
+	used := make([]bool, len(prior))
+
+	for i, o := range ops {
+		for j := range prior {
+			if !used[j] && prior[j].matches(o) {
+				matched[i] = &prior[j]
+				used[j] = true
+				break
 			}
-			op.Actions = append(op.Actions, string(a))
 		}
+	}
 
-		l = append(l, op)
+	for i := range ops {
+		if matched[i] == nil && i < len(prior) && !used[i] {
+			matched[i] = &prior[i]
+			used[i] = true
+		}
 	}
-	return l, nil
+	return matched
+}
+
+// matches returns whether the operation of the API has the content of the
+// operation, ignoring the ids of its actions.
+func (o RuleOperation) matches(output client.RuleOperationOutput) bool {
+	input, err := o.build()
+	if err != nil || len(input.Actions) != len(output.Actions) {
+		return false
+	}
+
+	var when, outputWhen interface{}
+	if input.When.Decode(&when) != nil || output.When.Decode(&outputWhen) != nil || !reflect.DeepEqual(when, outputWhen) {
+		return false
+	}
+
+	for i := range input.Actions {
+		var action, outputAction map[string]interface{}
+		if input.Actions[i].Decode(&action) != nil || output.Actions[i].Decode(&outputAction) != nil {
+			return false
+		}
+		delete(outputAction, "id")
+		if !reflect.DeepEqual(action, outputAction) {
+			return false
+		}
+	}
+	return true
 }
 
 // tagsToStringSlice converts a types.List to []string
@@ -248,6 +299,7 @@ func ensureLabelsInitialized(labels types.List) (types.List, diag.Diagnostics) {
 // RuleModel represents the terraform representation of the rule
 type RuleModel struct {
 	Id              types.String      `json:"id,omitempty" tfsdk:"id"`
+	AccountId       types.String      `json:"account_id,omitempty" tfsdk:"account_id"`
 	Name            types.String      `json:"name" tfsdk:"name"`
 	Description     types.String      `json:"description" tfsdk:"description"`
 	Version         types.Int64       `json:"version,omitempty" tfsdk:"version"`
@@ -266,6 +318,14 @@ type RuleModel struct {
 	IgnorePreviousResults types.Bool      `json:"ignore_previous_results" tfsdk:"ignore_previous_results"`
 	Labels                types.List      `json:"labels" tfsdk:"labels"`
 	ResourceGroupId       types.String    `json:"resource_group_id,omitempty" tfsdk:"resource_group_id"`
+	Preview               types.Bool      `json:"preview,omitempty" tfsdk:"preview"`
+
+	LatestAlertId                 types.String `json:"latest_alert_id" tfsdk:"latest_alert_id"`
+	LatestAlertIsActive           types.Bool   `json:"latest_alert_is_active" tfsdk:"latest_alert_is_active"`
+	LastEvaluationEndOn           types.Int64  `json:"last_evaluation_end_on" tfsdk:"last_evaluation_end_on"`
+	LastSuccessfulEvaluationEndOn types.Int64  `json:"last_successful_evaluation_end_on" tfsdk:"last_successful_evaluation_end_on"`
+	LastFailedEvaluationEndOn     types.Int64  `json:"last_failed_evaluation_end_on" tfsdk:"last_failed_evaluation_end_on"`
+	EvaluationStep                types.String `json:"evaluation_step" tfsdk:"evaluation_step"`
 }
 
 func NewQuestionRuleResource() resource.Resource {
@@ -297,6 +357,7 @@ func (r *QuestionRuleResource) Configure(ctx context.Context, req resource.Confi
 
 	r.version = p.version
 	r.qlient = p.Qlient
+	r.j1ql = &p.j1ql
 }
 
 // Schema implements resource.ResourceWithConfigure
@@ -311,6 +372,7 @@ func (*QuestionRuleResource) Schema(ctx context.Context, req resource.SchemaRequ
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"version": schema.Int64Attribute{
 				Description: "Computed current version of the rule. Incremented each time the rule is updated.",
 				Computed:    true,
@@ -350,7 +412,7 @@ func (*QuestionRuleResource) Schema(ctx context.Context, req resource.SchemaRequ
 				},
 			},
 			"templates": schema.MapAttribute{
-				Description: "Optional key/value pairs of template name to template",
+				Description: "Optional key/value pairs of template name to template. Templates are referenced as `{{templates.<name>}}`, references to missing templates or queries are reported before apply.",
 				ElementType: types.StringType,
 				Optional:    true,
 			},
@@ -364,23 +426,24 @@ func (*QuestionRuleResource) Schema(ctx context.Context, req resource.SchemaRequ
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"when": schema.StringAttribute{
-							Description: "A JSON object that specifies the condition to evaluate before executing the actions. Required when `trigger_on_new_only` is enabled.",
+							Description: "A JSON object that specifies the condition to evaluate before executing the actions. Required, or `condition`, when `trigger_on_new_only` is enabled.",
 							Optional:    true,
+							CustomType:  JSONType{},
 							Validators: []validator.String{
 								stringvalidator.LengthAtLeast(MIN_JSON_LENGTH),
 							},
-							PlanModifiers: []planmodifier.String{
-								jsonIgnoreDiffPlanModifier(),
-							},
 						},
+						"condition": ruleConditionAttribute(),
 						"actions": schema.ListAttribute{
-							Required:    true,
-							ElementType: types.StringType,
-							Validators:  []validator.List{},
-							PlanModifiers: []planmodifier.List{
-								jsonIgnoreDiffPlanModifierList(),
+							Description: "JSON objects of the actions to execute. Use `typed_actions` instead to configure actions with typed attributes, such as `send_email`.",
+							Optional:    true,
+							ElementType: JSONType{},
+							CustomType:  NewJSONListType(),
+							Validators: []validator.List{
+								ruleActionsValidator(),
 							},
 						},
+						"typed_actions": ruleTypedActionsAttribute(),
 					},
 				},
 			},
@@ -441,6 +504,52 @@ func (*QuestionRuleResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Optional:    true,
 				Description: "Specifies the ID of a resource group for the rule to be added to",
 			},
+			"preview": schema.BoolAttribute{
+				Optional:    true,
+				Description: previewDescription,
+			},
+			"latest_alert_id": schema.StringAttribute{
+				Computed:    true,
+				Description: ruleStatusDescriptions["latest_alert_id"],
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"latest_alert_is_active": schema.BoolAttribute{
+				Computed:    true,
+				Description: ruleStatusDescriptions["latest_alert_is_active"],
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_evaluation_end_on": schema.Int64Attribute{
+				Computed:    true,
+				Description: ruleStatusDescriptions["last_evaluation_end_on"],
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_successful_evaluation_end_on": schema.Int64Attribute{
+				Computed:    true,
+				Description: ruleStatusDescriptions["last_successful_evaluation_end_on"],
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_failed_evaluation_end_on": schema.Int64Attribute{
+				Computed:    true,
+				Description: ruleStatusDescriptions["last_failed_evaluation_end_on"],
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"evaluation_step": schema.StringAttribute{
+				Computed:    true,
+				Description: ruleStatusDescriptions["evaluation_step"],
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 		// TODO: Deprecate the use of blocks following new framework guidance:
 		// https://developer.hashicorp.com/terraform/plugin/framework/handling-data/blocks
@@ -460,10 +569,16 @@ func (*QuestionRuleResource) Schema(ctx context.Context, req resource.SchemaRequ
 										},
 									},
 									"query": schema.StringAttribute{
-										Required: true,
+										CustomType: J1QLQueryType{},
+										Optional:   true,
+										Computed:   true,
 										Validators: []validator.String{
+											configuredQuery(),
 											stringvalidator.LengthAtLeast(1),
 										},
+										PlanModifiers: []planmodifier.String{
+											useStateForEquivalentQuery(),
+										},
 									},
 									"version": schema.StringAttribute{
 										Required: true,
@@ -493,7 +608,7 @@ func (*QuestionRuleResource) Schema(ctx context.Context, req resource.SchemaRequ
 }
 
 // ConfigValidators implements resource.ResourceWithConfigValidators
-func (*QuestionRuleResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+func (r *QuestionRuleResource) ConfigValidators(context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
 		resourcevalidator.Conflicting(
 			path.MatchRoot("question"),
@@ -507,24 +622,43 @@ func (*QuestionRuleResource) ConfigValidators(context.Context) []resource.Config
 			path.MatchRoot("trigger_on_new_only"),
 			path.MatchRoot("ignore_previous_results"),
 		),
+		ruleReferencesValidator{},
+		j1qlValidator{
+			settings:        r.j1ql,
+			queries:         path.MatchRoot("question").AtAnyListIndex().AtName("queries").AtAnyListIndex().AtName("query"),
+			pollingInterval: path.Root("polling_interval"),
+			alertRule:       true,
+		},
 	}
 }
 
-// ModifyPlan is a workaround for unexpected behavior in the framework around
-// the `computed: true` `version` field to make sure that it is only part of
-// the plan if there is some other change in the resource.
+// ModifyPlan previews the rule when `preview` is enabled, and is a workaround
+// for unexpected behavior in the framework around the `computed: true`
+// `version` field to make sure that it is only part of the plan if there is
+// some other change in the resource.
 //
 // Based on the implementation of the Time resource:
 // https://github.com/hashicorp/terraform-provider-time/blob/main/internal/provider/resource_time_rotating.go#L189-L234
 //
 // This may be a bug in the framework, if so, this can be removed when fixed:
 // https://github.com/hashicorp/terraform-plugin-framework/issues/628
-func (*QuestionRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+func (r *QuestionRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
 	// Plan does not need to be modified when the resource is being destroyed.
 	if req.Plan.Raw.IsNull() {
 		return
 	}
 
+	var preview types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("preview"), &preview)...)
+	if previewEnabled(preview) {
+		var rule RuleModel
+		// The rule cannot be previewed while parts of it are unknown.
+		if diags := req.Plan.Get(ctx, &rule); !diags.HasError() && !rule.AccountId.IsUnknown() {
+			previewCtx, _ := withAccountContext(ctx, r.qlient, rule.AccountId)
+			resp.Diagnostics.Append(previewRule(previewCtx, r.qlient, &rule)...)
+		}
+	}
+
 	// Plan only needs modifying if the resource already exists as the purpose of
 	// the plan modifier is to show updated attribute values on CLI.
 	if req.State.Raw.IsNull() {
@@ -543,6 +677,11 @@ func (*QuestionRuleResource) ModifyPlan(ctx context.Context, req resource.Modify
 	if !reflect.DeepEqual(plan, state) {
 		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version"),
 			types.Int64Unknown())...)
+
+		// The update returns the current evaluation status of the rule.
+		for name, unknown := range ruleStatusUnknowns {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), unknown)...)
+		}
 	}
 }
 
@@ -555,6 +694,15 @@ type IdVersioner interface {
 	GetId() string
 }
 
+// ruleInputRenames maps the fields of the rule inputs to the attributes they
+// are built from where the names differ.
+var ruleInputRenames = map[string]string{
+	"triggerActionsOnNewEntitiesOnly": "trigger_on_new_only",
+	// The actions of an operation are built from both `actions` and
+	// `typed_actions`.
+	"actions": "",
+}
+
 // Create implements resource.ResourceWithConfigure
 func (r *QuestionRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data *RuleModel
@@ -566,7 +714,12 @@ func (r *QuestionRuleResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	var c IdVersioner
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
+	var c interface {
+		IdVersioner
+		RuleEvaluationStatus
+	}
 	if len(data.Question) > 0 {
 		rule, err := data.BuildCreateInlineQuestionRuleInstanceInput()
 		if err != nil {
@@ -576,7 +729,7 @@ func (r *QuestionRuleResource) Create(ctx context.Context, req resource.CreateRe
 
 		created, err := client.CreateInlineQuestionRuleInstance(ctx, r.qlient, rule)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to create rule", err.Error())
+			addInputError(ctx, &resp.Diagnostics, req.Plan.Schema, "failed to create rule", err, ruleInputRenames)
 			return
 		}
 		c = &created.CreateQuestionRuleInstance
@@ -589,7 +742,7 @@ func (r *QuestionRuleResource) Create(ctx context.Context, req resource.CreateRe
 
 		created, err := client.CreateReferencedQuestionRuleInstance(ctx, r.qlient, rule)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to create rule", err.Error())
+			addInputError(ctx, &resp.Diagnostics, req.Plan.Schema, "failed to create rule", err, ruleInputRenames)
 			return
 		}
 		c = &created.CreateQuestionRuleInstance
@@ -597,6 +750,7 @@ func (r *QuestionRuleResource) Create(ctx context.Context, req resource.CreateRe
 
 	data.Id = types.StringValue(c.GetId())
 	data.Version = types.Int64Value(int64(c.GetVersion()))
+	data.setEvaluationStatus(c)
 
 	var diags diag.Diagnostics
 	data.Labels, diags = ensureLabelsInitialized(data.Labels)
@@ -622,8 +776,10 @@ func (r *QuestionRuleResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteRuleInstance(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("failed to delete rule", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete rule", err)
 	}
 }
 
@@ -638,12 +794,14 @@ func (r *QuestionRuleResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	ctx, oldData.AccountId = withAccountContext(ctx, r.qlient, oldData.AccountId)
+
 	getResp, err := client.GetQuestionRuleInstance(ctx, r.qlient, oldData.Id.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "does not exist") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "does not exist") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to get rule", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to get rule", err)
 		}
 		return
 	}
@@ -682,6 +840,7 @@ func (r *QuestionRuleResource) Read(ctx context.Context, req resource.ReadReques
 
 	data := RuleModel{
 		Id:                    types.StringValue(rule.Id),
+		AccountId:             oldData.AccountId,
 		Name:                  types.StringValue(rule.Name),
 		Description:           types.StringValue(rule.Description),
 		Version:               types.Int64Value(int64(rule.Version)),
@@ -692,15 +851,11 @@ func (r *QuestionRuleResource) Read(ctx context.Context, req resource.ReadReques
 		NotifyOnFailure:       types.BoolValue(rule.NotifyOnFailure),
 		TriggerOnNewOnly:      types.BoolValue(rule.TriggerActionsOnNewEntitiesOnly),
 		IgnorePreviousResults: types.BoolValue(rule.IgnorePreviousResults),
+		Preview:               oldData.Preview,
 	}
+	data.setEvaluationStatus(&rule)
 
-	// FIXME: handling of these JSON fields (map[string]interface{}) is not DRY
-	templates, err := json.Marshal(rule.Templates)
-	if err != nil {
-		resp.Diagnostics.AddError("error marshaling templates from response", err.Error())
-	}
-	err = json.Unmarshal(templates, &data.Templates)
-	if err != nil {
+	if err := rule.Templates.Decode(&data.Templates); err != nil {
 		resp.Diagnostics.AddError("error unmarshaling templates from response", err.Error())
 	}
 
@@ -734,7 +889,7 @@ func (r *QuestionRuleResource) Read(ctx context.Context, req resource.ReadReques
 		data.Question = nil
 	}
 
-	data.Operations, err = newOperationsWithoutId(rule.Operations)
+	data.Operations, err = newOperationsWithoutId(rule.Operations, oldData.Operations)
 	if err != nil {
 		resp.Diagnostics.AddError("error unmarshaling templates from response", err.Error())
 	}
@@ -753,7 +908,7 @@ func (r *QuestionRuleResource) Read(ctx context.Context, req resource.ReadReques
 
 // ImportState implements resource.ResourceWithImportState
 func (*QuestionRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Update implements resource.ResourceWithConfigure
@@ -767,6 +922,8 @@ func (r *QuestionRuleResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	// The UpdateRule operation needs the most current version of the rule to update it.
 	// We fetch it from the state if it is not specified by the user.
 	if data.Version.IsUnknown() {
@@ -778,7 +935,10 @@ func (r *QuestionRuleResource) Update(ctx context.Context, req resource.UpdateRe
 		data.Version = state.Version
 	}
 
-	var update Versioner
+	var update interface {
+		Versioner
+		RuleEvaluationStatus
+	}
 	if len(data.Question) > 0 {
 		rule, err := data.BuildUpdateInlineQuestionRuleInstanceInput()
 		if err != nil {
@@ -788,7 +948,7 @@ func (r *QuestionRuleResource) Update(ctx context.Context, req resource.UpdateRe
 
 		updated, err := client.UpdateInlineQuestionRuleInstance(ctx, r.qlient, rule)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to update inline question rule", err.Error())
+			addInputError(ctx, &resp.Diagnostics, req.Plan.Schema, "failed to update inline question rule", err, ruleInputRenames)
 			return
 		}
 		update = &updated.UpdateInlineQuestionRuleInstance
@@ -801,13 +961,14 @@ func (r *QuestionRuleResource) Update(ctx context.Context, req resource.UpdateRe
 
 		updated, err := client.UpdateReferencedQuestionRuleInstance(ctx, r.qlient, rule)
 		if err != nil {
-			resp.Diagnostics.AddError("failed to update referenced question rule", err.Error())
+			addInputError(ctx, &resp.Diagnostics, req.Plan.Schema, "failed to update referenced question rule", err, ruleInputRenames)
 			return
 		}
 		update = &updated.UpdateReferencedQuestionRuleInstance
 	}
 
 	data.Version = types.Int64Value(int64(update.GetVersion()))
+	data.setEvaluationStatus(update)
 
 	var diags diag.Diagnostics
 	data.Labels, diags = ensureLabelsInitialized(data.Labels)
@@ -825,31 +986,32 @@ func (r *QuestionRuleResource) Update(ctx context.Context, req resource.UpdateRe
 func (r *RuleModel) buildOperations() ([]client.RuleOperationInput, error) {
 	ops := make([]client.RuleOperationInput, 0, len(r.Operations))
 	for _, o := range r.Operations {
-		op := client.RuleOperationInput{}
-		if !o.When.IsNull() {
-			err := json.Unmarshal([]byte(o.When.ValueString()), &op.When)
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		for _, action := range o.Actions {
-			var a map[string]interface{}
-			err := json.Unmarshal([]byte(action), &a)
-			if err != nil {
-				return nil, err
-			}
-			// NOTE: "id" should not be saved as currently implemented, so any
-			// "id" value in the input would be coming from the config
-			delete(a, "id")
-			op.Actions = append(op.Actions, a)
+		op, err := o.build()
+		if err != nil {
+			return nil, err
 		}
-
 		ops = append(ops, op)
 	}
 	return ops, nil
 }
 
+// build returns the operation for the API.
+func (o RuleOperation) build() (client.RuleOperationInput, error) {
+	op := client.RuleOperationInput{}
+	var err error
+	if o.Condition != nil {
+		op.When, err = client.NewJSON(o.Condition.build())
+	} else {
+		op.When, err = o.When.JSON()
+	}
+	if err != nil {
+		return op, err
+	}
+
+	op.Actions, err = o.buildActions()
+	return op, err
+}
+
 func (r *RuleModel) BuildCreateReferencedQuestionRuleInstanceInput() (client.CreateReferencedQuestionRuleInstanceInput, error) {
 	rule := client.CreateReferencedQuestionRuleInstanceInput{
 		QuestionId:                      r.QuestionId.ValueString(),
@@ -871,11 +1033,7 @@ func (r *RuleModel) BuildCreateReferencedQuestionRuleInstanceInput() (client.Cre
 	if err != nil {
 		return rule, err
 	}
-	templates, err := json.Marshal(r.Templates)
-	if err != nil {
-		return rule, err
-	}
-	err = json.Unmarshal(templates, &rule.Templates)
+	rule.Templates, err = client.NewJSON(r.Templates)
 	if err != nil {
 		return rule, err
 	}
@@ -906,11 +1064,7 @@ func (r *RuleModel) BuildUpdateReferencedQuestionRuleInstanceInput() (client.Upd
 	if err != nil {
 		return rule, err
 	}
-	templates, err := json.Marshal(r.Templates)
-	if err != nil {
-		return rule, err
-	}
-	err = json.Unmarshal(templates, &rule.Templates)
+	rule.Templates, err = client.NewJSON(r.Templates)
 	if err != nil {
 		return rule, err
 	}
@@ -943,11 +1097,7 @@ func (r *RuleModel) BuildCreateInlineQuestionRuleInstanceInput() (client.CreateI
 	if err != nil {
 		return rule, err
 	}
-	templates, err := json.Marshal(r.Templates)
-	if err != nil {
-		return rule, err
-	}
-	err = json.Unmarshal(templates, &rule.Templates)
+	rule.Templates, err = client.NewJSON(r.Templates)
 	if err != nil {
 		return rule, err
 	}
@@ -994,11 +1144,7 @@ func (r *RuleModel) BuildUpdateInlineQuestionRuleInstanceInput() (client.UpdateI
 		return rule, err
 	}
 
-	templates, err := json.Marshal(r.Templates)
-	if err != nil {
-		return rule, err
-	}
-	err = json.Unmarshal(templates, &rule.Templates)
+	rule.Templates, err = client.NewJSON(r.Templates)
 	if err != nil {
 		return rule, err
 	}
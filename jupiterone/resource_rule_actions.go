@@ -0,0 +1,611 @@
+package jupiterone
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+)
+
+// Action types of rule operations that have typed attributes. Actions of
+// other types are configured as JSON in `actions`.
+const (
+	ActionSendEmail        = "SEND_EMAIL"
+	ActionSendSlackMessage = "SEND_SLACK_MESSAGE"
+	ActionCreateJiraTicket = "CREATE_JIRA_TICKET"
+	ActionSetProperty      = "SET_PROPERTY"
+	ActionTagEntities      = "TAG_ENTITIES"
+)
+
+type SendEmailAction struct {
+	Recipients []string     `tfsdk:"recipients"`
+	Body       types.String `tfsdk:"body"`
+}
+
+type SendSlackMessageAction struct {
+	IntegrationInstanceId types.String `tfsdk:"integration_instance_id"`
+	Channels              []string     `tfsdk:"channels"`
+	Body                  types.String `tfsdk:"body"`
+}
+
+type CreateJiraTicketAction struct {
+	IntegrationInstanceId  types.String `tfsdk:"integration_instance_id"`
+	Project                types.String `tfsdk:"project"`
+	IssueType              types.String `tfsdk:"issue_type"`
+	Summary                types.String `tfsdk:"summary"`
+	EntityClass            types.String `tfsdk:"entity_class"`
+	AutoResolve            types.Bool   `tfsdk:"auto_resolve"`
+	ResolvedStatus         types.String `tfsdk:"resolved_status"`
+	UpdateContentOnChanges types.Bool   `tfsdk:"update_content_on_changes"`
+	AdditionalFields       JSONValue    `tfsdk:"additional_fields"`
+}
+
+type SetPropertyAction struct {
+	TargetProperty types.String `tfsdk:"target_property"`
+	TargetValue    JSONValue    `tfsdk:"target_value"`
+}
+
+type TagEntitiesAction struct {
+	Entities types.String `tfsdk:"entities"`
+	Tags     []EntityTag  `tfsdk:"tags"`
+}
+
+type EntityTag struct {
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+// RuleAction is an element of `typed_actions`, exactly one of its attributes
+// is set.
+type RuleAction struct {
+	SendEmail        *SendEmailAction        `tfsdk:"send_email"`
+	SendSlackMessage *SendSlackMessageAction `tfsdk:"send_slack_message"`
+	CreateJiraTicket *CreateJiraTicketAction `tfsdk:"create_jira_ticket"`
+	SetProperty      *SetPropertyAction      `tfsdk:"set_property"`
+	TagEntities      *TagEntitiesAction      `tfsdk:"tag_entities"`
+	JSON             JSONValue               `tfsdk:"json"`
+}
+
+// ruleTypedActionsAttribute is the `typed_actions` attribute of an operation.
+func ruleTypedActionsAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: "The actions to execute, in the order they are sent to the API. Each element configures exactly one action, either with the attribute of its type or as `json`. Conflicts with `actions`.",
+		Optional:    true,
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+			listvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("actions")),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"json": schema.StringAttribute{
+					Description: "A JSON object of an action of any type.",
+					Optional:    true,
+					CustomType:  JSONType{},
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(MIN_JSON_LENGTH),
+						stringvalidator.ExactlyOneOf(
+							path.MatchRelative().AtParent().AtName("send_email"),
+							path.MatchRelative().AtParent().AtName("send_slack_message"),
+							path.MatchRelative().AtParent().AtName("create_jira_ticket"),
+							path.MatchRelative().AtParent().AtName("set_property"),
+							path.MatchRelative().AtParent().AtName("tag_entities"),
+						),
+					},
+				},
+				"send_email": schema.SingleNestedAttribute{
+					Description: "Sends an email to the recipients (`SEND_EMAIL` action).",
+					Optional:    true,
+					Attributes: map[string]schema.Attribute{
+						"recipients": schema.ListAttribute{
+							Description: "Email addresses to send the alert to.",
+							ElementType: types.StringType,
+							Required:    true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+						"body": schema.StringAttribute{
+							Description: "Body of the email, which can use templates.",
+							Optional:    true,
+						},
+					},
+				},
+				"send_slack_message": schema.SingleNestedAttribute{
+					Description: "Sends a message to Slack channels (`SEND_SLACK_MESSAGE` action).",
+					Optional:    true,
+					Attributes: map[string]schema.Attribute{
+						"integration_instance_id": schema.StringAttribute{
+							Description: "ID of the Slack integration instance.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"channels": schema.ListAttribute{
+							Description: "Channels to send the message to, e.g. `#alerts`.",
+							ElementType: types.StringType,
+							Required:    true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+						"body": schema.StringAttribute{
+							Description: "Body of the message, which can use templates.",
+							Optional:    true,
+						},
+					},
+				},
+				"create_jira_ticket": schema.SingleNestedAttribute{
+					Description: "Creates a Jira issue (`CREATE_JIRA_TICKET` action).",
+					Optional:    true,
+					Attributes: map[string]schema.Attribute{
+						"integration_instance_id": schema.StringAttribute{
+							Description: "ID of the Jira integration instance.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"project": schema.StringAttribute{
+							Description: "Key of the Jira project to create the issue in.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"issue_type": schema.StringAttribute{
+							Description: "Type of the issue, e.g. `Task`.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"summary": schema.StringAttribute{
+							Description: "Summary of the issue, which can use templates.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"entity_class": schema.StringAttribute{
+							Description: "Class of the entity created for the issue in JupiterOne.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"auto_resolve": schema.BoolAttribute{
+							Description: "Resolve the issue when the rule no longer matches.",
+							Optional:    true,
+						},
+						"resolved_status": schema.StringAttribute{
+							Description: "Status the issue is moved to when it is resolved automatically.",
+							Optional:    true,
+						},
+						"update_content_on_changes": schema.BoolAttribute{
+							Description: "Update the issue when the results of the rule change.",
+							Optional:    true,
+						},
+						"additional_fields": schema.StringAttribute{
+							Description: "A JSON object of additional Jira fields, e.g. the `description` document.",
+							Optional:    true,
+							CustomType:  JSONType{},
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(MIN_JSON_LENGTH),
+							},
+						},
+					},
+				},
+				"set_property": schema.SingleNestedAttribute{
+					Description: "Sets a property of the rule evaluation (`SET_PROPERTY` action).",
+					Optional:    true,
+					Attributes: map[string]schema.Attribute{
+						"target_property": schema.StringAttribute{
+							Description: "Name of the property, e.g. `alertLevel`.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"target_value": schema.StringAttribute{
+							Description: "JSON encoded value of the property, so that numbers and booleans keep their type, e.g. `jsonencode(\"HIGH\")` or `jsonencode(3)`.",
+							Required:    true,
+							CustomType:  JSONType{},
+						},
+					},
+				},
+				"tag_entities": schema.SingleNestedAttribute{
+					Description: "Adds tags to entities (`TAG_ENTITIES` action).",
+					Optional:    true,
+					Attributes: map[string]schema.Attribute{
+						"entities": schema.StringAttribute{
+							Description: "Template of the entities to tag, e.g. `{{queries.query0.data}}`.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"tags": schema.ListNestedAttribute{
+							Description: "Tags to add to the entities.",
+							Required:    true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Description: "Name of the tag.",
+										Required:    true,
+										Validators: []validator.String{
+											stringvalidator.LengthAtLeast(1),
+										},
+									},
+									"value": schema.StringAttribute{
+										Description: "Value of the tag.",
+										Optional:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ruleActionsValidator requires every operation to have at least one action,
+// either in `actions` or in `typed_actions`.
+func ruleActionsValidator() validator.List {
+	return listvalidator.AtLeastOneOf(
+		path.MatchRelative().AtParent().AtName("typed_actions"),
+	)
+}
+
+// buildActions returns the actions of the operation for the API, in the order
+// of `actions` or `typed_actions`.
+func (o RuleOperation) buildActions() ([]client.JSON, error) {
+	var actions []map[string]interface{}
+
+	for _, action := range o.Actions {
+		a, err := decodeAction(action)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, a)
+	}
+
+	for _, action := range o.TypedActions {
+		a, err := action.build()
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, a)
+	}
+
+	encoded := make([]client.JSON, len(actions))
+	for i, a := range actions {
+		var err error
+		if encoded[i], err = client.NewJSON(a); err != nil {
+			return nil, err
+		}
+	}
+	return encoded, nil
+}
+
+// decodeAction decodes a JSON action of the config.
+func decodeAction(action string) (map[string]interface{}, error) {
+	var a map[string]interface{}
+	if err := json.Unmarshal([]byte(action), &a); err != nil {
+		return nil, err
+	}
+	// NOTE: "id" should not be saved as currently implemented, so any
+	// "id" value in the input would be coming from the config
+	delete(a, "id")
+	return a, nil
+}
+
+// build returns the action for the API.
+func (r RuleAction) build() (map[string]interface{}, error) {
+	switch {
+	case r.SendEmail != nil:
+		action := map[string]interface{}{
+			"type":       ActionSendEmail,
+			"recipients": r.SendEmail.Recipients,
+		}
+		setActionString(action, "body", r.SendEmail.Body)
+		return action, nil
+	case r.SendSlackMessage != nil:
+		action := map[string]interface{}{
+			"type":                  ActionSendSlackMessage,
+			"integrationInstanceId": r.SendSlackMessage.IntegrationInstanceId.ValueString(),
+			"channels":              r.SendSlackMessage.Channels,
+		}
+		setActionString(action, "body", r.SendSlackMessage.Body)
+		return action, nil
+	case r.CreateJiraTicket != nil:
+		a := r.CreateJiraTicket
+		action := map[string]interface{}{
+			"type":                  ActionCreateJiraTicket,
+			"integrationInstanceId": a.IntegrationInstanceId.ValueString(),
+			"project":               a.Project.ValueString(),
+			"issueType":             a.IssueType.ValueString(),
+			"summary":               a.Summary.ValueString(),
+			"entityClass":           a.EntityClass.ValueString(),
+		}
+		setActionBool(action, "autoResolve", a.AutoResolve)
+		setActionString(action, "resolvedStatus", a.ResolvedStatus)
+		setActionBool(action, "updateContentOnChanges", a.UpdateContentOnChanges)
+		if !a.AdditionalFields.IsNull() {
+			fields, err := a.AdditionalFields.JSON()
+			if err != nil {
+				return nil, fmt.Errorf("additional_fields of %s action: %w", ActionCreateJiraTicket, err)
+			}
+			action["additionalFields"] = fields
+		}
+		return action, nil
+	case r.SetProperty != nil:
+		value, err := r.SetProperty.TargetValue.JSON()
+		if err != nil {
+			return nil, fmt.Errorf("target_value of %s action: %w", ActionSetProperty, err)
+		}
+		return map[string]interface{}{
+			"type":           ActionSetProperty,
+			"targetProperty": r.SetProperty.TargetProperty.ValueString(),
+			"targetValue":    value,
+		}, nil
+	case r.TagEntities != nil:
+		tags := make([]interface{}, 0, len(r.TagEntities.Tags))
+		for _, t := range r.TagEntities.Tags {
+			tag := map[string]interface{}{"name": t.Name.ValueString()}
+			setActionString(tag, "value", t.Value)
+			tags = append(tags, tag)
+		}
+		return map[string]interface{}{
+			"type":     ActionTagEntities,
+			"entities": r.TagEntities.Entities.ValueString(),
+			"tags":     tags,
+		}, nil
+	default:
+		return decodeAction(r.JSON.ValueString())
+	}
+}
+
+// actionType returns the type of a typed action, or "" for a JSON action.
+func (r RuleAction) actionType() string {
+	switch {
+	case r.SendEmail != nil:
+		return ActionSendEmail
+	case r.SendSlackMessage != nil:
+		return ActionSendSlackMessage
+	case r.CreateJiraTicket != nil:
+		return ActionCreateJiraTicket
+	case r.SetProperty != nil:
+		return ActionSetProperty
+	case r.TagEntities != nil:
+		return ActionTagEntities
+	default:
+		return ""
+	}
+}
+
+// readActions sets the actions of the operation from the API, in the form the
+// prior operation configured them. Operations that configured `actions`, and
+// imported operations, which have no prior operation, read every action as
+// JSON into `actions`. Operations that configured `typed_actions` read every
+// action into an element of `typed_actions`: in the form of the prior element
+// with the same content if there is one, typed if the prior operation has a
+// typed action of its type, and as `json` otherwise.
+func (o *RuleOperation) readActions(actions []client.JSON, prior *RuleOperation) error {
+	typed := prior != nil && prior.TypedActions != nil
+
+	var priorActions []interface{}
+	if typed {
+		for _, action := range prior.TypedActions {
+			a, err := action.build()
+			if err != nil {
+				// The prior element cannot match the content of an action.
+				priorActions = append(priorActions, nil)
+				continue
+			}
+			priorActions = append(priorActions, normalizeAction(a))
+		}
+		o.TypedActions = []RuleAction{}
+	}
+	matched := make([]bool, len(priorActions))
+
+	for _, action := range actions {
+		var a map[string]interface{}
+		if err := action.Decode(&a); err != nil || a == nil {
+			if typed {
+				o.TypedActions = append(o.TypedActions, RuleAction{JSON: NewJSONValue(action.String())})
+			} else {
+				o.Actions = append(o.Actions, action.String())
+			}
+			continue
+		}
+		delete(a, "id")
+
+		raw, err := json.Marshal(a)
+		if err != nil {
+			return err
+		}
+		if !typed {
+			o.Actions = append(o.Actions, string(raw))
+			continue
+		}
+
+		actionType, _ := a["type"].(string)
+		asJSON := true
+		for _, p := range prior.TypedActions {
+			if p.actionType() == actionType && actionType != "" {
+				asJSON = false
+			}
+		}
+		normalized := normalizeAction(a)
+		for i, p := range priorActions {
+			if !matched[i] && p != nil && reflect.DeepEqual(p, normalized) {
+				matched[i] = true
+				asJSON = prior.TypedActions[i].actionType() == ""
+				break
+			}
+		}
+
+		if asJSON {
+			o.TypedActions = append(o.TypedActions, RuleAction{JSON: NewJSONValue(string(raw))})
+			continue
+		}
+		typedAction, err := readTypedAction(actionType, a)
+		if err != nil {
+			return err
+		}
+		o.TypedActions = append(o.TypedActions, typedAction)
+	}
+
+	// Keep `actions` null for operations that configure `typed_actions`.
+	if o.Actions == nil && !typed {
+		o.Actions = []string{}
+	}
+
+	return nil
+}
+
+// readTypedAction reads an action of the API into its typed attribute.
+func readTypedAction(actionType string, a map[string]interface{}) (RuleAction, error) {
+	switch actionType {
+	case ActionSendEmail:
+		return RuleAction{SendEmail: &SendEmailAction{
+			Recipients: actionStrings(a, "recipients"),
+			Body:       actionString(a, "body"),
+		}, JSON: NewJSONNull()}, nil
+	case ActionSendSlackMessage:
+		return RuleAction{SendSlackMessage: &SendSlackMessageAction{
+			IntegrationInstanceId: actionString(a, "integrationInstanceId"),
+			Channels:              actionStrings(a, "channels"),
+			Body:                  actionString(a, "body"),
+		}, JSON: NewJSONNull()}, nil
+	case ActionCreateJiraTicket:
+		ticket := &CreateJiraTicketAction{
+			IntegrationInstanceId:  actionString(a, "integrationInstanceId"),
+			Project:                actionString(a, "project"),
+			IssueType:              actionString(a, "issueType"),
+			Summary:                actionString(a, "summary"),
+			EntityClass:            actionString(a, "entityClass"),
+			AutoResolve:            actionBool(a, "autoResolve"),
+			ResolvedStatus:         actionString(a, "resolvedStatus"),
+			UpdateContentOnChanges: actionBool(a, "updateContentOnChanges"),
+		}
+		fields, err := actionJSON(a, "additionalFields")
+		if err != nil {
+			return RuleAction{}, err
+		}
+		ticket.AdditionalFields = fields
+		return RuleAction{CreateJiraTicket: ticket, JSON: NewJSONNull()}, nil
+	case ActionSetProperty:
+		value, err := actionJSON(a, "targetValue")
+		if err != nil {
+			return RuleAction{}, err
+		}
+		return RuleAction{SetProperty: &SetPropertyAction{
+			TargetProperty: actionString(a, "targetProperty"),
+			TargetValue:    value,
+		}, JSON: NewJSONNull()}, nil
+	case ActionTagEntities:
+		tagEntities := &TagEntitiesAction{
+			Entities: actionString(a, "entities"),
+		}
+		tags, _ := a["tags"].([]interface{})
+		for _, t := range tags {
+			tag, _ := t.(map[string]interface{})
+			tagEntities.Tags = append(tagEntities.Tags, EntityTag{
+				Name:  actionString(tag, "name"),
+				Value: actionString(tag, "value"),
+			})
+		}
+		return RuleAction{TagEntities: tagEntities, JSON: NewJSONNull()}, nil
+	default:
+		return RuleAction{}, fmt.Errorf("%s actions have no typed attribute", actionType)
+	}
+}
+
+// normalizeAction returns the action as decoded from its JSON encoding, so
+// that actions built from the config compare equal to the actions of the API.
+func normalizeAction(action map[string]interface{}) interface{} {
+	raw, err := json.Marshal(action)
+	if err != nil {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil
+	}
+	return v
+}
+
+func setActionString(action map[string]interface{}, key string, value types.String) {
+	if !value.IsNull() && !value.IsUnknown() {
+		action[key] = value.ValueString()
+	}
+}
+
+func setActionBool(action map[string]interface{}, key string, value types.Bool) {
+	if !value.IsNull() && !value.IsUnknown() {
+		action[key] = value.ValueBool()
+	}
+}
+
+// actionString returns a field of an action as a string, encoding values of
+// other types as JSON, or null if the field is missing.
+func actionString(action map[string]interface{}, key string) types.String {
+	switch v := action[key].(type) {
+	case nil:
+		return types.StringNull()
+	case string:
+		return types.StringValue(v)
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return types.StringValue(fmt.Sprint(v))
+		}
+		return types.StringValue(string(raw))
+	}
+}
+
+// actionJSON returns a field of an action as a JSON document, which keeps the
+// type of its value, or null if the field is missing.
+func actionJSON(action map[string]interface{}, key string) (JSONValue, error) {
+	v, ok := action[key]
+	if !ok || v == nil {
+		return NewJSONNull(), nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return JSONValue{}, err
+	}
+	return NewJSONValue(string(raw)), nil
+}
+
+func actionBool(action map[string]interface{}, key string) types.Bool {
+	if v, ok := action[key].(bool); ok {
+		return types.BoolValue(v)
+	}
+	return types.BoolNull()
+}
+
+func actionStrings(action map[string]interface{}, key string) []string {
+	values, _ := action[key].([]interface{})
+	l := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			l = append(l, s)
+		}
+	}
+	return l
+}
@@ -0,0 +1,167 @@
+package jupiterone
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// apiOperations returns the operations as the API returns them, with ids on
+// their actions.
+func apiOperations(t *testing.T, ops []client.RuleOperationInput) []client.RuleOperationOutput {
+	output := make([]client.RuleOperationOutput, len(ops))
+	id := 'a'
+	for i, op := range ops {
+		output[i].When = op.When
+		for _, action := range op.Actions {
+			var a map[string]interface{}
+			require.NoError(t, action.Decode(&a))
+			a["id"] = string(id)
+			id++
+
+			j, err := client.NewJSON(a)
+			require.NoError(t, err)
+			output[i].Actions = append(output[i].Actions, j)
+		}
+	}
+	return output
+}
+
+func TestRuleOperationTypedActions(t *testing.T) {
+	op := RuleOperation{
+		When: NewJSONNull(),
+		TypedActions: []RuleAction{
+			{
+				SetProperty: &SetPropertyAction{TargetProperty: types.StringValue("alertLevel"), TargetValue: NewJSONValue(`"HIGH"`)},
+				JSON:        NewJSONNull(),
+			},
+			{JSON: NewJSONValue(createAlertActionJSON)},
+			{
+				SendEmail: &SendEmailAction{Recipients: []string{"security@example.com"}, Body: types.StringNull()},
+				JSON:      NewJSONNull(),
+			},
+			{
+				CreateJiraTicket: &CreateJiraTicketAction{
+					IntegrationInstanceId:  types.StringValue("jira"),
+					Project:                types.StringValue("SEC"),
+					IssueType:              types.StringValue("Task"),
+					Summary:                types.StringValue("{{queries.query0.total}} findings"),
+					EntityClass:            types.StringValue("Finding"),
+					AutoResolve:            types.BoolValue(true),
+					ResolvedStatus:         types.StringValue("Done"),
+					UpdateContentOnChanges: types.BoolNull(),
+					AdditionalFields:       NewJSONValue(`{"description":{"type":"doc"}}`),
+				},
+				JSON: NewJSONNull(),
+			},
+			{
+				TagEntities: &TagEntitiesAction{
+					Entities: types.StringValue("{{queries.query0.data}}"),
+					Tags:     []EntityTag{{Name: types.StringValue("reviewed"), Value: types.StringNull()}},
+				},
+				JSON: NewJSONNull(),
+			},
+			{
+				SetProperty: &SetPropertyAction{TargetProperty: types.StringValue("suppressed"), TargetValue: NewJSONValue("true")},
+				JSON:        NewJSONNull(),
+			},
+		},
+	}
+
+	rule := RuleModel{Operations: []RuleOperation{op}}
+	inputs, err := rule.buildOperations()
+	require.NoError(t, err)
+	require.Len(t, inputs, 1)
+
+	b, err := json.Marshal(inputs[0].Actions)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"type":"SET_PROPERTY","targetProperty":"alertLevel","targetValue":"HIGH"},
+		{"type":"CREATE_ALERT"},
+		{"type":"SEND_EMAIL","recipients":["security@example.com"]},
+		{"type":"CREATE_JIRA_TICKET","integrationInstanceId":"jira","project":"SEC","issueType":"Task",
+		 "summary":"{{queries.query0.total}} findings","entityClass":"Finding","autoResolve":true,
+		 "resolvedStatus":"Done","additionalFields":{"description":{"type":"doc"}}},
+		{"type":"TAG_ENTITIES","entities":"{{queries.query0.data}}","tags":[{"name":"reviewed"}]},
+		{"type":"SET_PROPERTY","targetProperty":"suppressed","targetValue":true}
+	]`, string(b), "actions are sent in the order of typed_actions, with the JSON type of target_value")
+
+	output := apiOperations(t, inputs)
+
+	read, err := newOperationsWithoutId(output, rule.Operations)
+	require.NoError(t, err)
+	assert.Equal(t, rule.Operations, read, "actions are read in the form of the prior operation")
+
+	imported, err := newOperationsWithoutId(output, nil)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	assert.Len(t, imported[0].Actions, 6, "imported actions are read as JSON")
+	assert.Nil(t, imported[0].TypedActions)
+	assert.JSONEq(t, `{"type":"SET_PROPERTY","targetProperty":"alertLevel","targetValue":"HIGH"}`, imported[0].Actions[0])
+}
+
+func TestRuleOperationReadKeepsJSONActions(t *testing.T) {
+	prior := []RuleOperation{
+		{Actions: []string{`{"type":"SET_PROPERTY","targetProperty":"alertLevel","targetValue":"HIGH"}`}},
+		{TypedActions: []RuleAction{
+			{JSON: NewJSONValue(`{"type":"SET_PROPERTY","targetProperty":"alertLevel","targetValue":"LOW"}`)},
+			{SendEmail: &SendEmailAction{Recipients: []string{"a@example.com"}}, JSON: NewJSONNull()},
+			{SetProperty: &SetPropertyAction{TargetProperty: types.StringValue("count"), TargetValue: NewJSONValue("1")}, JSON: NewJSONNull()},
+		}},
+	}
+	output := []client.RuleOperationOutput{
+		{Actions: []client.JSON{
+			client.JSON(`{"id":"1","type":"SET_PROPERTY","targetProperty":"alertLevel","targetValue":"HIGH"}`),
+		}},
+		{Actions: []client.JSON{
+			client.JSON(`{"id":"2","type":"SEND_EMAIL","recipients":["a@example.com"]}`),
+			client.JSON(`{"id":"3","type":"SET_PROPERTY","targetProperty":"alertLevel","targetValue":"LOW"}`),
+			client.JSON(`{"id":"4","type":"SET_PROPERTY","targetProperty":"count","targetValue":3}`),
+			client.JSON(`{"id":"5","type":"CREATE_ALERT"}`),
+		}},
+	}
+
+	read, err := newOperationsWithoutId(output, prior)
+	require.NoError(t, err)
+	require.Len(t, read, 2)
+
+	assert.Equal(t, []string{`{"targetProperty":"alertLevel","targetValue":"HIGH","type":"SET_PROPERTY"}`}, read[0].Actions)
+	assert.Nil(t, read[0].TypedActions, "the first operation configured its actions as JSON")
+
+	assert.Nil(t, read[1].Actions)
+	assert.Equal(t, []RuleAction{
+		{SendEmail: &SendEmailAction{Recipients: []string{"a@example.com"}, Body: types.StringNull()}, JSON: NewJSONNull()},
+		{JSON: NewJSONValue(`{"targetProperty":"alertLevel","targetValue":"LOW","type":"SET_PROPERTY"}`)},
+		{SetProperty: &SetPropertyAction{TargetProperty: types.StringValue("count"), TargetValue: NewJSONValue("3")}, JSON: NewJSONNull()},
+		{JSON: NewJSONValue(`{"type":"CREATE_ALERT"}`)},
+	}, read[1].TypedActions, "actions are read in the order of the API, in the form of the prior action with the same content, or else of their type")
+}
+
+func TestRuleOperationsMatchPriorByContent(t *testing.T) {
+	email := RuleOperation{
+		When: NewJSONNull(),
+		TypedActions: []RuleAction{
+			{SendEmail: &SendEmailAction{Recipients: []string{"a@example.com"}, Body: types.StringNull()}, JSON: NewJSONNull()},
+		},
+	}
+	alert := RuleOperation{
+		When:    NewJSONValue(`{"type":"FILTER","condition":["AND",["queries.query0.total",">",0]]}`),
+		Actions: []string{createAlertActionJSON},
+	}
+
+	rule := RuleModel{Operations: []RuleOperation{alert, email}}
+	inputs, err := rule.buildOperations()
+	require.NoError(t, err)
+	output := apiOperations(t, inputs)
+
+	read, err := newOperationsWithoutId(output, []RuleOperation{email, alert})
+	require.NoError(t, err)
+	require.Len(t, read, 2)
+	assert.Equal(t, []string{createAlertActionJSON}, read[0].Actions)
+	assert.Nil(t, read[0].TypedActions)
+	assert.Equal(t, email.TypedActions, read[1].TypedActions, "the operations are matched to the prior operations with the same content")
+}
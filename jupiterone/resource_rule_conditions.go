@@ -0,0 +1,376 @@
+package jupiterone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+)
+
+const (
+	ConditionLogicAnd = "AND"
+	ConditionLogicOr  = "OR"
+)
+
+var ConditionOperators = []string{"=", "!=", "===", "!==", ">", ">=", "<", "<="}
+
+// Types of the right operand of a comparison. Without a type, `true`,
+// `false`, `null` and numbers are compared as such and other values as
+// strings.
+const (
+	OperandTypeString  = "string"
+	OperandTypeNumber  = "number"
+	OperandTypeBoolean = "boolean"
+)
+
+// RuleCondition is the `condition` of an operation, it compiles to the
+// condition of a FILTER `when`:
+//
+//	["AND", ["queries.query0.total", ">", 0], ["OR", [...], [...]]]
+type RuleCondition struct {
+	Logic      types.String              `tfsdk:"logic"`
+	Conditions []RuleConditionComparison `tfsdk:"conditions"`
+	Groups     []RuleConditionGroup      `tfsdk:"groups"`
+}
+
+type RuleConditionGroup struct {
+	Logic      types.String              `tfsdk:"logic"`
+	Conditions []RuleConditionComparison `tfsdk:"conditions"`
+}
+
+type RuleConditionComparison struct {
+	Left      types.String `tfsdk:"left"`
+	Operator  types.String `tfsdk:"operator"`
+	Right     types.String `tfsdk:"right"`
+	RightType types.String `tfsdk:"right_type"`
+}
+
+func ruleConditionComparisonAttribute(description string, required bool) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: description,
+		Required:    required,
+		Optional:    !required,
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"left": schema.StringAttribute{
+					Description: "Left operand, usually a property of the evaluation such as `queries.query0.total`.",
+					Required:    true,
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					},
+				},
+				"operator": schema.StringAttribute{
+					Description: fmt.Sprintf("Comparison operator, one of %s.", strings.Join(wrapBackticks(ConditionOperators), ", ")),
+					Required:    true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(ConditionOperators...),
+					},
+				},
+				"right": schema.StringAttribute{
+					Description: "Right operand. Without `right_type`, numbers, `true`, `false` and `null` are compared as such, other values as strings.",
+					Required:    true,
+					Validators: []validator.String{
+						conditionOperandValidator{},
+					},
+				},
+				"right_type": schema.StringAttribute{
+					Description: "Type the right operand is compared as, one of `string`, `number` and `boolean`. Set it to `string` to compare values such as `0123` or `true` as strings.",
+					Optional:    true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(OperandTypeString, OperandTypeNumber, OperandTypeBoolean),
+					},
+				},
+			},
+		},
+	}
+}
+
+func ruleConditionLogicAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "How the conditions are combined, `AND` or `OR`. Defaults to `AND`.",
+		Optional:    true,
+		Validators: []validator.String{
+			stringvalidator.OneOf(ConditionLogicAnd, ConditionLogicOr),
+		},
+	}
+}
+
+// ruleConditionAttribute is the `condition` attribute of an operation.
+func ruleConditionAttribute() schema.SingleNestedAttribute {
+	conditions := ruleConditionComparisonAttribute("Comparisons that are combined by `logic`.", false)
+	conditions.Validators = append(conditions.Validators, listvalidator.AtLeastOneOf(
+		path.MatchRelative().AtParent().AtName("groups"),
+	))
+
+	return schema.SingleNestedAttribute{
+		Description: "The condition to evaluate before executing the actions, compiled to the FILTER JSON of `when`. Conflicts with `when`.",
+		Optional:    true,
+		Validators: []validator.Object{
+			objectvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("when")),
+		},
+		Attributes: map[string]schema.Attribute{
+			"logic":      ruleConditionLogicAttribute(),
+			"conditions": conditions,
+			"groups": schema.ListNestedAttribute{
+				Description: "Groups of comparisons that are combined by their own `logic`, and then with the comparisons of the condition. Groups cannot be nested, use `when` for deeper conditions.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"logic":      ruleConditionLogicAttribute(),
+						"conditions": ruleConditionComparisonAttribute("Comparisons that are combined by `logic`.", true),
+					},
+				},
+			},
+		},
+	}
+}
+
+func wrapBackticks(values []string) []string {
+	wrapped := make([]string, len(values))
+	for i, v := range values {
+		wrapped[i] = "`" + v + "`"
+	}
+	return wrapped
+}
+
+// build returns the FILTER `when` of the condition.
+func (c *RuleCondition) build() map[string]interface{} {
+	condition := []interface{}{conditionLogic(c.Logic)}
+	for _, comparison := range c.Conditions {
+		condition = append(condition, comparison.build())
+	}
+	for _, group := range c.Groups {
+		g := []interface{}{conditionLogic(group.Logic)}
+		for _, comparison := range group.Conditions {
+			g = append(g, comparison.build())
+		}
+		condition = append(condition, g)
+	}
+
+	return map[string]interface{}{
+		"type":      "FILTER",
+		"condition": condition,
+	}
+}
+
+func (c RuleConditionComparison) build() []interface{} {
+	return []interface{}{c.Left.ValueString(), c.Operator.ValueString(), conditionOperand(c.Right.ValueString(), c.RightType.ValueString())}
+}
+
+func conditionLogic(logic types.String) string {
+	if logic.IsNull() || logic.IsUnknown() {
+		return ConditionLogicAnd
+	}
+	return logic.ValueString()
+}
+
+// conditionOperand converts the right operand to the JSON value it is
+// compared as. Values that are not of the operand type are kept as strings,
+// conditionOperandValidator reports them.
+func conditionOperand(s, operandType string) interface{} {
+	if operandType == OperandTypeString {
+		return s
+	}
+	if operandType != OperandTypeNumber {
+		switch s {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			if operandType == "" {
+				return nil
+			}
+		}
+	}
+	if operandType != OperandTypeBoolean {
+		if n, ok := parseOperandNumber(s); ok {
+			return n
+		}
+	}
+	return s
+}
+
+// parseOperandNumber parses a number operand. NaN and infinities are not
+// numbers of JSON and are rejected.
+func parseOperandNumber(s string) (float64, bool) {
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, false
+	}
+	return n, true
+}
+
+// conditionOperandValidator checks that the right operand of a comparison is
+// a value of its `right_type`, and that values compared as numbers are
+// finite.
+type conditionOperandValidator struct{}
+
+var _ validator.String = conditionOperandValidator{}
+
+// Description implements validator.Describer
+func (conditionOperandValidator) Description(context.Context) string {
+	return "value must be of the type of right_type"
+}
+
+// MarkdownDescription implements validator.Describer
+func (v conditionOperandValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements validator.String
+func (conditionOperandValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+
+	var operandType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName("right_type"), &operandType)...)
+	if operandType.IsUnknown() {
+		return
+	}
+
+	switch operandType.ValueString() {
+	case OperandTypeNumber:
+		if _, ok := parseOperandNumber(value); !ok {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid Right Operand",
+				fmt.Sprintf("%q is not a finite number, as required by right_type %q.", value, OperandTypeNumber))
+		}
+	case OperandTypeBoolean:
+		if value != "true" && value != "false" {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid Right Operand",
+				fmt.Sprintf("%q is not `true` or `false`, as required by right_type %q.", value, OperandTypeBoolean))
+		}
+	case "":
+		// strconv.ParseFloat accepts NaN and infinities, which cannot be
+		// encoded as JSON numbers.
+		if n, err := strconv.ParseFloat(value, 64); err == nil && (math.IsNaN(n) || math.IsInf(n, 0)) {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid Right Operand",
+				fmt.Sprintf("%q is not a finite number. Set right_type to %q to compare it as a string.", value, OperandTypeString))
+		}
+	}
+}
+
+// readRuleCondition converts a `when` from the API to a condition. The prior
+// condition is kept when it compiles to the same `when`, so optional values
+// it left unset stay unset. It returns false when the `when` cannot be
+// represented as a condition.
+func readRuleCondition(when client.JSON, prior *RuleCondition) (*RuleCondition, bool) {
+	var actual interface{}
+	if err := when.Decode(&actual); err != nil {
+		return nil, false
+	}
+
+	if prior != nil {
+		b, err := json.Marshal(prior.build())
+		if err == nil {
+			var expected interface{}
+			if json.Unmarshal(b, &expected) == nil && reflect.DeepEqual(expected, actual) {
+				return prior, true
+			}
+		}
+	}
+
+	filter, ok := actual.(map[string]interface{})
+	if !ok || filter["type"] != "FILTER" || len(filter) != 2 {
+		return nil, false
+	}
+	condition, ok := filter["condition"].([]interface{})
+	if !ok || len(condition) < 2 {
+		return nil, false
+	}
+	logic, comparisons, ok := readConditionList(condition)
+	if !ok {
+		return nil, false
+	}
+
+	c := &RuleCondition{Logic: types.StringValue(logic), Conditions: comparisons}
+	for _, item := range condition[1+len(comparisons):] {
+		group, _ := item.([]interface{})
+		groupLogic, groupComparisons, ok := readConditionList(group)
+		if !ok || len(groupComparisons) != len(group)-1 || len(groupComparisons) == 0 {
+			return nil, false
+		}
+		c.Groups = append(c.Groups, RuleConditionGroup{
+			Logic:      types.StringValue(groupLogic),
+			Conditions: groupComparisons,
+		})
+	}
+	return c, true
+}
+
+// readConditionList reads the logic of a condition list and its leading
+// comparisons.
+func readConditionList(list []interface{}) (string, []RuleConditionComparison, bool) {
+	if len(list) == 0 {
+		return "", nil, false
+	}
+	logic, _ := list[0].(string)
+	if logic != ConditionLogicAnd && logic != ConditionLogicOr {
+		return "", nil, false
+	}
+
+	var comparisons []RuleConditionComparison
+	for _, item := range list[1:] {
+		comparison, ok := item.([]interface{})
+		if !ok || len(comparison) == 0 {
+			return "", nil, false
+		}
+		if first, _ := comparison[0].(string); first == ConditionLogicAnd || first == ConditionLogicOr {
+			break
+		}
+		if len(comparison) != 3 {
+			return "", nil, false
+		}
+		left, leftOk := comparison[0].(string)
+		operator, operatorOk := comparison[1].(string)
+		if !leftOk || !operatorOk {
+			return "", nil, false
+		}
+		r, rightType, ok := readConditionOperand(comparison[2])
+		if !ok {
+			return "", nil, false
+		}
+		comparisons = append(comparisons, RuleConditionComparison{
+			Left:      types.StringValue(left),
+			Operator:  types.StringValue(operator),
+			Right:     types.StringValue(r),
+			RightType: rightType,
+		})
+	}
+	return logic, comparisons, true
+}
+
+// readConditionOperand converts a right operand of the API to the value of
+// `right` and its `right_type`. The type is only set for strings that would
+// otherwise be compared as another type.
+func readConditionOperand(right interface{}) (string, types.String, bool) {
+	r, ok := right.(string)
+	if !ok {
+		b, err := json.Marshal(right)
+		if err != nil {
+			return "", types.StringNull(), false
+		}
+		return string(b), types.StringNull(), true
+	}
+	if _, isString := conditionOperand(r, "").(string); !isString {
+		return r, types.StringValue(OperandTypeString), true
+	}
+	return r, types.StringNull(), true
+}
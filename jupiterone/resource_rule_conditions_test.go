@@ -0,0 +1,158 @@
+package jupiterone
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ruleComparison(left, operator, right string) RuleConditionComparison {
+	return RuleConditionComparison{
+		Left:     types.StringValue(left),
+		Operator: types.StringValue(operator),
+		Right:    types.StringValue(right),
+	}
+}
+
+func TestRuleConditionBuild(t *testing.T) {
+	condition := &RuleCondition{
+		Logic: types.StringNull(),
+		Conditions: []RuleConditionComparison{
+			ruleComparison("queries.query0.total", ">", "0"),
+		},
+		Groups: []RuleConditionGroup{
+			{
+				Logic: types.StringValue(ConditionLogicOr),
+				Conditions: []RuleConditionComparison{
+					ruleComparison("alertLevel", "===", "HIGH"),
+					ruleComparison("queries.query1.total", "!=", "null"),
+				},
+			},
+		},
+	}
+
+	b, err := json.Marshal(condition.build())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"FILTER","condition":[
+		"AND",
+		["queries.query0.total",">",0],
+		["OR",["alertLevel","===","HIGH"],["queries.query1.total","!=",null]]
+	]}`, string(b))
+
+	when := client.JSON(b)
+
+	read, ok := readRuleCondition(when, condition)
+	require.True(t, ok)
+	assert.Same(t, condition, read, "the prior condition is kept when it compiles to the same when")
+
+	read, ok = readRuleCondition(when, nil)
+	require.True(t, ok)
+	assert.Equal(t, types.StringValue(ConditionLogicAnd), read.Logic)
+	assert.Equal(t, condition.Conditions, read.Conditions)
+	assert.Equal(t, condition.Groups, read.Groups)
+
+	_, ok = readRuleCondition(client.JSON(`{"type":"FILTER","condition":"{{queries.query0.total != 0}}"}`), nil)
+	assert.False(t, ok, "template conditions are not representable")
+}
+
+func TestNewOperationsReadsConditionInPriorForm(t *testing.T) {
+	prior := []RuleOperation{
+		{Condition: &RuleCondition{Conditions: []RuleConditionComparison{ruleComparison("queries.query0.total", ">", "0")}}},
+		{When: NewJSONValue(`{"type":"FILTER","condition":["AND",["queries.query0.total",">",0]]}`)},
+	}
+	when := client.JSON(`{"type":"FILTER","condition":["AND",["queries.query0.total",">",0]]}`)
+
+	read, err := newOperationsWithoutId([]client.RuleOperationOutput{{When: when}, {When: when}}, prior)
+	require.NoError(t, err)
+	require.Len(t, read, 2)
+
+	assert.Equal(t, prior[0].Condition, read[0].Condition)
+	assert.True(t, read[0].When.IsNull())
+
+	assert.Nil(t, read[1].Condition)
+	assert.JSONEq(t, `{"type":"FILTER","condition":["AND",["queries.query0.total",">",0]]}`, read[1].When.ValueString())
+}
+
+func TestConditionOperand(t *testing.T) {
+	for _, tc := range []struct {
+		right, rightType string
+		expected         interface{}
+	}{
+		{"0123", "", float64(123)},
+		{"0123", OperandTypeString, "0123"},
+		{"true", "", true},
+		{"true", OperandTypeString, "true"},
+		{"false", OperandTypeBoolean, false},
+		{"null", "", nil},
+		{"1.5", OperandTypeNumber, 1.5},
+		{"nan", "", "nan"},
+		{"inf", OperandTypeNumber, "inf"},
+		{"HIGH", "", "HIGH"},
+	} {
+		assert.Equal(t, tc.expected, conditionOperand(tc.right, tc.rightType), "%q as %q", tc.right, tc.rightType)
+	}
+
+	when := client.JSON(`{"type":"FILTER","condition":["AND",["a","=","0123"],["b","=","HIGH"],["c","=",1]]}`)
+	read, ok := readRuleCondition(when, nil)
+	require.True(t, ok)
+	assert.Equal(t, []RuleConditionComparison{
+		{Left: types.StringValue("a"), Operator: types.StringValue("="), Right: types.StringValue("0123"), RightType: types.StringValue(OperandTypeString)},
+		ruleComparison("b", "=", "HIGH"),
+		ruleComparison("c", "=", "1"),
+	}, read.Conditions, "strings that would be compared as another type are read with right_type string")
+
+	b, err := json.Marshal(read.build())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(when), string(b))
+}
+
+func TestConditionOperandValidator(t *testing.T) {
+	comparison := path.Root("operations").AtListIndex(0).AtName("condition").AtName("conditions").AtListIndex(0)
+
+	for _, tc := range []struct {
+		right, rightType string
+		valid            bool
+	}{
+		{"0", "", true},
+		{"nan", "", false},
+		{"-Inf", "", false},
+		{"nan", OperandTypeString, true},
+		{"1e3", OperandTypeNumber, true},
+		{"0x", OperandTypeNumber, false},
+		{"Infinity", OperandTypeNumber, false},
+		{"true", OperandTypeBoolean, true},
+		{"yes", OperandTypeBoolean, false},
+	} {
+		rightType := types.StringNull()
+		if tc.rightType != "" {
+			rightType = types.StringValue(tc.rightType)
+		}
+		rule := &RuleModel{Operations: []RuleOperation{{
+			When: NewJSONNull(),
+			Condition: &RuleCondition{
+				Logic: types.StringNull(),
+				Conditions: []RuleConditionComparison{{
+					Left:      types.StringValue("queries.query0.total"),
+					Operator:  types.StringValue("="),
+					Right:     types.StringValue(tc.right),
+					RightType: rightType,
+				}},
+			},
+		}}}
+		req := validator.StringRequest{
+			Path:        comparison.AtName("right"),
+			Config:      ruleConfig(t, rule),
+			ConfigValue: types.StringValue(tc.right),
+		}
+		resp := &validator.StringResponse{}
+		conditionOperandValidator{}.ValidateString(context.TODO(), req, resp)
+		assert.Equal(t, !tc.valid, resp.Diagnostics.HasError(), "%q as %q: %v", tc.right, tc.rightType, resp.Diagnostics)
+	}
+}
@@ -0,0 +1,312 @@
+package jupiterone
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+)
+
+// previewQuery is a query of a question or rule that is run during plan when
+// `preview` is enabled.
+type previewQuery struct {
+	Path           path.Path
+	Name           string
+	Query          string
+	IncludeDeleted bool
+}
+
+// previewDescription is the description of the `preview` attribute.
+const previewDescription = "When enabled, the queries are run during plan and the results are reported as warnings. " +
+	"Rule previews also report which operations would fire. No alerts are created and no actions are taken."
+
+// runPreviewQueries runs the queries and returns the values their results
+// provide to rule conditions, such as `queries.query0.total`, together with a
+// summary of them in query order. A query that fails is reported as a warning
+// and left out of the values.
+func runPreviewQueries(ctx context.Context, qlient graphql.Client, queries []previewQuery) (map[string]interface{}, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	values := map[string]interface{}{}
+	var summary []string
+	for i, q := range queries {
+		name := q.Name
+		if name == "" {
+			name = fmt.Sprintf("query%d", i)
+		}
+
+		result, err := client.ExecuteQuery(ctx, qlient, q.Query, q.IncludeDeleted, "")
+		if err != nil {
+			diags.AddAttributeWarning(q.Path, "Preview Failed",
+				fmt.Sprintf("Unable to run query %s: %s", name, err))
+			continue
+		}
+
+		key := "queries." + name + ".total"
+		values[key] = float64(result.QueryV1.TotalCount)
+		summary = append(summary, fmt.Sprintf("%s = %d", key, result.QueryV1.TotalCount))
+	}
+	return values, strings.Join(summary, ", "), diags
+}
+
+// evaluateFilter evaluates the condition of a FILTER `when` against the
+// values of a preview. Conditions that depend on anything other than those
+// values, such as templates, cannot be evaluated.
+func evaluateFilter(when interface{}, values map[string]interface{}) (bool, error) {
+	filter, ok := when.(map[string]interface{})
+	if !ok || filter["type"] != "FILTER" {
+		return false, fmt.Errorf("only FILTER conditions can be previewed")
+	}
+	condition, ok := filter["condition"].([]interface{})
+	if !ok {
+		return false, fmt.Errorf("the condition is not a list")
+	}
+	return evaluateConditionList(condition, values)
+}
+
+func evaluateConditionList(list []interface{}, values map[string]interface{}) (bool, error) {
+	if len(list) == 0 {
+		return false, fmt.Errorf("the condition is empty")
+	}
+	logic, _ := list[0].(string)
+	if logic != ConditionLogicAnd && logic != ConditionLogicOr {
+		return false, fmt.Errorf("unknown condition logic %v", list[0])
+	}
+
+	result := logic == ConditionLogicAnd
+	for _, item := range list[1:] {
+		entry, ok := item.([]interface{})
+		if !ok || len(entry) == 0 {
+			return false, fmt.Errorf("unexpected condition %v", item)
+		}
+
+		var matched bool
+		var err error
+		if first, _ := entry[0].(string); first == ConditionLogicAnd || first == ConditionLogicOr {
+			matched, err = evaluateConditionList(entry, values)
+		} else {
+			matched, err = evaluateComparison(entry, values)
+		}
+		if err != nil {
+			return false, err
+		}
+
+		if logic == ConditionLogicAnd {
+			result = result && matched
+		} else {
+			result = result || matched
+		}
+	}
+	return result, nil
+}
+
+func evaluateComparison(comparison []interface{}, values map[string]interface{}) (bool, error) {
+	if len(comparison) != 3 {
+		return false, fmt.Errorf("unexpected comparison %v", comparison)
+	}
+	operator, _ := comparison[1].(string)
+
+	left, ok := resolveOperand(comparison[0], values)
+	if !ok {
+		return false, fmt.Errorf("%v is not known before the rule is evaluated", comparison[0])
+	}
+	right, ok := resolveOperand(comparison[2], values)
+	if !ok {
+		// A string that is not a value of the preview is a literal.
+		right = comparison[2]
+	}
+
+	switch operator {
+	case "===":
+		return reflect.DeepEqual(left, right), nil
+	case "!==":
+		return !reflect.DeepEqual(left, right), nil
+	case "=":
+		return looseEqual(left, right), nil
+	case "!=":
+		return !looseEqual(left, right), nil
+	case ">", ">=", "<", "<=":
+		c, ok := compareOperands(left, right)
+		if !ok {
+			return false, nil
+		}
+		switch operator {
+		case ">":
+			return c > 0, nil
+		case ">=":
+			return c >= 0, nil
+		case "<":
+			return c < 0, nil
+		default:
+			return c <= 0, nil
+		}
+	}
+	return false, fmt.Errorf("unknown operator %v", comparison[1])
+}
+
+// resolveOperand looks up an operand such as `queries.query0.total` or
+// `{{queries.query0.total}}` in the values of a preview.
+func resolveOperand(operand interface{}, values map[string]interface{}) (interface{}, bool) {
+	s, ok := operand.(string)
+	if !ok {
+		return operand, true
+	}
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "{{") && strings.HasSuffix(s, "}}") {
+		s = strings.TrimSpace(s[2 : len(s)-2])
+	}
+	v, ok := values[s]
+	return v, ok
+}
+
+func looseEqual(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	x, xOk := operandNumber(a)
+	y, yOk := operandNumber(b)
+	return xOk && yOk && x == y
+}
+
+func compareOperands(a, b interface{}) (int, bool) {
+	if x, ok := a.(string); ok {
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	}
+	x, xOk := operandNumber(a)
+	y, yOk := operandNumber(b)
+	if !xOk || !yOk {
+		return 0, false
+	}
+	switch {
+	case x < y:
+		return -1, true
+	case x > y:
+		return 1, true
+	}
+	return 0, true
+}
+
+func operandNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case bool:
+		if n {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// previewRule runs the queries of a rule and reports for each operation
+// whether it would fire.
+func previewRule(ctx context.Context, qlient graphql.Client, rule *RuleModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var queries []previewQuery
+	if len(rule.Question) > 0 {
+		for i, q := range rule.Question[0].Queries {
+			queries = append(queries, previewQuery{
+				Path:           path.Root("question").AtListIndex(0).AtName("queries").AtListIndex(i),
+				Name:           q.Name,
+				Query:          q.Query,
+				IncludeDeleted: q.IncludedDeleted,
+			})
+		}
+	} else {
+		if rule.QuestionId.IsUnknown() || rule.QuestionId.IsNull() {
+			return diags
+		}
+		question, err := client.GetQuestionById(ctx, qlient, rule.QuestionId.ValueString())
+		if err != nil {
+			diags.AddAttributeWarning(path.Root("question_id"), "Preview Failed",
+				fmt.Sprintf("Unable to get the question of the rule: %s", err))
+			return diags
+		}
+		for _, q := range question.Question.Queries {
+			queries = append(queries, previewQuery{
+				Path:           path.Root("question_id"),
+				Name:           q.Name,
+				Query:          q.Query,
+				IncludeDeleted: q.IncludeDeleted,
+			})
+		}
+	}
+
+	values, summary, queryDiags := runPreviewQueries(ctx, qlient, queries)
+	diags.Append(queryDiags...)
+	if queryDiags.WarningsCount() > 0 {
+		return diags
+	}
+
+	ops, err := rule.buildOperations()
+	if err != nil {
+		diags.AddAttributeWarning(path.Root("operations"), "Preview Failed",
+			fmt.Sprintf("Unable to build the operations of the rule: %s", err))
+		return diags
+	}
+	for i, op := range ops {
+		p := path.Root("operations").AtListIndex(i)
+		if rule.Operations[i].Condition == nil && rule.Operations[i].When.IsUnknown() {
+			diags.AddAttributeWarning(p, "Rule Preview",
+				fmt.Sprintf("operation %d cannot be previewed: the condition is not known until apply", i+1))
+			continue
+		}
+
+		fires := true
+		if !op.When.IsNull() {
+			var when interface{}
+			if err = op.When.Decode(&when); err == nil {
+				fires, err = evaluateFilter(when, values)
+			}
+			if err != nil {
+				diags.AddAttributeWarning(p, "Rule Preview",
+					fmt.Sprintf("operation %d cannot be previewed: %s", i+1, err))
+				continue
+			}
+		}
+
+		if fires {
+			diags.AddAttributeWarning(p, "Rule Preview", fmt.Sprintf("operation %d would fire: %s", i+1, summary))
+		} else {
+			diags.AddAttributeWarning(p, "Rule Preview", fmt.Sprintf("operation %d would not fire: %s", i+1, summary))
+		}
+	}
+	return diags
+}
+
+// previewQuestion runs the queries of a question and reports their totals.
+func previewQuestion(ctx context.Context, qlient graphql.Client, question *QuestionModel) diag.Diagnostics {
+	var queries []previewQuery
+	for i, q := range question.Query {
+		queries = append(queries, previewQuery{
+			Path:           path.Root("query").AtListIndex(i),
+			Name:           q.Name,
+			Query:          q.Query,
+			IncludeDeleted: q.IncludedDeleted,
+		})
+	}
+
+	_, summary, diags := runPreviewQueries(ctx, qlient, queries)
+	if diags.WarningsCount() == 0 && summary != "" {
+		diags.AddAttributeWarning(path.Root("query"), "Question Preview", summary)
+	}
+	return diags
+}
+
+// previewEnabled reports whether the `preview` of a plan is enabled.
+func previewEnabled(preview types.Bool) bool {
+	return !preview.IsNull() && !preview.IsUnknown() && preview.ValueBool()
+}
@@ -0,0 +1,110 @@
+package jupiterone
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestEvaluateFilter(t *testing.T) {
+	values := map[string]interface{}{
+		"queries.query0.total": float64(37),
+		"queries.query1.total": float64(0),
+	}
+
+	cases := []struct {
+		name  string
+		when  string
+		fires bool
+		err   string
+	}{
+		{"greater", `{"type":"FILTER","condition":["AND",["queries.query0.total",">",0]]}`, true, ""},
+		{"template", `{"type":"FILTER","condition":["AND",["{{queries.query1.total}}",">",0]]}`, false, ""},
+		{"loose equality", `{"type":"FILTER","condition":["AND",["queries.query0.total","=","37"]]}`, true, ""},
+		{"strict equality", `{"type":"FILTER","condition":["AND",["queries.query0.total","===","37"]]}`, false, ""},
+		{"operand", `{"type":"FILTER","condition":["AND",["queries.query0.total",">=","queries.query1.total"]]}`, true, ""},
+		{"or", `{"type":"FILTER","condition":["OR",["queries.query0.total","<",1],["queries.query1.total","<",1]]}`, true, ""},
+		{"group", `{"type":"FILTER","condition":["AND",["queries.query0.total",">",0],["OR",["queries.query1.total",">",0],["queries.query0.total","!=",37]]]}`, false, ""},
+		{"unknown operand", `{"type":"FILTER","condition":["AND",["queries.query0.data",">",0]]}`, false, "queries.query0.data is not known before the rule is evaluated"},
+		{"not a filter", `{"type":"TEMPLATE","condition":[]}`, false, "only FILTER conditions can be previewed"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var when interface{}
+			require.NoError(t, json.Unmarshal([]byte(c.when), &when))
+
+			fires, err := evaluateFilter(when, values)
+			if c.err != "" {
+				assert.EqualError(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.fires, fires)
+		})
+	}
+}
+
+func TestPreviewRule(t *testing.T) {
+	ctx := context.TODO()
+
+	server, qlient := setupFakeServer(ctx, t)
+	server.SetQueryTotal("FIND Host", 37)
+
+	rule := &RuleModel{
+		Question: []*RuleQuestion{{Queries: []*J1QueryInputModel{{Query: "FIND Host", Version: "v1"}}}},
+		Operations: []RuleOperation{
+			{When: NewJSONValue(`{"type":"FILTER","condition":["AND",["queries.query0.total",">",0]]}`)},
+			{Condition: &RuleCondition{
+				Logic: types.StringNull(),
+				Conditions: []RuleConditionComparison{{
+					Left:     types.StringValue("queries.query0.total"),
+					Operator: types.StringValue(">"),
+					Right:    types.StringValue("100"),
+				}},
+			}},
+			{When: NewJSONNull()},
+		},
+	}
+
+	diags := previewRule(ctx, qlient, rule)
+	assert.Equal(t, []string{
+		"operation 1 would fire: queries.query0.total = 37",
+		"operation 2 would not fire: queries.query0.total = 37",
+		"operation 3 would fire: queries.query0.total = 37",
+	}, diagnosticDetails(diags))
+
+	server.FailNext("ExecuteQuery", &gqlerror.Error{Message: "Invalid query"})
+	diags = previewRule(ctx, qlient, rule)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Preview Failed", diags[0].Summary())
+	assert.False(t, diags.HasError(), "a failed preview does not fail the plan")
+}
+
+func TestPreviewQuestion(t *testing.T) {
+	ctx := context.TODO()
+
+	server, qlient := setupFakeServer(ctx, t)
+	server.SetQueryTotal("FIND Host", 3)
+
+	question := &QuestionModel{Query: []*QuestionQueryModel{
+		{Query: "FIND Host", Name: "hosts"},
+		{Query: "FIND User"},
+	}}
+
+	diags := previewQuestion(ctx, qlient, question)
+	assert.Equal(t, []string{"queries.hosts.total = 3, queries.query1.total = 0"}, diagnosticDetails(diags))
+}
+
+func diagnosticDetails(diags diag.Diagnostics) []string {
+	details := make([]string, len(diags))
+	for i, d := range diags {
+		details[i] = d.Detail()
+	}
+	return details
+}
@@ -0,0 +1,300 @@
+package jupiterone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ConfigValidator = ruleReferencesValidator{}
+
+// ruleReferencesValidator checks the references of a rule before apply:
+// `queries.<name>` must name a query of the question block and
+// `templates.<key>` a key of `templates`. References are read from the
+// `{{...}}` expressions of the operations and templates, from the operands of
+// conditions and from `outputs`. Query references are not checked for rules
+// that reference a question by id, as its queries are only known to the API.
+type ruleReferencesValidator struct{}
+
+// Description implements resource.ConfigValidator
+func (ruleReferencesValidator) Description(context.Context) string {
+	return "References to queries and templates must match the queries of the question block and the keys of templates"
+}
+
+// MarkdownDescription implements resource.ConfigValidator
+func (v ruleReferencesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource implements resource.ConfigValidator
+func (ruleReferencesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	refs := ruleReferences{}
+	refs.queries, refs.queriesKnown = configQueryNames(ctx, req.Config, &resp.Diagnostics)
+
+	var templates types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("templates"), &templates)...)
+
+	var outputs, operations types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("outputs"), &outputs)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("operations"), &operations)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !templates.IsUnknown() {
+		refs.templatesKnown = true
+		for key := range templates.Elements() {
+			refs.templates = append(refs.templates, key)
+		}
+		sort.Strings(refs.templates)
+	}
+
+	if !refs.queriesKnown && !refs.templatesKnown {
+		return
+	}
+
+	for i, element := range outputs.Elements() {
+		if output, ok := element.(types.String); ok && !output.IsNull() && !output.IsUnknown() {
+			refs.checkReference(path.Root("outputs").AtListIndex(i), output.ValueString(), output.ValueString(), &resp.Diagnostics)
+		}
+	}
+
+	refs.walk(path.Root("templates"), "", templates, &resp.Diagnostics)
+	refs.walk(path.Root("operations"), "", operations, &resp.Diagnostics)
+}
+
+// configQueryNames returns the names of the queries of the question block,
+// and false if they are not known from the configuration.
+func configQueryNames(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) ([]string, bool) {
+	var question types.List
+	diags.Append(config.GetAttribute(ctx, path.Root("question"), &question)...)
+	if diags.HasError() || question.IsNull() || question.IsUnknown() {
+		return nil, false
+	}
+
+	var questions []struct {
+		Queries types.List `tfsdk:"queries"`
+	}
+	diags.Append(question.ElementsAs(ctx, &questions, false)...)
+	if diags.HasError() || len(questions) == 0 || questions[0].Queries.IsUnknown() {
+		return nil, false
+	}
+
+	var queries []struct {
+		Name           types.String   `tfsdk:"name"`
+		Query          J1QLQueryValue `tfsdk:"query"`
+		Version        types.String   `tfsdk:"version"`
+		IncludeDeleted types.Bool     `tfsdk:"include_deleted"`
+	}
+	diags.Append(questions[0].Queries.ElementsAs(ctx, &queries, false)...)
+	if diags.HasError() {
+		return nil, false
+	}
+
+	names := make([]string, 0, len(queries))
+	for i, query := range queries {
+		switch {
+		case query.Name.IsUnknown():
+			return nil, false
+		case query.Name.IsNull():
+			// The API names unnamed queries by their index.
+			names = append(names, fmt.Sprintf("query%d", i))
+		default:
+			names = append(names, query.Name.ValueString())
+		}
+	}
+	return names, true
+}
+
+type ruleReferences struct {
+	queries        []string
+	queriesKnown   bool
+	templates      []string
+	templatesKnown bool
+}
+
+// walk checks the references of the strings of a configuration value. name
+// is the name of the attribute the value is in.
+func (r ruleReferences) walk(p path.Path, name string, value attr.Value, diags *diag.Diagnostics) {
+	if value == nil || value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	switch v := value.(type) {
+	case JSONValue:
+		// The strings of JSON values are checked instead of the JSON text.
+		var decoded interface{}
+		// Invalid JSON is reported by the attribute type.
+		if json.Unmarshal([]byte(v.ValueString()), &decoded) == nil {
+			r.walkJSON(p, name == "when", decoded, diags)
+		}
+	case types.String:
+		s := v.ValueString()
+		if name == "left" || name == "right" {
+			// Condition operands are references without braces.
+			r.checkReference(p, s, s, diags)
+		} else {
+			r.checkExpressions(p, s, diags)
+		}
+	case JSONListValue:
+		for i, element := range v.Elements() {
+			r.walk(p.AtListIndex(i), name, element, diags)
+		}
+	case types.List:
+		for i, element := range v.Elements() {
+			r.walk(p.AtListIndex(i), name, element, diags)
+		}
+	case types.Map:
+		elements := v.Elements()
+		for _, key := range sortedKeys(elements) {
+			r.walk(p.AtMapKey(key), name, elements[key], diags)
+		}
+	case types.Object:
+		attributes := v.Attributes()
+		for _, key := range sortedKeys(attributes) {
+			r.walk(p.AtName(key), key, attributes[key], diags)
+		}
+	}
+}
+
+// walkJSON checks the references of the strings of a JSON value. The strings
+// of a `when` are also condition operands.
+func (r ruleReferences) walkJSON(p path.Path, operands bool, value interface{}, diags *diag.Diagnostics) {
+	switch v := value.(type) {
+	case string:
+		if operands {
+			r.checkReference(p, v, v, diags)
+		}
+		r.checkExpressions(p, v, diags)
+	case []interface{}:
+		for _, element := range v {
+			r.walkJSON(p, operands, element, diags)
+		}
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			r.walkJSON(p, operands, v[key], diags)
+		}
+	}
+}
+
+func (r ruleReferences) checkExpressions(p path.Path, s string, diags *diag.Diagnostics) {
+	references, err := templateReferences(s)
+	if err != nil {
+		diags.AddAttributeError(p, "Invalid template expression", fmt.Sprintf("%q: %s.", s, err))
+		return
+	}
+	seen := map[string]bool{}
+	for _, reference := range references {
+		if !seen[reference] {
+			seen[reference] = true
+			r.checkReference(p, s, reference, diags)
+		}
+	}
+}
+
+// checkReference reports a `queries.<name>` or `templates.<key>` reference
+// that does not resolve. value is the configured value the reference is in.
+func (r ruleReferences) checkReference(p path.Path, value, reference string, diags *diag.Diagnostics) {
+	root, name, _ := strings.Cut(reference, ".")
+	name, _, _ = strings.Cut(name, ".")
+	if name == "" {
+		return
+	}
+
+	switch {
+	case root == "queries" && r.queriesKnown && !containsString(r.queries, name):
+		diags.AddAttributeError(p, "Unknown query reference",
+			fmt.Sprintf("%q references the query %q, which is not a query of the rule's question. The queries are: %s.",
+				value, name, strings.Join(r.queries, ", ")))
+	case root == "templates" && r.templatesKnown && !containsString(r.templates, name):
+		available := "The rule has no templates."
+		if len(r.templates) > 0 {
+			available = fmt.Sprintf("The templates are: %s.", strings.Join(r.templates, ", "))
+		}
+		diags.AddAttributeError(p, "Unknown template reference",
+			fmt.Sprintf("%q references the template %q, which is not a key of templates. %s", value, name, available))
+	}
+}
+
+// templateReferences returns the property paths referenced by the `{{...}}`
+// expressions of s, such as `queries.query0.data` in
+// `{{queries.query0.data|mapProperty('displayName')|join(', ')}}`. Paths in
+// string literals are skipped.
+func templateReferences(s string) ([]string, error) {
+	var references []string
+	for {
+		start := strings.Index(s, "{{")
+		if start < 0 {
+			return references, nil
+		}
+		end := strings.Index(s[start:], "}}")
+		if end < 0 {
+			return nil, fmt.Errorf("the expression at offset %d is not closed with }}", start)
+		}
+		references = append(references, expressionPaths(s[start+2:start+end])...)
+		s = s[start+end+2:]
+	}
+}
+
+func expressionPaths(expression string) []string {
+	var paths []string
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == '\'' || c == '"':
+			// Skip the string literal.
+			i++
+			for i < len(expression) && expression[i] != c {
+				if expression[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i++
+		case isIdentifierStart(c) && (i == 0 || expression[i-1] != '.'):
+			start := i
+			for i < len(expression) && (isIdentifierStart(expression[i]) || isDigit(expression[i]) || expression[i] == '.') {
+				i++
+			}
+			paths = append(paths, strings.TrimRight(expression[start:i], "."))
+		default:
+			i++
+		}
+	}
+	return paths
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || c == '$' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
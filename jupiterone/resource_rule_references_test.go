@@ -0,0 +1,157 @@
+package jupiterone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateReferences(t *testing.T) {
+	references, err := templateReferences(`{{alertWebLink}} * {{queries.query0.data|mapProperty('tag.AccountName')|join('\n* ')}} {{ templates.table }}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alertWebLink", "queries.query0.data", "mapProperty", "join", "templates.table"}, references,
+		"paths in string literals are skipped")
+
+	references, err = templateReferences("no expressions")
+	require.NoError(t, err)
+	assert.Empty(t, references)
+
+	_, err = templateReferences("{{queries.query0.total")
+	assert.ErrorContains(t, err, "not closed")
+}
+
+// ruleConfig returns the config of the rule.
+func ruleConfig(t *testing.T, rule *RuleModel) tfsdk.Config {
+	ctx := context.TODO()
+
+	schemaResp := &resource.SchemaResponse{}
+	NewQuestionRuleResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	if rule.Outputs.IsNull() {
+		rule.Outputs = types.ListNull(types.StringType)
+	}
+	rule.Tags = types.ListNull(types.StringType)
+	rule.Labels = types.ListNull(types.ObjectType{AttrTypes: map[string]attr.Type{
+		"label_name":  types.StringType,
+		"label_value": types.StringType,
+	}})
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	require.False(t, state.Set(ctx, rule).HasError())
+
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+}
+
+// validateRuleConfig runs the rule config validators on the configuration of
+// the rule.
+func validateRuleConfig(t *testing.T, rule *RuleModel) diag.Diagnostics {
+	req := resource.ValidateConfigRequest{Config: ruleConfig(t, rule)}
+	resp := &resource.ValidateConfigResponse{}
+	ruleReferencesValidator{}.ValidateResource(context.TODO(), req, resp)
+	return resp.Diagnostics
+}
+
+func diagnosticPaths(diags diag.Diagnostics) []path.Path {
+	paths := make([]path.Path, 0, len(diags))
+	for _, d := range diags {
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			paths = append(paths, withPath.Path())
+		}
+	}
+	return paths
+}
+
+func TestRuleReferencesValidator(t *testing.T) {
+	question := []*RuleQuestion{
+		{Queries: []*J1QueryInputModel{{Name: "query0", Query: "FIND Host", Version: "v1"}}},
+	}
+
+	diags := validateRuleConfig(t, &RuleModel{
+		Question:  question,
+		Templates: map[string]string{"table": "{{queries.query0.data|mapProperty('displayName')}}"},
+		Outputs: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("queries.query0.total"),
+			types.StringValue("alertLevel"),
+		}),
+		Operations: []RuleOperation{
+			{
+				When: NewJSONValue(`{"type":"FILTER","condition":["AND",["queries.query0.total",">",0]]}`),
+				TypedActions: []RuleAction{
+					{JSON: NewJSONValue(`{"type":"SEND_EMAIL","recipients":["a@example.com"],"body":"{{templates.table}} {{queries.query0.total}}"}`)},
+					{
+						SendSlackMessage: &SendSlackMessageAction{IntegrationInstanceId: types.StringValue("slack"), Channels: []string{"#alerts"}, Body: types.StringValue("{{alertWebLink}}")},
+						JSON:             NewJSONNull(),
+					},
+				},
+			},
+		},
+	})
+	assert.Empty(t, diags, "valid references")
+
+	diags = validateRuleConfig(t, &RuleModel{
+		Question:  question,
+		Templates: map[string]string{"table": "{{queries.hosts.data}}"},
+		Outputs:   types.ListValueMust(types.StringType, []attr.Value{types.StringValue("queries.hosts.total")}),
+		Operations: []RuleOperation{
+			{
+				When: NewJSONValue(`{"type":"FILTER","condition":["AND",["queries.hosts.total",">",0]]}`),
+				Condition: &RuleCondition{
+					Conditions: []RuleConditionComparison{
+						ruleComparison("queries.query0.total", ">", "0"),
+						ruleComparison("queries.query1.total", ">", "queries.hosts.total"),
+					},
+				},
+				TypedActions: []RuleAction{
+					{JSON: NewJSONValue(`{"type":"SEND_EMAIL","body":"{{templates.list}} {{templates.list}}"}`)},
+					{
+						SendEmail: &SendEmailAction{Recipients: []string{"a@example.com"}, Body: types.StringValue("{{queries.query0.total")},
+						JSON:      NewJSONNull(),
+					},
+				},
+			},
+		},
+	})
+
+	operation := path.Root("operations").AtListIndex(0)
+	conditions := operation.AtName("condition").AtName("conditions").AtListIndex(1)
+	assert.Equal(t, []path.Path{
+		path.Root("outputs").AtListIndex(0),
+		path.Root("templates").AtMapKey("table"),
+		conditions.AtName("left"),
+		conditions.AtName("right"),
+		operation.AtName("typed_actions").AtListIndex(0).AtName("json"),
+		operation.AtName("typed_actions").AtListIndex(1).AtName("send_email").AtName("body"),
+		operation.AtName("when"),
+	}, diagnosticPaths(diags))
+
+	assert.Contains(t, diags[4].Detail(), `references the template "list", which is not a key of templates. The templates are: table.`)
+	assert.Contains(t, diags[2].Detail(), `references the query "query1"`)
+	assert.Contains(t, diags[2].Detail(), "The queries are: query0.")
+	assert.Equal(t, "Invalid template expression", diags[5].Summary())
+}
+
+func TestRuleReferencesValidatorSkipsReferencedQuestions(t *testing.T) {
+	diags := validateRuleConfig(t, &RuleModel{
+		QuestionId: types.StringValue("question"),
+		Outputs:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("queries.anything.total")}),
+		Operations: []RuleOperation{
+			{Actions: []string{`{"type":"SEND_EMAIL","body":"{{templates.table}}"}`}},
+		},
+	})
+
+	require.Len(t, diags, 1, "query references are not checked without a question block")
+	assert.Contains(t, diags[0].Detail(), "The rule has no templates.")
+}
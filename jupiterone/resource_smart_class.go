@@ -32,6 +32,7 @@ type SmartClassRule struct {
 
 type SmartClass struct {
 	Id          types.String `json:"id,omitempty" tfsdk:"id"`
+	AccountId   types.String `json:"account_id,omitempty" tfsdk:"account_id"`
 	Description types.String `json:"description,omitempty" tfsdk:"description"`
 	TagName     types.String `json:"tag_name,omitempty" tfsdk:"tag_name"`
 }
@@ -44,13 +45,15 @@ func (r *SmartClassResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	mutationResult, err := client.CreateSmartClass(ctx, r.qlient, client.CreateSmartClassInput{
 		TagName:     data.TagName.ValueString(),
 		Description: data.Description.ValueString(),
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create smart class", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to create smart class", err)
 		return
 	}
 
@@ -70,12 +73,14 @@ func (r *SmartClassResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	smartClass, err := client.GetSmartClass(ctx, r.qlient, data.Id.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "not found") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to get smart class", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to get smart class", err)
 		}
 		return
 	}
@@ -83,6 +88,8 @@ func (r *SmartClassResource) Read(ctx context.Context, req resource.ReadRequest,
 	data.Id = types.StringValue(smartClass.SmartClass.Id)
 	data.TagName = types.StringValue(smartClass.SmartClass.TagName)
 	data.Description = types.StringValue(smartClass.SmartClass.Description)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *SmartClassResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -94,11 +101,13 @@ func (r *SmartClassResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.UpdateSmartClass(ctx, r.qlient, client.PatchSmartClassInput{
 		Id:          data.Id.ValueString(),
 		Description: data.Description.ValueString(),
 	}); err != nil {
-		resp.Diagnostics.AddError("Failed to update smart class", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to update smart class", err)
 		return
 	}
 
@@ -116,13 +125,15 @@ func (r *SmartClassResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteSmartClass(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Failed to delete smart class", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to delete smart class", err)
 	}
 }
 
 func (r *SmartClassResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 func (r *SmartClassResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -157,6 +168,7 @@ func (r *SmartClassResource) Schema(ctx context.Context, req resource.SchemaRequ
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"tag_name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the smart class and the name of the tag that will be added to each entity returned from this class's queries. Must start with a capital letter, be alphanumeric, and contain no spaces.",
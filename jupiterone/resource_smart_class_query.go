@@ -11,16 +11,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
 )
 
 type SmartClassQuery struct {
-	Id           types.String `json:"id,omitempty" tfsdk:"id"`
-	Query        types.String `json:"query,omitempty" tfsdk:"query"`
-	SmartClassId types.String `json:"smart_class_id,omitempty" tfsdk:"smart_class_id"`
-	Description  types.String `json:"description,omitempty" tfsdk:"description"`
+	Id           types.String   `json:"id,omitempty" tfsdk:"id"`
+	AccountId    types.String   `json:"account_id,omitempty" tfsdk:"account_id"`
+	Query        J1QLQueryValue `json:"query,omitempty" tfsdk:"query"`
+	SmartClassId types.String   `json:"smart_class_id,omitempty" tfsdk:"smart_class_id"`
+	Description  types.String   `json:"description,omitempty" tfsdk:"description"`
 }
 
 func NewSmartClassQueryResource() resource.Resource {
@@ -30,6 +32,7 @@ func NewSmartClassQueryResource() resource.Resource {
 type SmartClassQueryResource struct {
 	version string
 	qlient  graphql.Client
+	j1ql    *j1qlSettings
 }
 
 func (r *SmartClassQueryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,9 +49,18 @@ func (r *SmartClassQueryResource) Schema(_ context.Context, _ resource.SchemaReq
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"query": schema.StringAttribute{
-				Required:    true,
+				CustomType:  J1QLQueryType{},
+				Optional:    true,
+				Computed:    true,
 				Description: "The J1QL query to find entities for the smart class",
+				Validators: []validator.String{
+					configuredQuery(),
+				},
+				PlanModifiers: []planmodifier.String{
+					useStateForEquivalentQuery(),
+				},
 			},
 			"smart_class_id": schema.StringAttribute{
 				Required:    true,
@@ -83,10 +95,21 @@ func (r *SmartClassQueryResource) Configure(_ context.Context, req resource.Conf
 
 	r.version = p.version
 	r.qlient = p.Qlient
+	r.j1ql = &p.j1ql
+}
+
+// ConfigValidators implements resource.ResourceWithConfigValidators
+func (r *SmartClassQueryResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		j1qlValidator{
+			settings: r.j1ql,
+			queries:  path.MatchRoot("query"),
+		},
+	}
 }
 
 func (r *SmartClassQueryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 func (r *SmartClassQueryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -97,6 +120,8 @@ func (r *SmartClassQueryResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	mutationResult, err := client.CreateSmartClassQuery(ctx, r.qlient, client.CreateSmartClassQueryInput{
 		Query:        data.Query.ValueString(),
 		SmartClassId: data.SmartClassId.ValueString(),
@@ -104,7 +129,7 @@ func (r *SmartClassQueryResource) Create(ctx context.Context, req resource.Creat
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create smart class query", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to create smart class query", err)
 		return
 	}
 
@@ -124,18 +149,20 @@ func (r *SmartClassQueryResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	smartClassQuery, err := client.GetSmartClassQuery(ctx, r.qlient, data.Id.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "not found") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("Failed to get smart class query", err.Error())
+			addAPIError(&resp.Diagnostics, "Failed to get smart class query", err)
 		}
 		return
 	}
 
 	data.Id = types.StringValue(smartClassQuery.SmartClassQuery.Id)
-	data.Query = types.StringValue(smartClassQuery.SmartClassQuery.Query)
+	data.Query = NewJ1QLQueryValue(smartClassQuery.SmartClassQuery.Query)
 	data.SmartClassId = types.StringValue(smartClassQuery.SmartClassQuery.SmartClassId)
 	data.Description = types.StringValue(smartClassQuery.SmartClassQuery.Description)
 }
@@ -148,6 +175,8 @@ func (r *SmartClassQueryResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.UpdateSmartClassQuery(ctx, r.qlient, client.PatchSmartClassQueryInput{
 		Id:          data.Id.ValueString(),
 		Query:       data.Query.ValueString(),
@@ -155,7 +184,7 @@ func (r *SmartClassQueryResource) Update(ctx context.Context, req resource.Updat
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update smart class query", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to update smart class query", err)
 		return
 	}
 
@@ -172,8 +201,10 @@ func (r *SmartClassQueryResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteSmartClassQuery(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Failed to delete smart class query", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to delete smart class query", err)
 		return
 	}
 
@@ -20,6 +20,7 @@ import (
 
 type SmartClassTag struct {
 	Id           types.String `json:"id,omitempty" tfsdk:"id"`
+	AccountId    types.String `json:"account_id,omitempty" tfsdk:"account_id"`
 	SmartClassId types.String `json:"smart_class_id,omitempty" tfsdk:"smart_class_id"`
 	Name         types.String `json:"name,omitempty" tfsdk:"name"`
 	Type         types.String `json:"type,omitempty" tfsdk:"type"`
@@ -49,6 +50,7 @@ func (r *SmartClassTagResource) Schema(_ context.Context, _ resource.SchemaReque
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"smart_class_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The ID of the smart class to associate the tag with",
@@ -96,7 +98,7 @@ func (r *SmartClassTagResource) Configure(_ context.Context, req resource.Config
 }
 
 func (r *SmartClassTagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 func (r *SmartClassTagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -107,6 +109,8 @@ func (r *SmartClassTagResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	mutationResult, err := client.CreateSmartClassTag(ctx, r.qlient, client.CreateSmartClassTagInput{
 		SmartClassId: data.SmartClassId.ValueString(),
 		Type:         client.SmartClassTagType(data.Type.ValueString()),
@@ -115,7 +119,7 @@ func (r *SmartClassTagResource) Create(ctx context.Context, req resource.CreateR
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create smart class Tag", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to create smart class Tag", err)
 		return
 	}
 
@@ -135,10 +139,16 @@ func (r *SmartClassTagResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	smartClass, err := client.GetSmartClass(ctx, r.qlient, data.SmartClassId.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read smart class Tag", err.Error())
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Failed to read smart class Tag", err)
 		return
 	}
 
@@ -153,7 +163,7 @@ func (r *SmartClassTagResource) Read(ctx context.Context, req resource.ReadReque
 	}
 
 	if !found {
-		resp.Diagnostics.AddError("Failed to read smart class Tag", "Tag not found on smart class")
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
@@ -162,6 +172,8 @@ func (r *SmartClassTagResource) Read(ctx context.Context, req resource.ReadReque
 	data.Name = types.StringValue(smartClassTag.Name)
 	data.Value = types.StringValue(smartClassTag.Value)
 	data.SmartClassId = types.StringValue(data.SmartClassId.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *SmartClassTagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -172,6 +184,8 @@ func (r *SmartClassTagResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.UpdateSmartClassTag(ctx, r.qlient, client.PatchSmartClassTagInput{
 		Id:    data.Id.ValueString(),
 		Type:  client.SmartClassTagType(data.Type.ValueString()),
@@ -180,7 +194,7 @@ func (r *SmartClassTagResource) Update(ctx context.Context, req resource.UpdateR
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update smart class Tag", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to update smart class Tag", err)
 		return
 	}
 
@@ -197,8 +211,10 @@ func (r *SmartClassTagResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteSmartClassTag(ctx, r.qlient, data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Failed to delete smart class Tag", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to delete smart class Tag", err)
 		return
 	}
 
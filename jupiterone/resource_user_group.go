@@ -2,7 +2,6 @@ package jupiterone
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -25,6 +24,7 @@ type UserGroupResource struct {
 // UserGroupModel is the terraform HCL representation of a user group.
 type UserGroupModel struct {
 	Id          types.String          `json:"id,omitempty" tfsdk:"id"`
+	AccountId   types.String          `json:"account_id,omitempty" tfsdk:"account_id"`
 	Name        types.String          `json:"groupName,omitempty" tfsdk:"name"`
 	Description types.String          `json:"groupDescription,omitempty" tfsdk:"description"`
 	Permissions []string              `json:"groupAbacPermission,omitempty" tfsdk:"permissions"`
@@ -51,6 +51,7 @@ func (*UserGroupResource) Schema(ctx context.Context, req resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the user group.",
@@ -109,16 +110,12 @@ func (r *UserGroupResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	var queryPolicy []interface{}
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
 
-	for _, statementData := range data.QueryPolicy {
-		var queryPolicyStatement = make(map[string]interface{})
-
-		for key, value := range statementData {
-			queryPolicyStatement[key] = value
-		}
-
-		queryPolicy = append(queryPolicy, queryPolicyStatement)
+	queryPolicy, err := buildQueryPolicy(data.QueryPolicy)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to build query policy", err.Error())
+		return
 	}
 
 	created, err := client.CreateUserGroup(
@@ -131,7 +128,7 @@ func (r *UserGroupResource) Create(ctx context.Context, req resource.CreateReque
 	)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create user group", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create user group", err)
 		return
 	}
 
@@ -154,8 +151,10 @@ func (r *UserGroupResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteUserGroup(ctx, r.qlient, data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("failed to delete group", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete group", err)
 	}
 }
 
@@ -170,12 +169,14 @@ func (r *UserGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	group, err := client.GetUserGroup(ctx, r.qlient, data.Id.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "does not exist") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "does not exist") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to get user group", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to get user group", err)
 		}
 		return
 	}
@@ -184,36 +185,10 @@ func (r *UserGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 	data.Description = types.StringValue(group.IamGetGroup.GroupDescription)
 	data.Permissions = group.IamGetGroup.GroupAbacPermission.Statement
 
-	// Convert from []interface{} to []map[string][]string
-	var queryPolicy []map[string][]string
-
-	for _, statementData := range group.IamGetGroup.GroupQueryPolicy.Statement {
-		var queryPolicyStatement = make(map[string][]string) // Initialize the map
-
-		if statementDataMap, ok := statementData.(map[string]interface{}); ok {
-			for key, value := range statementDataMap {
-				// Was unable to parse the []string from the JSON response in any other way.
-				// So we convert the value to a string and then unmarshal it into a []string.
-				stringValue, stringifyError := json.Marshal(value)
-
-				if stringifyError != nil {
-					resp.Diagnostics.AddError("failed to parse query policy", stringifyError.Error())
-					return
-				}
-
-				var arrayValue []string
-				parseError := json.Unmarshal(stringValue, &arrayValue)
-
-				if parseError != nil {
-					resp.Diagnostics.AddError("failed to parse query policy", parseError.Error())
-					return
-				}
-
-				queryPolicyStatement[key] = arrayValue
-			}
-		}
-
-		queryPolicy = append(queryPolicy, queryPolicyStatement)
+	queryPolicy, err := readQueryPolicy(group.IamGetGroup.GroupQueryPolicy.Statement)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to parse query policy", err.Error())
+		return
 	}
 
 	data.QueryPolicy = queryPolicy
@@ -224,7 +199,7 @@ func (r *UserGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // ImportState implements resource.ResourceWithImportState
 func (*UserGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Update implements resource.Resource
@@ -238,20 +213,15 @@ func (r *UserGroupResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	// Convert from  []map[string][]string to []interface{}
-	var queryPolicy []interface{}
-
-	for _, statementData := range data.QueryPolicy {
-		var queryPolicyStatement = make(map[string]interface{}) // Initialize the map
-
-		for key, value := range statementData {
-			queryPolicyStatement[key] = value
-		}
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
 
-		queryPolicy = append(queryPolicy, queryPolicyStatement)
+	queryPolicy, err := buildQueryPolicy(data.QueryPolicy)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to build query policy", err.Error())
+		return
 	}
 
-	_, err := client.UpdateUserGroup(
+	_, err = client.UpdateUserGroup(
 		ctx,
 		r.qlient,
 		data.Id.ValueString(),
@@ -262,7 +232,7 @@ func (r *UserGroupResource) Update(ctx context.Context, req resource.UpdateReque
 	)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update user group", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update user group", err)
 		return
 	}
 
@@ -271,3 +241,29 @@ func (r *UserGroupResource) Update(ctx context.Context, req resource.UpdateReque
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// buildQueryPolicy encodes the statements of a query policy for the API.
+func buildQueryPolicy(statements []map[string][]string) ([]client.JSON, error) {
+	var queryPolicy []client.JSON
+	for _, statement := range statements {
+		j, err := client.NewJSON(statement)
+		if err != nil {
+			return nil, err
+		}
+		queryPolicy = append(queryPolicy, j)
+	}
+	return queryPolicy, nil
+}
+
+// readQueryPolicy decodes the statements of a query policy of the API.
+func readQueryPolicy(statements []client.JSON) ([]map[string][]string, error) {
+	var queryPolicy []map[string][]string
+	for _, statement := range statements {
+		queryPolicyStatement := make(map[string][]string)
+		if err := statement.Decode(&queryPolicyStatement); err != nil {
+			return nil, err
+		}
+		queryPolicy = append(queryPolicy, queryPolicyStatement)
+	}
+	return queryPolicy, nil
+}
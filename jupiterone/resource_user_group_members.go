@@ -0,0 +1,437 @@
+package jupiterone
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+)
+
+var _ resource.Resource = &UserGroupMembersResource{}
+var _ resource.ResourceWithConfigure = &UserGroupMembersResource{}
+var _ resource.ResourceWithImportState = &UserGroupMembersResource{}
+var _ resource.ResourceWithValidateConfig = &UserGroupMembersResource{}
+
+// invitationStatusPending is the status of invitations that have not been
+// accepted or revoked yet.
+const invitationStatusPending = "PENDING"
+
+type UserGroupMembersResource struct {
+	version string
+	qlient  graphql.Client
+}
+
+// UserGroupMembersModel is the terraform HCL representation of all the
+// members of a user group.
+type UserGroupMembersModel struct {
+	Id                 types.String `json:"id,omitempty" tfsdk:"id"`
+	AccountId          types.String `json:"account_id,omitempty" tfsdk:"account_id"`
+	GroupId            types.String `json:"groupId,omitempty" tfsdk:"group_id"`
+	Emails             []string     `json:"emails" tfsdk:"emails"`
+	Members            types.Set    `json:"members" tfsdk:"members"`
+	PendingInvitations types.Set    `json:"pending_invitations" tfsdk:"pending_invitations"`
+}
+
+func NewUserGroupMembersResource() resource.Resource {
+	return &UserGroupMembersResource{}
+}
+
+// Metadata implements resource.Resource
+func (*UserGroupMembersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_group_members"
+}
+
+// Schema implements resource.Resource
+func (*UserGroupMembersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The members of a JupiterOne User Group. The resource is authoritative: users that are not " +
+			"listed are removed from the group and their invitations are revoked. It should not be used together " +
+			"with `jupiterone_user_group_membership` for the same group.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_id": resourceAccountIdAttribute(),
+			"group_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the group.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"emails": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The emails of the users in the group. Users that are not in the account yet are invited. Emails are compared case-insensitively.",
+			},
+			"members": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The emails of the users that are members of the group.",
+			},
+			"pending_invitations": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The emails of the users that have been invited to the group and have not accepted the invitation yet.",
+			},
+		},
+	}
+}
+
+// Configure implements resource.ResourceWithConfigure
+func (r *UserGroupMembersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*JupiterOneProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected JupiterOneProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.version = p.version
+	r.qlient = p.Qlient
+}
+
+// ValidateConfig implements resource.ResourceWithValidateConfig
+func (*UserGroupMembersResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var emails types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("emails"), &emails)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Emails are compared case-insensitively, so emails that only differ in
+	// case are the same user.
+	seen := map[string]string{}
+	for _, e := range emails.Elements() {
+		email, ok := e.(types.String)
+		if !ok || email.IsNull() || email.IsUnknown() {
+			continue
+		}
+		key := strings.ToLower(email.ValueString())
+		if other, ok := seen[key]; ok {
+			resp.Diagnostics.AddAttributeError(path.Root("emails"), "Duplicate Email",
+				fmt.Sprintf("The emails %q and %q are the same user, emails are compared case-insensitively.", other, email.ValueString()))
+			continue
+		}
+		seen[key] = email.ValueString()
+	}
+}
+
+// ImportState implements resource.ResourceWithImportState
+func (*UserGroupMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateWithAccount(ctx, path.Root("group_id"), req, resp)
+}
+
+// Create implements resource.Resource
+func (r *UserGroupMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserGroupMembersModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
+	if err := setGroupMembers(ctx, r.qlient, data.GroupId.ValueString(), data.Emails); err != nil {
+		addAPIError(&resp.Diagnostics, "failed to set user group members", err)
+		return
+	}
+
+	tflog.Trace(ctx, "Set user group members",
+		map[string]interface{}{"groupId": data.GroupId, "emails": data.Emails})
+
+	members, err := getGroupMembers(ctx, r.qlient, data.GroupId.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to get user group members", err)
+		return
+	}
+
+	data.Id = data.GroupId
+	resp.Diagnostics.Append(data.setMembers(ctx, members)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements resource.Resource
+func (r *UserGroupMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserGroupMembersModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
+	if _, err := client.GetUserGroup(ctx, r.qlient, data.GroupId.ValueString()); err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+		} else {
+			addAPIError(&resp.Diagnostics, "failed to get user group", err)
+		}
+		return
+	}
+
+	members, err := getGroupMembers(ctx, r.qlient, data.GroupId.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to get user group members", err)
+		return
+	}
+
+	// Keep the spelling of the emails in state, and add the users that were
+	// added to the group outside of Terraform.
+	emails := []string{}
+	known := map[string]bool{}
+	for _, email := range data.Emails {
+		key := strings.ToLower(email)
+		if members.contains(key) && !known[key] {
+			emails = append(emails, email)
+			known[key] = true
+		}
+	}
+	for _, key := range members.keys() {
+		if !known[key] {
+			emails = append(emails, members.emails[key])
+		}
+	}
+	data.Emails = emails
+	data.Id = data.GroupId
+
+	resp.Diagnostics.Append(data.setMembers(ctx, members)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements resource.Resource
+func (r *UserGroupMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data UserGroupMembersModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
+	if err := setGroupMembers(ctx, r.qlient, data.GroupId.ValueString(), data.Emails); err != nil {
+		addAPIError(&resp.Diagnostics, "failed to set user group members", err)
+		return
+	}
+
+	tflog.Trace(ctx, "Set user group members",
+		map[string]interface{}{"groupId": data.GroupId, "emails": data.Emails})
+
+	members, err := getGroupMembers(ctx, r.qlient, data.GroupId.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to get user group members", err)
+		return
+	}
+
+	data.Id = data.GroupId
+	resp.Diagnostics.Append(data.setMembers(ctx, members)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements resource.Resource
+func (r *UserGroupMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UserGroupMembersModel
+
+	// Read Terraform state into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
+	// Only the users in state are removed, so users added to the group after
+	// the last refresh keep their membership.
+	members, err := getGroupMembers(ctx, r.qlient, data.GroupId.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "failed to get user group members", err)
+		return
+	}
+	var remaining []string
+	removed := lowerEmails(data.Emails)
+	for _, key := range members.keys() {
+		if !removed[key] {
+			remaining = append(remaining, members.emails[key])
+		}
+	}
+
+	if err := setGroupMembers(ctx, r.qlient, data.GroupId.ValueString(), remaining); err != nil {
+		addAPIError(&resp.Diagnostics, "failed to remove user group members", err)
+	}
+}
+
+// setMembers sets the computed members and pending invitations of the group,
+// in the spelling of the emails of the model.
+func (gm *UserGroupMembersModel) setMembers(ctx context.Context, members *groupMembers) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	spelling := map[string]string{}
+	for _, email := range gm.Emails {
+		spelling[strings.ToLower(email)] = email
+	}
+
+	accepted := []string{}
+	pending := []string{}
+	for _, key := range members.keys() {
+		email, ok := spelling[key]
+		if !ok {
+			email = members.emails[key]
+		}
+		if _, ok := members.users[key]; ok {
+			accepted = append(accepted, email)
+		} else {
+			pending = append(pending, email)
+		}
+	}
+
+	var d diag.Diagnostics
+	gm.Members, d = types.SetValueFrom(ctx, types.StringType, accepted)
+	diags.Append(d...)
+	gm.PendingInvitations, d = types.SetValueFrom(ctx, types.StringType, pending)
+	diags.Append(d...)
+	return diags
+}
+
+// groupMembers are the members of a user group and the users with open
+// invitations to it, keyed by their lower case email.
+type groupMembers struct {
+	emails      map[string]string
+	users       map[string]string
+	invitations map[string][]string
+}
+
+func (m *groupMembers) contains(key string) bool {
+	_, ok := m.emails[key]
+	return ok
+}
+
+// keys returns the lower case emails in order.
+func (m *groupMembers) keys() []string {
+	keys := make([]string, 0, len(m.emails))
+	for key := range m.emails {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getGroupMembers returns the members of the group, which are users that
+// accepted an invitation to it, and the users invited to it.
+func getGroupMembers(ctx context.Context, qlient graphql.Client, groupId string) (*groupMembers, error) {
+	members := &groupMembers{
+		emails:      map[string]string{},
+		users:       map[string]string{},
+		invitations: map[string][]string{},
+	}
+
+	users, err := getAllUsers(ctx, qlient)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		for _, group := range user.UserGroups.Items {
+			if group.Id == groupId {
+				key := strings.ToLower(user.Email)
+				members.emails[key] = user.Email
+				members.users[key] = user.Id
+			}
+		}
+	}
+
+	invitations, err := getAllInvitations(ctx, qlient)
+	if err != nil {
+		return nil, err
+	}
+	for _, invite := range invitations {
+		if invite.GroupId != groupId || (invite.Status != "" && invite.Status != invitationStatusPending) {
+			continue
+		}
+		key := strings.ToLower(invite.Email)
+		if _, ok := members.emails[key]; !ok {
+			members.emails[key] = invite.Email
+		}
+		members.invitations[key] = append(members.invitations[key], invite.Id)
+	}
+
+	return members, nil
+}
+
+// setGroupMembers invites the users with the emails that are not members of
+// the group, and removes the other members and invitations of the group.
+func setGroupMembers(ctx context.Context, qlient graphql.Client, groupId string, emails []string) error {
+	members, err := getGroupMembers(ctx, qlient, groupId)
+	if err != nil {
+		return err
+	}
+
+	wanted := lowerEmails(emails)
+	for _, email := range emails {
+		key := strings.ToLower(email)
+		if !members.contains(key) {
+			if _, err := client.InviteUser(ctx, qlient, email, groupId); err != nil {
+				return err
+			}
+			// Duplicates in other cases are only invited once.
+			members.emails[key] = email
+		}
+	}
+
+	for _, key := range members.keys() {
+		if wanted[key] {
+			continue
+		}
+		if _, ok := members.users[key]; ok {
+			if _, err := client.RemoveUserFromGroup(ctx, qlient, members.emails[key], groupId); err != nil {
+				return err
+			}
+		}
+		for _, id := range members.invitations[key] {
+			if _, err := client.RevokeInvitation(ctx, qlient, id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func lowerEmails(emails []string) map[string]bool {
+	lower := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		lower[strings.ToLower(email)] = true
+	}
+	return lower
+}
@@ -0,0 +1,116 @@
+package jupiterone
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jupiterone/terraform-provider-jupiterone/jupiterone/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetGroupMembers(t *testing.T) {
+	ctx := context.TODO()
+
+	server, qlient := setupFakeServer(ctx, t)
+
+	group, err := client.CreateUserGroup(ctx, qlient, "members", "", nil, nil)
+	require.NoError(t, err)
+	groupId := group.CreateIamGroup.Id
+
+	server.AddUser("member@example.com", groupId)
+	server.AddUser("extra@example.com", groupId)
+	_, err = client.InviteUser(ctx, qlient, "invited@example.com", groupId)
+	require.NoError(t, err)
+
+	err = setGroupMembers(ctx, qlient, groupId, []string{"Member@example.com", "new@example.com"})
+	require.NoError(t, err)
+
+	members, err := getGroupMembers(ctx, qlient, groupId)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"member@example.com", "new@example.com"}, members.keys())
+	assert.Contains(t, members.users, "member@example.com", "members are compared case-insensitively")
+	assert.Contains(t, members.invitations, "new@example.com")
+
+	assert.Equal(t, 2, server.Requests("InviteUser"), "only the missing user is invited")
+	assert.Equal(t, 1, server.Requests("RemoveUserFromGroup"))
+	assert.Equal(t, 1, server.Requests("RevokeInvitation"))
+
+	model := UserGroupMembersModel{Emails: []string{"Member@example.com", "new@example.com"}}
+	require.False(t, model.setMembers(ctx, members).HasError())
+	assert.Equal(t, `["Member@example.com"]`, model.Members.String())
+	assert.Equal(t, `["new@example.com"]`, model.PendingInvitations.String())
+}
+
+func TestUserGroupMembers_FakeServer(t *testing.T) {
+	ctx := context.TODO()
+
+	server, qlient := setupFakeServer(ctx, t)
+
+	resourceName := "jupiterone_user_group_members.test"
+	var groupId string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(qlient),
+		Steps: []resource.TestStep{
+			{
+				Config:      testUserGroupMembersConfig(`"a@example.com", "A@example.com"`),
+				ExpectError: regexp.MustCompile(`Duplicate Email`),
+			},
+			{
+				Config: testUserGroupMembersConfig(`"a@example.com", "b@example.com"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "emails.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "pending_invitations.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "0"),
+					func(s *terraform.State) error {
+						groupId = s.RootModule().Resources[resourceName].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// A user added outside of Terraform is removed.
+				PreConfig: func() {
+					server.AddUser("C@example.com", groupId)
+				},
+				Config:             testUserGroupMembersConfig(`"a@example.com", "b@example.com"`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testUserGroupMembersConfig(`"a@example.com", "c@example.com"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "emails.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "members.*", "c@example.com"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "pending_invitations.*", "a@example.com"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Imported emails are spelled as in the account.
+				ImportStateVerifyIgnore: []string{"emails", "members"},
+			},
+		},
+	})
+}
+
+func testUserGroupMembersConfig(emails string) string {
+	return fmt.Sprintf(`
+		resource "jupiterone_user_group" "test" {
+			name = "tf-provider-test-user-group-members"
+			description = "Test"
+			permissions = []
+		}
+
+		resource "jupiterone_user_group_members" "test" {
+			group_id = jupiterone_user_group.test.id
+			emails = [%s]
+		}
+	`, emails)
+}
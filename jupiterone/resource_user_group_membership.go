@@ -19,9 +19,10 @@ type UserGroupMembershipResource struct {
 
 // UserGroupMembershipModel is the terraform HCL representation of a user group membership.
 type UserGroupMembershipModel struct {
-	Id      types.String `json:"id,omitempty" tfsdk:"id"`
-	GroupId types.String `json:"groupId,omitempty" tfsdk:"group_id"`
-	Email   types.String `json:"email,omitempty" tfsdk:"email"`
+	Id        types.String `json:"id,omitempty" tfsdk:"id"`
+	AccountId types.String `json:"account_id,omitempty" tfsdk:"account_id"`
+	GroupId   types.String `json:"groupId,omitempty" tfsdk:"group_id"`
+	Email     types.String `json:"email,omitempty" tfsdk:"email"`
 }
 
 func NewUserGroupMembershipResource() resource.Resource {
@@ -41,6 +42,7 @@ func (*UserGroupMembershipResource) Schema(ctx context.Context, req resource.Sch
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"group_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The id of the group to add the user to.",
@@ -85,6 +87,8 @@ func (r *UserGroupMembershipResource) Create(ctx context.Context, req resource.C
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	_, err := client.InviteUser(
 		ctx,
 		r.qlient,
@@ -93,7 +97,7 @@ func (r *UserGroupMembershipResource) Create(ctx context.Context, req resource.C
 	)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create user group membership", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create user group membership", err)
 		return
 	}
 
@@ -116,23 +120,29 @@ func (r *UserGroupMembershipResource) Delete(ctx context.Context, req resource.D
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	// We need to delete the user from the group if the user is part of the group
 	var usersResponse, getUserErr = client.GetUsersByEmail(ctx, r.qlient, data.Email.ValueString())
 
 	if getUserErr != nil {
-		resp.Diagnostics.AddError("failed to get user", getUserErr.Error())
+		addAPIError(&resp.Diagnostics, "failed to get user", getUserErr)
 		return
 	}
 
 	// User exists in this account, check for the group and remove them
 	if len(usersResponse.IamGetUserList.Items) > 0 {
 		var user = usersResponse.IamGetUserList.Items[0]
+		if getGroupsErr := getAllUserGroups(ctx, r.qlient, &user); getGroupsErr != nil {
+			addAPIError(&resp.Diagnostics, "failed to get user groups", getGroupsErr)
+			return
+		}
 
 		// Iterate through groups and remove the user from the group
 		for _, group := range user.UserGroups.Items {
 			if group.Id == data.GroupId.ValueString() {
 				if _, removeFromGroupErr := client.RemoveUserFromGroup(ctx, r.qlient, data.Email.ValueString(), group.Id); removeFromGroupErr != nil {
-					resp.Diagnostics.AddError("failed to remove user from group", removeFromGroupErr.Error())
+					addAPIError(&resp.Diagnostics, "failed to remove user from group", removeFromGroupErr)
 				}
 				tflog.Trace(ctx, "User was removed from group",
 					map[string]interface{}{"groupId": data.GroupId, "email": data.Email})
@@ -147,17 +157,17 @@ func (r *UserGroupMembershipResource) Delete(ctx context.Context, req resource.D
 	tflog.Trace(ctx, "User was not part of this group, removing any related invitations",
 		map[string]interface{}{"groupId": data.GroupId, "email": data.Email})
 
-	var invitations, getInvitesErr = client.GetInvitations(ctx, r.qlient)
+	var invitations, getInvitesErr = getAllInvitations(ctx, r.qlient)
 
 	if getInvitesErr != nil {
-		resp.Diagnostics.AddError("failed to get invitations", getInvitesErr.Error())
+		addAPIError(&resp.Diagnostics, "failed to get invitations", getInvitesErr)
 		return
 	}
 
-	for _, invite := range invitations.IamGetAccount.AccountInvitations.Items {
+	for _, invite := range invitations {
 		if invite.Email == data.Email.ValueString() && invite.GroupId == data.GroupId.ValueString() {
 			if _, removeInviteErr := client.RevokeInvitation(ctx, r.qlient, invite.Id); removeInviteErr != nil {
-				resp.Diagnostics.AddError("failed to remove invitation", removeInviteErr.Error())
+				addAPIError(&resp.Diagnostics, "failed to remove invitation", removeInviteErr)
 			}
 		}
 	}
@@ -174,17 +184,23 @@ func (r *UserGroupMembershipResource) Read(ctx context.Context, req resource.Rea
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	// If the user doesn't exist, is no longer in the group, or there are no open invitations
 	// then we should remove this resource from the state
 	var usersResponse, getUserErr = client.GetUsersByEmail(ctx, r.qlient, data.Email.ValueString())
 
 	if getUserErr != nil {
-		resp.Diagnostics.AddError("failed to get user", getUserErr.Error())
+		addAPIError(&resp.Diagnostics, "failed to get user", getUserErr)
 		return
 	}
 
 	if len(usersResponse.IamGetUserList.Items) > 0 {
 		var user = usersResponse.IamGetUserList.Items[0]
+		if getGroupsErr := getAllUserGroups(ctx, r.qlient, &user); getGroupsErr != nil {
+			addAPIError(&resp.Diagnostics, "failed to get user groups", getGroupsErr)
+			return
+		}
 
 		// Iterate through groups and remove the user from the group
 		for _, group := range user.UserGroups.Items {
@@ -199,14 +215,14 @@ func (r *UserGroupMembershipResource) Read(ctx context.Context, req resource.Rea
 	}
 
 	// Lets see if the user is part of the group by open invitation
-	var invitations, getInvitesErr = client.GetInvitations(ctx, r.qlient)
+	var invitations, getInvitesErr = getAllInvitations(ctx, r.qlient)
 
 	if getInvitesErr != nil {
-		resp.Diagnostics.AddError("failed to get invitations", getInvitesErr.Error())
+		addAPIError(&resp.Diagnostics, "failed to get invitations", getInvitesErr)
 		return
 	}
 
-	for _, invite := range invitations.IamGetAccount.AccountInvitations.Items {
+	for _, invite := range invitations {
 		if invite.Email == data.Email.ValueString() && invite.GroupId == data.GroupId.ValueString() {
 			// Membership exists, we can return early
 			tflog.Trace(ctx, "Found invitation for user")
@@ -233,25 +249,31 @@ func (r *UserGroupMembershipResource) Update(ctx context.Context, req resource.U
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	// We are going to remove the old user from the group and invite the new user
 
 	// We need to delete the user from the group if the user is part of the group
 	var usersResponse, getUserErr = client.GetUsersByEmail(ctx, r.qlient, currentState.Email.ValueString())
 
 	if getUserErr != nil {
-		resp.Diagnostics.AddError("failed to get user", getUserErr.Error())
+		addAPIError(&resp.Diagnostics, "failed to get user", getUserErr)
 		return
 	}
 
 	// User exists in this account, check for the group and remove them
 	if len(usersResponse.IamGetUserList.Items) > 0 {
 		var user = usersResponse.IamGetUserList.Items[0]
+		if getGroupsErr := getAllUserGroups(ctx, r.qlient, &user); getGroupsErr != nil {
+			addAPIError(&resp.Diagnostics, "failed to get user groups", getGroupsErr)
+			return
+		}
 
 		// Iterate through groups and remove the user from the group
 		for _, group := range user.UserGroups.Items {
 			if group.Id == currentState.GroupId.ValueString() {
 				if _, removeFromGroupErr := client.RemoveUserFromGroup(ctx, r.qlient, currentState.Email.ValueString(), group.Id); removeFromGroupErr != nil {
-					resp.Diagnostics.AddError("failed to remove user from group", removeFromGroupErr.Error())
+					addAPIError(&resp.Diagnostics, "failed to remove user from group", removeFromGroupErr)
 				}
 				tflog.Trace(ctx, "User was removed from group",
 					map[string]interface{}{"groupId": currentState.GroupId, "email": currentState.Email})
@@ -263,17 +285,17 @@ func (r *UserGroupMembershipResource) Update(ctx context.Context, req resource.U
 	tflog.Trace(ctx, "User was not part of this group, removing any related invitations",
 		map[string]interface{}{"groupId": currentState.GroupId, "email": currentState.Email})
 
-	var invitations, getInvitesErr = client.GetInvitations(ctx, r.qlient)
+	var invitations, getInvitesErr = getAllInvitations(ctx, r.qlient)
 
 	if getInvitesErr != nil {
-		resp.Diagnostics.AddError("failed to get invitations", getInvitesErr.Error())
+		addAPIError(&resp.Diagnostics, "failed to get invitations", getInvitesErr)
 		return
 	}
 
-	for _, invite := range invitations.IamGetAccount.AccountInvitations.Items {
+	for _, invite := range invitations {
 		if invite.Email == currentState.Email.ValueString() && invite.GroupId == currentState.GroupId.ValueString() {
 			if _, removeInviteErr := client.RevokeInvitation(ctx, r.qlient, invite.Id); removeInviteErr != nil {
-				resp.Diagnostics.AddError("failed to remove invitation", removeInviteErr.Error())
+				addAPIError(&resp.Diagnostics, "failed to remove invitation", removeInviteErr)
 			}
 			tflog.Trace(ctx, "Invitation was revoked",
 				map[string]interface{}{"groupId": currentState.GroupId, "email": currentState.Email})
@@ -289,7 +311,7 @@ func (r *UserGroupMembershipResource) Update(ctx context.Context, req resource.U
 	)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create user group membership", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create user group membership", err)
 		return
 	}
 
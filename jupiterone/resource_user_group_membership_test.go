@@ -85,9 +85,9 @@ func userGroupMembershipExistsHelper(ctx context.Context, s *terraform.State, ql
 			}
 
 			// If no user or user does not have a group, check to make sure the user does not have an pending invitations
-			var invitations, _ = client.GetInvitations(ctx, qlient)
+			var invitations, _ = getAllInvitations(ctx, qlient)
 
-			for _, invite := range invitations.IamGetAccount.AccountInvitations.Items {
+			for _, invite := range invitations {
 				if invite.Email == email && invite.GroupId == groupId && invite.Status != "REVOKED" {
 					return nil
 				}
@@ -147,9 +147,9 @@ func userGroupMembershipDestroyHelper(ctx context.Context, s *terraform.State, q
 			}
 
 			// Check to make sure the user does not have an pending invitations
-			var invitations, _ = client.GetInvitations(ctx, qlient)
+			var invitations, _ = getAllInvitations(ctx, qlient)
 
-			for _, invite := range invitations.IamGetAccount.AccountInvitations.Items {
+			for _, invite := range invitations {
 				if invite.Email == email && invite.GroupId == groupId && invite.Status != "REVOKED" {
 					return retry.RetryableError(fmt.Errorf("Invite still exists (email=%q, groupId=%q)", email, groupId))
 				}
@@ -2,7 +2,6 @@ package jupiterone
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -35,20 +34,22 @@ var WidgetTypes = []string{
 type WidgetResource struct {
 	version string
 	qlient  graphql.Client
+	j1ql    *j1qlSettings
 }
 
 type WidgetQuery struct {
-	Name  types.String `json:"name" tfsdk:"name"`
-	Query types.String `json:"query" tfsdk:"query"`
+	Name  types.String   `json:"name" tfsdk:"name"`
+	Query J1QLQueryValue `json:"query" tfsdk:"query"`
 }
 
 type WidgetConfig struct {
 	Queries  []WidgetQuery `json:"queries,omitempty" tfsdk:"queries"`
-	Settings types.String  `json:"settings,omitempty" tfsdk:"settings"`
+	Settings JSONValue     `json:"settings,omitempty" tfsdk:"settings"`
 }
 
 type WidgetModel struct {
 	Id          types.String `json:"id,omitempty" tfsdk:"id"`
+	AccountId   types.String `json:"account_id,omitempty" tfsdk:"account_id"`
 	Title       types.String `json:"title,omitempty" tfsdk:"title"`
 	Description types.String `json:"description,omitempty" tfsdk:"description"`
 	Type        types.String `json:"type" tfsdk:"type"`
@@ -83,6 +84,18 @@ func (r *WidgetResource) Configure(ctx context.Context, req resource.ConfigureRe
 
 	r.version = p.version
 	r.qlient = p.Qlient
+	r.j1ql = &p.j1ql
+}
+
+// ConfigValidators implements resource.ResourceWithConfigValidators
+func (r *WidgetResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		j1qlValidator{
+			settings: r.j1ql,
+			queries:  path.MatchRoot("config").AtName("queries").AtAnyListIndex().AtName("query"),
+			widget:   true,
+		},
+	}
 }
 
 // Create implements resource.Resource.
@@ -95,6 +108,8 @@ func (r *WidgetResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	widgetInput, err := data.BuildCreateInsightsWidgetInput()
 	if err != nil {
 		resp.Diagnostics.AddError("failed to build widget input from configuration", err.Error())
@@ -109,7 +124,7 @@ func (r *WidgetResource) Create(ctx context.Context, req resource.CreateRequest,
 	)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to create widget entity", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to create widget entity", err)
 		return
 	}
 
@@ -133,8 +148,10 @@ func (r *WidgetResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	if _, err := client.DeleteWidget(ctx, r.qlient, data.DashboardId.ValueString(), data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("failed to delete widget", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to delete widget", err)
 	}
 }
 
@@ -148,27 +165,22 @@ func (r *WidgetResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
+
 	// Fetch the widget data from the API
 	response, err := client.GetWidget(ctx, r.qlient, data.DashboardId.ValueString(), "Account", data.Id.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if client.IsNotFound(err) || strings.Contains(err.Error(), "not found") {
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError("failed to get widget", err.Error())
+			addAPIError(&resp.Diagnostics, "failed to get widget", err)
 		}
 		return
 	}
 
-	// Marshal API response to JSON
-	jsonData, err := json.Marshal(response.GetWidget.Widget)
-	if err != nil {
-		resp.Diagnostics.AddError("failed to marshal json from widget api", err.Error())
-		return
-	}
-
 	// Unmarshal JSON response into a map
 	var widgetMap map[string]interface{}
-	err = json.Unmarshal(jsonData, &widgetMap)
+	err = response.GetWidget.Widget.Decode(&widgetMap)
 	if err != nil {
 		resp.Diagnostics.AddError("failed to unmarshal widget settings", err.Error())
 		return
@@ -179,16 +191,12 @@ func (r *WidgetResource) Read(ctx context.Context, req resource.ReadRequest, res
 	if config, ok := widgetMap["config"].(map[string]interface{}); ok {
 		// Convert settings to JSON string
 		if settings, ok := config["settings"]; ok {
-			settingsJson, err := json.Marshal(settings)
+			settingsJson, err := client.NewJSON(settings)
 			if err != nil {
 				resp.Diagnostics.AddError("failed to marshal settings to JSON", err.Error())
 				return
 			}
-			if string(settingsJson) != "{}" {
-				widgetConfig.Settings = types.StringValue(string(settingsJson))
-			} else {
-				widgetConfig.Settings = types.StringNull()
-			}
+			widgetConfig.Settings = readWidgetSettings(settingsJson)
 		}
 
 		// Process queries
@@ -201,7 +209,7 @@ func (r *WidgetResource) Read(ctx context.Context, req resource.ReadRequest, res
 						widgetQuery.Name = types.StringValue(name)
 					}
 					if queryString, ok := queryMap["query"].(string); ok {
-						widgetQuery.Query = types.StringValue(queryString)
+						widgetQuery.Query = NewJ1QLQueryValue(queryString)
 					}
 					widgetConfig.Queries[i] = widgetQuery
 				}
@@ -226,7 +234,7 @@ func (r *WidgetResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 // ImportState implements resource.ResourceWithImportState
 func (*WidgetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithAccount(ctx, path.Root("id"), req, resp)
 }
 
 // Schema implements resource.Resource.
@@ -240,6 +248,7 @@ func (*WidgetResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"account_id": resourceAccountIdAttribute(),
 			"title": schema.StringAttribute{
 				Required:    true,
 				Description: "The title of the widget.",
@@ -261,6 +270,7 @@ func (*WidgetResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Attributes: map[string]schema.Attribute{
 					"settings": schema.StringAttribute{
 						Optional:    true,
+						CustomType:  JSONType{},
 						Description: "The settings for the widget. This is a flexible JSON structure.",
 					},
 					"queries": schema.ListNestedAttribute{
@@ -273,8 +283,16 @@ func (*WidgetResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 									Description: "The query name.",
 								},
 								"query": schema.StringAttribute{
-									Required:    true,
+									CustomType:  J1QLQueryType{},
+									Optional:    true,
+									Computed:    true,
 									Description: "The query.",
+									Validators: []validator.String{
+										configuredQuery(),
+									},
+									PlanModifiers: []planmodifier.String{
+										useStateForEquivalentQuery(),
+									},
 								},
 							},
 						},
@@ -300,15 +318,11 @@ func (r *WidgetResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	settings := map[string]interface{}{}
+	ctx, data.AccountId = withAccountContext(ctx, r.qlient, data.AccountId)
 
-	if data.Config.Settings.IsNull() {
-		settings = make(map[string]interface{})
-	} else {
-		err := json.Unmarshal([]byte(data.Config.Settings.ValueString()), &settings)
-		if err != nil {
-			resp.Diagnostics.AddError("failed to unencode widget settings", err.Error())
-		}
+	settings, err := buildWidgetSettings(data.Config.Settings)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to unencode widget settings", err.Error())
 	}
 
 	queries := make([]client.WidgetQuery, len(data.Config.Queries))
@@ -331,7 +345,7 @@ func (r *WidgetResource) Update(ctx context.Context, req resource.UpdateRequest,
 		Config:      config,
 	}
 
-	_, err := client.UpdateWidget(
+	_, err = client.UpdateWidget(
 		ctx,
 		r.qlient,
 		data.DashboardId.ValueString(),
@@ -340,7 +354,7 @@ func (r *WidgetResource) Update(ctx context.Context, req resource.UpdateRequest,
 	)
 
 	if err != nil {
-		resp.Diagnostics.AddError("failed to update widget", err.Error())
+		addAPIError(&resp.Diagnostics, "failed to update widget", err)
 		return
 	}
 
@@ -352,15 +366,9 @@ func (r *WidgetResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 func (r *WidgetModel) BuildCreateInsightsWidgetInput() (client.CreateInsightsWidgetInput, error) {
 
-	settings := map[string]interface{}{}
-
-	if r.Config.Settings.IsNull() {
-		settings = make(map[string]interface{})
-	} else {
-		err := json.Unmarshal([]byte(r.Config.Settings.ValueString()), &settings)
-		if err != nil {
-			return client.CreateInsightsWidgetInput{}, err
-		}
+	settings, err := buildWidgetSettings(r.Config.Settings)
+	if err != nil {
+		return client.CreateInsightsWidgetInput{}, err
 	}
 
 	queries := make([]client.CreateInsightsWidgetConfigQueryInput, len(r.Config.Queries))
@@ -386,3 +394,22 @@ func (r *WidgetModel) BuildCreateInsightsWidgetInput() (client.CreateInsightsWid
 
 	return widget, nil
 }
+
+// buildWidgetSettings returns the settings of the widget for the API, an
+// empty object when they are not configured.
+func buildWidgetSettings(settings JSONValue) (client.JSON, error) {
+	if settings.IsNull() {
+		return client.JSON("{}"), nil
+	}
+	return settings.JSON()
+}
+
+// readWidgetSettings returns the settings of a widget from the API, null when
+// they are empty.
+func readWidgetSettings(settings client.JSON) JSONValue {
+	var fields map[string]interface{}
+	if err := settings.Decode(&fields); err == nil && len(fields) == 0 {
+		return NewJSONNull()
+	}
+	return NewJSONValueFrom(settings)
+}